@@ -0,0 +1,95 @@
+// Command dedupe-users reports accounts whose username or email differ only in letter case,
+// which keep the unique user indexes from being created. With -rename-usernames the oldest
+// account of each username keeps it and the others are renamed to "<username>_<id suffix>";
+// bookings stay under the original username, so check the report before renaming. Duplicate
+// emails are only reported: decide per case which account to keep. Once no duplicates are
+// left the indexes are created.
+//
+//	go run ./cmd/dedupe-users
+//	go run ./cmd/dedupe-users -rename-usernames
+package main
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+)
+
+// maxUsernameLength matches the validation of RegisterUserRequest
+const maxUsernameLength = 20
+
+func main() {
+	renameUsernames := flag.Bool("rename-usernames", false, "rename every account but the oldest of each duplicate username")
+	actor := flag.String("actor", "dedupe-cli", "name recorded as the actor in the audit log")
+	flag.Parse()
+
+	db := configs.ConnectDB()
+	userRepo := repository.NewUserRepository(db)
+	auditLog := usecase.NewAuditUsecase(repository.NewAuditRepository(db))
+	ctx := audit.WithActor(context.Background(), audit.Actor{UserID: *actor, UserName: *actor, Role: constants.RoleAdmin})
+
+	usernameGroups, err := userRepo.FindDuplicateUsers(ctx, "username")
+	if err != nil {
+		log.Fatalf("Report failed: %v", err)
+	}
+	emailGroups, err := userRepo.FindDuplicateUsers(ctx, "email")
+	if err != nil {
+		log.Fatalf("Report failed: %v", err)
+	}
+	printGroups(usernameGroups)
+	printGroups(emailGroups)
+
+	if *renameUsernames {
+		for _, group := range usernameGroups {
+			for _, user := range group.Users[1:] {
+				renameUser(ctx, userRepo, auditLog, user)
+			}
+		}
+		if usernameGroups, err = userRepo.FindDuplicateUsers(ctx, "username"); err != nil {
+			log.Fatalf("Report failed: %v", err)
+		}
+	}
+
+	if len(usernameGroups) > 0 || len(emailGroups) > 0 {
+		fmt.Printf("⚠️ %d duplicate username(s), %d duplicate email(s); the unique indexes are not created yet\n", len(usernameGroups), len(emailGroups))
+		return
+	}
+	if err := userRepo.EnsureUserIndexes(ctx); err != nil {
+		log.Fatalf("Index creation failed: %v", err)
+	}
+	fmt.Println("✅ No duplicate accounts; unique username and email indexes are in place")
+}
+
+func printGroups(groups []models.DuplicateUserGroup) {
+	for _, group := range groups {
+		fmt.Printf("%s %q is shared by %d accounts (oldest first):\n", group.Field, group.Value, len(group.Users))
+		for _, user := range group.Users {
+			fmt.Printf("   %s  %-20s %-30s %-5s %s\n", user.ID, user.Username, user.Email, user.Role, user.CreatedAt.Format(time.RFC3339))
+		}
+	}
+}
+
+func renameUser(ctx context.Context, userRepo repository.UserRepositoryInterface, auditLog usecase.AuditRecorder, user models.UserModel) {
+	suffix := "_" + user.ID[len(user.ID)-6:]
+	base := []rune(user.Username)
+	if len(base)+len(suffix) > maxUsernameLength {
+		base = base[:maxUsernameLength-len(suffix)]
+	}
+	newUsername := string(base) + suffix
+
+	if err := userRepo.RenameUser(ctx, user.ID, newUsername, time.Now()); err != nil {
+		log.Printf("⚠️ %s: cannot rename %q to %q: %v", user.ID, user.Username, newUsername, err)
+		return
+	}
+	auditLog.Record(ctx, constants.AuditUserRename, constants.AuditTargetUser, user.ID,
+		map[string]interface{}{"username": user.Username}, map[string]interface{}{"username": newUsername})
+	fmt.Printf("✏️ %s renamed %q to %q\n", user.ID, user.Username, newUsername)
+}
@@ -0,0 +1,71 @@
+// Command generate-jwt-key writes a new JWT signing key as a PKCS#8 PEM file and prints the
+// JWT_KEYS entry for it. Add the entry next to the current key with an activation time to
+// schedule the rotation; the new public key is published in the JWKS right away.
+//
+//	go run ./cmd/generate-jwt-key -kid 2026-10 -alg EdDSA -out keys/2026-10.pem -activate 2026-11-01T00:00:00Z
+package main
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	kid := flag.String("kid", "", "key ID put in the kid header of the tokens it signs")
+	alg := flag.String("alg", "EdDSA", "EdDSA or RS256")
+	bits := flag.Int("bits", 3072, "RSA key size")
+	out := flag.String("out", "", "PEM file to write")
+	activate := flag.String("activate", "", "RFC 3339 time the key starts signing (default: immediately)")
+	flag.Parse()
+
+	if *kid == "" || *out == "" {
+		log.Fatal("-kid and -out are required")
+	}
+	if *activate != "" {
+		if _, err := time.Parse(time.RFC3339, *activate); err != nil {
+			log.Fatalf("Invalid -activate: %v", err)
+		}
+	}
+
+	var key crypto.Signer
+	var err error
+	switch *alg {
+	case "EdDSA":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case "RS256":
+		key, err = rsa.GenerateKey(rand.Reader, *bits)
+	default:
+		log.Fatalf("Unsupported -alg %q, use EdDSA or RS256", *alg)
+	}
+	if err != nil {
+		log.Fatalf("Key generation failed: %v", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		log.Fatalf("Cannot encode the key: %v", err)
+	}
+	file, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		log.Fatalf("Cannot create %s: %v", *out, err)
+	}
+	defer file.Close()
+	if err := pem.Encode(file, &pem.Block{Type: "PRIVATE KEY", Bytes: der}); err != nil {
+		log.Fatalf("Cannot write %s: %v", *out, err)
+	}
+
+	entry := *kid + "=" + *out
+	if *activate != "" {
+		entry += "@" + *activate
+	}
+	fmt.Printf("✅ Wrote %s key %s\nAdd to JWT_KEYS: %s\n", *alg, *out, entry)
+}
@@ -0,0 +1,80 @@
+// Command import-ocm imports stations from an Open Charge Map JSON export. Sites are upserted by
+// the external_ref "ocm:<ID>"; sites close to a similarly named station are skipped as duplicates.
+// Use -dry-run to only print the report.
+//
+//	go run ./cmd/import-ocm -file poi.json -price 7.5 -dry-run
+package main
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	file := flag.String("file", "", "Open Charge Map JSON export")
+	price := flag.Float64("price", 0, "price per unit given to every imported connector")
+	timeZone := flag.String("time-zone", constants.DefaultTimeZone, "IANA time zone of the imported stations")
+	radius := flag.Float64("radius", 50, "metres within which a similarly named station is a duplicate")
+	dryRun := flag.Bool("dry-run", false, "validate and report without writing")
+	actor := flag.String("actor", "ocm-import-cli", "name recorded as the actor in the audit log")
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("-file is required")
+	}
+	if *price <= 0 {
+		log.Fatal("-price is required, Open Charge Map exports carry no prices")
+	}
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("Cannot read %s: %v", *file, err)
+	}
+
+	db := configs.ConnectDB()
+	auditUsecase := usecase.NewAuditUsecase(repository.NewAuditRepository(db))
+	stationUsecase := usecase.NewEVStationUsecase(repository.NewEVStationRepository(db), repository.NewVehicleRepository(db), auditUsecase, nil)
+
+	ctx := audit.WithActor(context.Background(), audit.Actor{UserID: *actor, UserName: *actor, Role: constants.RoleAdmin})
+	report, err := stationUsecase.ImportOpenChargeMap(ctx, request.ImportOpenChargeMapRequest{
+		DryRun:       *dryRun,
+		PricePerUnit: *price,
+		TimeZone:     *timeZone,
+		DedupeRadius: *radius,
+		Data:         data,
+	})
+	if err != nil {
+		log.Fatalf("Import stopped: %v", err)
+	}
+
+	for _, row := range report.Rows {
+		switch row.Action {
+		case constants.ImportActionError:
+			for _, e := range row.Errors {
+				fmt.Printf("⚠️ site %d %s: %s %s\n", row.Row, row.ExternalRef, e.Field, e.Message)
+			}
+		case constants.ImportActionDuplicate:
+			fmt.Printf("site %d %s: duplicate of %s\n", row.Row, row.ExternalRef, row.DuplicateOf)
+		default:
+			fmt.Printf("site %d %s: %s %s\n", row.Row, row.ExternalRef, row.Action, row.StationID)
+		}
+	}
+	for _, t := range report.UnmappedConnectionTypes {
+		fmt.Printf("🔌 unmapped connection type %d %q: %d connection(s) skipped\n", t.ID, t.Title, t.Connections)
+	}
+
+	prefix := "✅"
+	if report.DryRun {
+		prefix = "🔎 Dry run:"
+	}
+	fmt.Printf("%s %d created, %d updated, %d unchanged, %d duplicate, %d failed of %d site(s)\n",
+		prefix, report.Created, report.Updated, report.Unchanged, report.Duplicates, report.Failed, report.Total)
+}
@@ -0,0 +1,79 @@
+// Command import-stations upserts stations from a CSV or JSON file by external_ref,
+// the same way as POST /stations/import. Use -dry-run to only print the report.
+//
+//	go run ./cmd/import-stations -file stations.csv -dry-run
+package main
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	file := flag.String("file", "", "CSV or JSON file of stations")
+	format := flag.String("format", "", "csv or json (default: from the file extension)")
+	dryRun := flag.Bool("dry-run", false, "validate and report without writing")
+	actor := flag.String("actor", "import-cli", "name recorded as the actor in the audit log")
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("-file is required")
+	}
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("Cannot read %s: %v", *file, err)
+	}
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(*file)) {
+		case ".csv":
+			*format = constants.ImportFormatCSV
+		case ".json":
+			*format = constants.ImportFormatJSON
+		}
+	}
+
+	db := configs.ConnectDB()
+	auditUsecase := usecase.NewAuditUsecase(repository.NewAuditRepository(db))
+	stationUsecase := usecase.NewEVStationUsecase(repository.NewEVStationRepository(db), repository.NewVehicleRepository(db), auditUsecase, nil)
+
+	ctx := audit.WithActor(context.Background(), audit.Actor{UserID: *actor, UserName: *actor, Role: constants.RoleAdmin})
+	report, err := stationUsecase.ImportStations(ctx, request.ImportStationsRequest{
+		Format: *format,
+		DryRun: *dryRun,
+		Data:   data,
+	})
+	if err != nil {
+		log.Fatalf("Import stopped: %v", err)
+	}
+
+	for _, row := range report.Rows {
+		if row.Action != constants.ImportActionError {
+			fmt.Printf("row %d %s: %s %s\n", row.Row, row.ExternalRef, row.Action, row.StationID)
+			continue
+		}
+		for _, e := range row.Errors {
+			fmt.Printf("⚠️ row %d %s: %s %s\n", row.Row, row.ExternalRef, e.Field, e.Message)
+		}
+	}
+
+	prefix := "✅"
+	if report.DryRun {
+		prefix = "🔎 Dry run:"
+	}
+	fmt.Printf("%s %d created, %d updated, %d unchanged, %d failed of %d station(s)\n",
+		prefix, report.Created, report.Updated, report.Unchanged, report.Failed, report.Total)
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
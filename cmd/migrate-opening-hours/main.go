@@ -0,0 +1,52 @@
+// Command migrate-opening-hours converts legacy free-text station hours
+// (status.open_hours / status.close_hours) into structured weekly schedules. Stations marked
+// closed (status.is_open=false) would reopen on their schedule; they are listed and left alone
+// unless -include-closed is given.
+//
+//	go run ./cmd/migrate-opening-hours -time-zone Asia/Bangkok
+//	go run ./cmd/migrate-opening-hours -time-zone Asia/Bangkok -include-closed
+package main
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+)
+
+func main() {
+	timeZone := flag.String("time-zone", constants.DefaultTimeZone, "IANA time zone assigned to migrated stations")
+	includeClosed := flag.Bool("include-closed", false, "also migrate stations marked closed, which then open on their schedule")
+	flag.Parse()
+
+	if _, err := time.LoadLocation(*timeZone); err != nil {
+		log.Fatalf("Invalid time zone %q: %v", *timeZone, err)
+	}
+
+	db := configs.ConnectDB()
+	stationRepo := repository.NewEVStationRepository(db)
+
+	report, err := stationRepo.MigrateLegacyOpeningHours(context.Background(), *timeZone, *includeClosed)
+	if err != nil {
+		log.Fatalf("Migration stopped: %v", err)
+	}
+
+	fmt.Printf("✅ Migrated %d station(s) to structured opening hours\n", report.Migrated)
+	for id, reason := range report.Failed {
+		fmt.Printf("⚠️ %s: %s\n", id, reason)
+	}
+	if len(report.Closed) > 0 && !*includeClosed {
+		fmt.Printf("⏸️ %d station(s) are marked closed and were left as they are; close them with an exception or rerun with -include-closed:\n", len(report.Closed))
+	}
+	for _, id := range report.Closed {
+		if *includeClosed {
+			fmt.Printf("⚠️ %s was marked closed and now opens on its schedule\n", id)
+		} else {
+			fmt.Printf("   %s\n", id)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Command provision-admin creates the first ADMIN account, or promotes an existing account,
+// now that POST /users/register only creates USER accounts. The password of a new account
+// is read from ADMIN_PASSWORD, or from stdin when that is not set.
+//
+//	ADMIN_PASSWORD=... go run ./cmd/provision-admin -username root -email root@example.com
+//	go run ./cmd/provision-admin -promote alice
+package main
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	username := flag.String("username", "", "username of the new ADMIN account")
+	email := flag.String("email", "", "email of the new ADMIN account")
+	promote := flag.String("promote", "", "username or email of an existing account to make ADMIN")
+	actor := flag.String("actor", "provision-cli", "name recorded as the actor in the audit log")
+	flag.Parse()
+
+	if *promote == "" && (*username == "" || *email == "") {
+		log.Fatal("either -promote, or -username and -email, is required")
+	}
+
+	db := configs.ConnectDB()
+	userRepo := repository.NewUserRepository(db)
+	userUsecase := usecase.NewUserUsecase(userRepo, usecase.NewAuditUsecase(repository.NewAuditRepository(db)), nil, nil, nil, nil)
+	ctx := audit.WithActor(context.Background(), audit.Actor{UserID: *actor, UserName: *actor, Role: constants.RoleAdmin})
+
+	var user *response.UserResponse
+	if *promote != "" {
+		existing, err := userRepo.FindByUsernameOrEmail(ctx, *promote)
+		if err != nil || existing == nil {
+			log.Fatalf("No account %q", *promote)
+		}
+		user, err = userUsecase.ChangeUserRole(ctx, request.ChangeUserRoleRequest{UserID: existing.ID, Role: constants.RoleAdmin})
+		if err != nil {
+			log.Fatalf("Promotion failed: %v", err)
+		}
+	} else {
+		password, err := readPassword()
+		if err != nil {
+			log.Fatalf("Cannot read the password: %v", err)
+		}
+		if len(password) < 6 {
+			log.Fatal("The password must be at least 6 characters")
+		}
+		user, err = userUsecase.ProvisionUser(ctx, request.ProvisionUserRequest{
+			Username: *username,
+			Email:    *email,
+			Password: password,
+			Role:     constants.RoleAdmin,
+		})
+		if err != nil {
+			log.Fatalf("Provisioning failed: %v", err)
+		}
+	}
+
+	fmt.Printf("✅ %s (%s) is %s\n", user.Username, user.ID, user.Role)
+}
+
+func readPassword() (string, error) {
+	if password := os.Getenv("ADMIN_PASSWORD"); password != "" {
+		return password, nil
+	}
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
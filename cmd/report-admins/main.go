@@ -0,0 +1,40 @@
+// Command report-admins lists every ADMIN account. Accounts without created_by were not
+// provisioned: they chose the ADMIN role on the public register endpoint before it was
+// restricted to USER, and should be reviewed and demoted where needed.
+//
+//	go run ./cmd/report-admins
+package main
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+func main() {
+	db := configs.ConnectDB()
+	userUsecase := usecase.NewUserUsecase(repository.NewUserRepository(db), nil, nil, nil, nil, nil)
+
+	admins, err := userUsecase.GetAdminAccounts(audit.WithActor(context.Background(), audit.SystemActor))
+	if err != nil {
+		log.Fatalf("Report failed: %v", err)
+	}
+
+	selfRegistered := 0
+	for _, admin := range admins {
+		provisioning := "provisioned by " + admin.CreatedBy
+		prefix := "  "
+		if admin.CreatedBy == "" {
+			provisioning = "self-registered"
+			prefix = "⚠️"
+			selfRegistered++
+		}
+		fmt.Printf("%s %s  %-20s %-30s %s  %s\n", prefix, admin.ID, admin.Username, admin.Email, admin.CreatedAt.Format(time.RFC3339), provisioning)
+	}
+	fmt.Printf("%d ADMIN account(s), %d self-registered\n", len(admins), selfRegistered)
+}
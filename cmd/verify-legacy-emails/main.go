@@ -0,0 +1,27 @@
+// Command verify-legacy-emails marks accounts created before email verification existed as
+// verified, so that BOOKING_REQUIRES_VERIFIED_EMAIL does not lock them out of booking. Accounts
+// that registered since, and have not verified yet, are left alone.
+//
+//	go run ./cmd/verify-legacy-emails
+package main
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+func main() {
+	db := configs.ConnectDB()
+	userRepo := repository.NewUserRepository(db)
+
+	marked, err := userRepo.MarkLegacyEmailsVerified(context.Background(), time.Now())
+	if err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+
+	fmt.Printf("✅ Marked %d existing account(s) as verified\n", marked)
+}
@@ -0,0 +1,47 @@
+package configs
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// JWTConfig lists the asymmetric signing keys and whether HS256 tokens are still accepted
+type JWTConfig struct {
+	Keys        []JWTKeyConfig // JWT_KEYS, comma separated "kid=path.pem" or "kid=path.pem@2026-07-01T00:00:00Z"
+	AcceptHS256 bool           // JWT_ACCEPT_HS256, default true; set false once HS256 tokens have expired
+}
+
+// JWTKeyConfig is one PEM file; a private key signs from ActivatesAt on, a public key only verifies
+type JWTKeyConfig struct {
+	ID          string
+	Path        string
+	ActivatesAt time.Time
+}
+
+func LoadJWTConfig() (JWTConfig, error) {
+	config := JWTConfig{AcceptHS256: os.Getenv("JWT_ACCEPT_HS256") != "false"}
+
+	for _, entry := range strings.Split(os.Getenv("JWT_KEYS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, path, ok := strings.Cut(entry, "=")
+		if !ok || id == "" || path == "" {
+			return JWTConfig{}, fmt.Errorf("JWT_KEYS entry %q is not kid=path", entry)
+		}
+
+		key := JWTKeyConfig{ID: id, Path: path}
+		if path, activatesAt, scheduled := strings.Cut(path, "@"); scheduled {
+			parsed, err := time.Parse(time.RFC3339, activatesAt)
+			if err != nil {
+				return JWTConfig{}, fmt.Errorf("JWT_KEYS entry %q: activation time must be RFC 3339", entry)
+			}
+			key.Path, key.ActivatesAt = path, parsed
+		}
+		config.Keys = append(config.Keys, key)
+	}
+	return config, nil
+}
@@ -0,0 +1,48 @@
+package configs
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// LoginProtectionConfig limits failed logins per account and per client IP
+type LoginProtectionConfig struct {
+	// A known account's username and email share one counter. An unknown name cannot be tied to
+	// any other name, so it keeps its own counter: once a username is locked, a single attempt with
+	// an email shows whether both belong to the same account. The IP counter bounds such probing.
+	MaxAccountFailures int           // LOGIN_MAX_ACCOUNT_FAILURES, failures before the account is locked, default 5
+	MaxIPFailures      int           // LOGIN_MAX_IP_FAILURES, failures before the IP is locked, default 20
+	FailureWindow      time.Duration // LOGIN_FAILURE_WINDOW, failures older than this are forgotten, default 15 minutes
+	LockoutDuration    time.Duration // LOGIN_LOCKOUT_DURATION, default 15 minutes
+	BaseDelay          time.Duration // LOGIN_BASE_DELAY, wait after the first failure, doubled after each further one, default 1 second
+	MaxDelay           time.Duration // LOGIN_MAX_DELAY, default 30 seconds
+	Store              string        // LOGIN_ATTEMPT_STORE, "memory" for a single instance or "mongo" to share between instances, default memory
+}
+
+func LoadLoginProtectionConfig() LoginProtectionConfig {
+	return LoginProtectionConfig{
+		MaxAccountFailures: intFromEnv("LOGIN_MAX_ACCOUNT_FAILURES", 5),
+		MaxIPFailures:      intFromEnv("LOGIN_MAX_IP_FAILURES", 20),
+		FailureWindow:      durationFromEnv("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+		LockoutDuration:    durationFromEnv("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		BaseDelay:          durationFromEnv("LOGIN_BASE_DELAY", time.Second),
+		MaxDelay:           durationFromEnv("LOGIN_MAX_DELAY", 30*time.Second),
+		Store:              stringFromEnv("LOGIN_ATTEMPT_STORE", "memory"),
+	}
+}
+
+func intFromEnv(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		log.Printf("⚠️ invalid %s=%q, using %d", key, value, fallback)
+		return fallback
+	}
+	return number
+}
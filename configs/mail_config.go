@@ -0,0 +1,61 @@
+package configs
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// MailConfig selects how emails are delivered: through SMTP when SMTP_HOST is set, otherwise
+// as .eml files in MAIL_DIR
+type MailConfig struct {
+	SMTPHost     string // SMTP_HOST
+	SMTPPort     int    // SMTP_PORT, default 587
+	SMTPUsername string // SMTP_USERNAME, empty disables authentication
+	SMTPPassword string // SMTP_PASSWORD
+	From         string // MAIL_FROM, default no-reply@evchargehub.local
+	Dir          string // MAIL_DIR, default ./mail_outbox
+}
+
+// PasswordResetConfig controls the emailed password reset links
+type PasswordResetConfig struct {
+	TokenTTL time.Duration // PASSWORD_RESET_TTL, default 1 hour
+	URL      string        // PASSWORD_RESET_URL, page of the client app the token is appended to
+}
+
+// EmailVerificationConfig controls the link mailed at registration and whether bookings need a verified email
+type EmailVerificationConfig struct {
+	TokenTTL           time.Duration // EMAIL_VERIFICATION_TTL, default 48 hours
+	URL                string        // EMAIL_VERIFICATION_URL, default the GET /users/verify endpoint of this server
+	RequiredForBooking bool          // BOOKING_REQUIRES_VERIFIED_EMAIL, default true
+}
+
+func LoadMailConfig() MailConfig {
+	port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+	if err != nil || port <= 0 {
+		port = 587
+	}
+	return MailConfig{
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     port,
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		From:         stringFromEnv("MAIL_FROM", "no-reply@evchargehub.local"),
+		Dir:          stringFromEnv("MAIL_DIR", "mail_outbox"),
+	}
+}
+
+func LoadPasswordResetConfig() PasswordResetConfig {
+	return PasswordResetConfig{
+		TokenTTL: durationFromEnv("PASSWORD_RESET_TTL", time.Hour),
+		URL:      stringFromEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+	}
+}
+
+func LoadEmailVerificationConfig() EmailVerificationConfig {
+	return EmailVerificationConfig{
+		TokenTTL:           durationFromEnv("EMAIL_VERIFICATION_TTL", 48*time.Hour),
+		URL:                stringFromEnv("EMAIL_VERIFICATION_URL", "http://localhost:8080/users/verify"),
+		RequiredForBooking: os.Getenv("BOOKING_REQUIRES_VERIFIED_EMAIL") != "false",
+	}
+}
@@ -0,0 +1,34 @@
+package configs
+
+import (
+	"os"
+	"strings"
+)
+
+// OCPIConfig identifies this platform to roaming partners
+type OCPIConfig struct {
+	BaseURL      string // OCPI_BASE_URL, public URL of this server; our endpoint URLs are built from it
+	CountryCode  string // OCPI_COUNTRY_CODE, ISO 3166-1 alpha-2, default TH
+	PartyID      string // OCPI_PARTY_ID, three characters, default EVH
+	BusinessName string // OCPI_BUSINESS_NAME
+	Country      string // OCPI_COUNTRY, ISO 3166-1 alpha-3 country of the locations, default THA
+	City         string // OCPI_CITY, city reported for locations (stations carry no address yet)
+}
+
+func LoadOCPIConfig() OCPIConfig {
+	return OCPIConfig{
+		BaseURL:      strings.TrimRight(stringFromEnv("OCPI_BASE_URL", "http://localhost:8080"), "/"),
+		CountryCode:  stringFromEnv("OCPI_COUNTRY_CODE", "TH"),
+		PartyID:      stringFromEnv("OCPI_PARTY_ID", "EVH"),
+		BusinessName: stringFromEnv("OCPI_BUSINESS_NAME", "EV Charge Hub"),
+		Country:      stringFromEnv("OCPI_COUNTRY", "THA"),
+		City:         stringFromEnv("OCPI_CITY", "Bangkok"),
+	}
+}
+
+func stringFromEnv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,16 @@
+package configs
+
+import "time"
+
+// SessionConfig controls how long login tokens live
+type SessionConfig struct {
+	AccessTokenTTL  time.Duration // ACCESS_TOKEN_TTL, default 15 minutes
+	RefreshTokenTTL time.Duration // REFRESH_TOKEN_TTL, default 30 days
+}
+
+func LoadSessionConfig() SessionConfig {
+	return SessionConfig{
+		AccessTokenTTL:  durationFromEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL: durationFromEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+	}
+}
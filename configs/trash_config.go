@@ -0,0 +1,34 @@
+package configs
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+// StationTrashConfig controls how long soft-deleted stations are kept before they are purged
+type StationTrashConfig struct {
+	Retention     time.Duration // STATION_TRASH_RETENTION, default 30 days
+	PurgeInterval time.Duration // STATION_PURGE_INTERVAL, default daily; 0 disables the purge job
+}
+
+func LoadStationTrashConfig() StationTrashConfig {
+	return StationTrashConfig{
+		Retention:     durationFromEnv("STATION_TRASH_RETENTION", 30*24*time.Hour),
+		PurgeInterval: durationFromEnv("STATION_PURGE_INTERVAL", 24*time.Hour),
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		log.Printf("⚠️ invalid %s=%q, using %s", key, value, fallback)
+		return fallback
+	}
+	return duration
+}
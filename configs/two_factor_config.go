@@ -0,0 +1,21 @@
+package configs
+
+import (
+	"os"
+	"time"
+)
+
+// TwoFactorConfig controls TOTP two-factor authentication
+type TwoFactorConfig struct {
+	Issuer            string        // TOTP_ISSUER, name shown in authenticator apps, default "EV Charge Hub"
+	ChallengeTTL      time.Duration // TWO_FACTOR_CHALLENGE_TTL, time to enter the code after the password, default 5 minutes
+	RequiredForAdmins bool          // TWO_FACTOR_REQUIRED_FOR_ADMINS, default false; ADMIN accounts then enroll at their next login
+}
+
+func LoadTwoFactorConfig() TwoFactorConfig {
+	return TwoFactorConfig{
+		Issuer:            stringFromEnv("TOTP_ISSUER", "EV Charge Hub"),
+		ChallengeTTL:      durationFromEnv("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute),
+		RequiredForAdmins: os.Getenv("TWO_FACTOR_REQUIRED_FOR_ADMINS") == "true",
+	}
+}
@@ -0,0 +1,44 @@
+// Package audit carries the identity of the caller and the request ID from the HTTP
+// layer down to the usecases, which record them in the audit log.
+package audit
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	"context"
+)
+
+type contextKey int
+
+const (
+	actorKey contextKey = iota
+	requestIDKey
+)
+
+// Actor is the authenticated user a change is made on behalf of
+type Actor struct {
+	UserID   string
+	UserName string
+	Role     string
+}
+
+// SystemActor is the actor of background jobs; it holds the ADMIN role
+var SystemActor = Actor{UserID: constants.AuditSystemActor, UserName: constants.AuditSystemActor, Role: constants.RoleAdmin}
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor; ok is false for unauthenticated calls
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey).(Actor)
+	return actor, ok
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
@@ -0,0 +1,47 @@
+package constants
+
+type AuditAction string
+
+const (
+	AuditStationCreate        AuditAction = "STATION_CREATE"
+	AuditStationEdit          AuditAction = "STATION_EDIT"
+	AuditStationPatch         AuditAction = "STATION_PATCH"
+	AuditStationDelete        AuditAction = "STATION_DELETE"
+	AuditStationRestore       AuditAction = "STATION_RESTORE"
+	AuditStationRevert        AuditAction = "STATION_REVERT"
+	AuditStationImport        AuditAction = "STATION_IMPORT"
+	AuditStationPurge         AuditAction = "STATION_PURGE"
+	AuditConnectorAdd         AuditAction = "CONNECTOR_ADD"
+	AuditConnectorEdit        AuditAction = "CONNECTOR_EDIT"
+	AuditConnectorRemove      AuditAction = "CONNECTOR_REMOVE"
+	AuditBookingSet           AuditAction = "BOOKING_SET"
+	AuditMaintenanceCreate    AuditAction = "MAINTENANCE_CREATE"
+	AuditMaintenanceEdit      AuditAction = "MAINTENANCE_EDIT"
+	AuditMaintenanceRemove    AuditAction = "MAINTENANCE_REMOVE"
+	AuditUserRegister         AuditAction = "USER_REGISTER"
+	AuditUserProvision        AuditAction = "USER_PROVISION"
+	AuditUserRoleChange       AuditAction = "USER_ROLE_CHANGE"
+	AuditUserLogoutAll        AuditAction = "USER_LOGOUT_ALL"
+	AuditRefreshTokenReuse    AuditAction = "REFRESH_TOKEN_REUSE"
+	AuditPasswordResetRequest AuditAction = "PASSWORD_RESET_REQUEST"
+	AuditPasswordReset        AuditAction = "PASSWORD_RESET"
+	AuditUserEmailVerified    AuditAction = "USER_EMAIL_VERIFIED"
+	AuditUserRename           AuditAction = "USER_RENAME"
+	AuditLoginLockout         AuditAction = "LOGIN_LOCKOUT"
+	AuditUserUnlock           AuditAction = "USER_UNLOCK"
+	AuditTwoFactorEnable      AuditAction = "USER_2FA_ENABLE"
+	AuditTwoFactorDisable     AuditAction = "USER_2FA_DISABLE"
+	AuditRecoveryCodesReset   AuditAction = "USER_2FA_RECOVERY_CODES_RESET"
+	AuditRecoveryCodeUse      AuditAction = "USER_2FA_RECOVERY_CODE_USE"
+	AuditTwoFactorLoginEnroll AuditAction = "USER_2FA_LOGIN_ENROLL"
+)
+
+// Audit target types
+const (
+	AuditTargetStation = "STATION"
+	AuditTargetUser    = "USER"
+	AuditTargetIP      = "IP"
+)
+
+// AuditSystemActor is recorded when a change is not made on behalf of a user (e.g. background jobs)
+const AuditSystemActor = "system"
@@ -17,3 +17,34 @@ const (
 	GBTAC    PlugName = "GB/T AC"
 	GBTDC    PlugName = "GB/T DC"
 )
+
+// plugCurrent pairs every known plug with the current it carries
+var plugCurrent = map[PlugName]ConnectorType{
+	J1772:    AC,
+	Type2:    AC,
+	GBTAC:    AC,
+	CCSType1: DC,
+	CCSType2: DC,
+	CHAdeMO:  DC,
+	GBTDC:    DC,
+}
+
+// ConnectorType returns the current (AC/DC) the plug carries; ok is false for unknown plugs
+func (p PlugName) ConnectorType() (ConnectorType, bool) {
+	connectorType, ok := plugCurrent[p]
+	return connectorType, ok
+}
+
+// connectorPowerRange is the output (kW) a connector of each current can sensibly deliver:
+// AC tops out at 43 kW (three-phase 63 A), DC covers wallboxes up to high-power chargers
+var connectorPowerRange = map[ConnectorType][2]int{
+	AC: {1, 43},
+	DC: {5, 500},
+}
+
+// PowerRange returns the lowest and highest power output (kW) accepted for the current;
+// ok is false for unknown connector types
+func (t ConnectorType) PowerRange() (min int, max int, ok bool) {
+	r, ok := connectorPowerRange[t]
+	return r[0], r[1], ok
+}
@@ -0,0 +1,16 @@
+package constants
+
+// File formats served by the station export
+const (
+	ExportFormatCSV     = "csv"
+	ExportFormatGeoJSON = "geojson"
+	ExportFormatKML     = "kml"
+)
+
+// Station CSV columns shared by the export and the import, so an exported file can be edited and
+// imported again. The export writes the export-only columns too; the import skips them.
+var (
+	StationCSVStationColumns    = []string{"external_ref", "name", "latitude", "longitude", "company", "time_zone", "always_open", "open_hours", "close_hours"}
+	StationCSVConnectorColumns  = []string{"connector_id", "connector_type", "plug_name", "price_per_unit", "power_output"}
+	StationCSVExportOnlyColumns = []string{"station_id", "is_open"}
+)
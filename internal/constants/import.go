@@ -0,0 +1,18 @@
+package constants
+
+// File formats accepted by the bulk station import
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatJSON = "json"
+)
+
+// Outcome of one imported station
+const (
+	ImportActionCreate    = "create"
+	ImportActionUpdate    = "update"
+	ImportActionUnchanged = "unchanged"
+	ImportActionError     = "error"
+)
+
+// ImportActionDuplicate marks an Open Charge Map station skipped because it matches a nearby station
+const ImportActionDuplicate = "duplicate"
@@ -0,0 +1,11 @@
+package constants
+
+type MaintenanceScope string
+
+const (
+	MaintenanceScopeStation   MaintenanceScope = "STATION"
+	MaintenanceScopeConnector MaintenanceScope = "CONNECTOR"
+)
+
+// DateTimeLayout is the wire format for booking and maintenance times
+const DateTimeLayout = "2006-01-02T15:04:05"
@@ -0,0 +1,78 @@
+package constants
+
+// OCPIVersion is the only OCPI version this server speaks
+const OCPIVersion = "2.2.1"
+
+// OCPI status codes carried in the response envelope
+const (
+	OCPIStatusSuccess            = 1000
+	OCPIStatusClientError        = 2000
+	OCPIStatusInvalidParams      = 2001
+	OCPIStatusUnknownLocation    = 2003
+	OCPIStatusServerError        = 3000
+	OCPIStatusUnableToUseAPI     = 3001
+	OCPIStatusUnsupportedVersion = 3002
+)
+
+// OCPI module identifiers and interface roles
+const (
+	OCPIModuleCredentials = "credentials"
+	OCPIModuleLocations   = "locations"
+
+	OCPIInterfaceSender   = "SENDER"
+	OCPIInterfaceReceiver = "RECEIVER"
+)
+
+// OCPIRoleCPO is the role this platform plays towards roaming partners
+const OCPIRoleCPO = "CPO"
+
+// Registration state of a roaming partner. A PENDING partner only holds the token handed out
+// by an admin (token A) and may only call the credentials module.
+const (
+	OCPIPartnerPending      = "PENDING"
+	OCPIPartnerRegistered   = "REGISTERED"
+	OCPIPartnerUnregistered = "UNREGISTERED"
+)
+
+// OCPI EVSE statuses
+const (
+	OCPIEVSEAvailable   = "AVAILABLE"
+	OCPIEVSEReserved    = "RESERVED"
+	OCPIEVSEInoperative = "INOPERATIVE"
+	OCPIEVSERemoved     = "REMOVED"
+)
+
+// OCPI power types and connector formats
+const (
+	OCPIPowerAC1Phase = "AC_1_PHASE"
+	OCPIPowerAC3Phase = "AC_3_PHASE"
+	OCPIPowerDC       = "DC"
+
+	OCPIFormatSocket = "SOCKET"
+	OCPIFormatCable  = "CABLE"
+)
+
+// plugOCPIStandard maps every known plug to its OCPI ConnectorType
+var plugOCPIStandard = map[PlugName]string{
+	J1772:    "IEC_62196_T1",
+	Type2:    "IEC_62196_T2",
+	CCSType1: "IEC_62196_T1_COMBO",
+	CCSType2: "IEC_62196_T2_COMBO",
+	CHAdeMO:  "CHADEMO",
+	GBTAC:    "GBT_AC",
+	GBTDC:    "GBT_DC",
+}
+
+// OCPIStandard returns the OCPI connector standard of the plug; ok is false for unknown plugs
+func (p PlugName) OCPIStandard() (string, bool) {
+	standard, ok := plugOCPIStandard[p]
+	return standard, ok
+}
+
+// OCPIFormat tells whether the plug is a socket on the charger or a tethered cable
+func (p PlugName) OCPIFormat() string {
+	if p == Type2 || p == GBTAC {
+		return OCPIFormatSocket
+	}
+	return OCPIFormatCable
+}
@@ -0,0 +1,26 @@
+package constants
+
+// OCMUnknownOperator is the company given to Open Charge Map sites without an operator,
+// matching the title Open Charge Map uses for its own "unknown" operator
+const OCMUnknownOperator = "(Unknown Operator)"
+
+// ocmConnectionPlugs maps Open Charge Map ConnectionTypeID reference values to our plugs.
+// Socket and tethered variants of the same plug map to the same PlugName.
+var ocmConnectionPlugs = map[int]PlugName{
+	1:    J1772,    // Type 1 (J1772)
+	25:   Type2,    // Type 2 (Socket Only)
+	1036: Type2,    // Type 2 (Tethered Connector)
+	32:   CCSType1, // CCS (Type 1)
+	33:   CCSType2, // CCS (Type 2)
+	2:    CHAdeMO,  // CHAdeMO
+	1038: GBTAC,    // GB-T AC - GB/T 20234.2 (Socket)
+	1039: GBTAC,    // GB-T AC - GB/T 20234.2 (Tethered Cable)
+	1040: GBTDC,    // GB-T DC - GB/T 20234.3
+}
+
+// OCMConnectionPlug returns the plug for an Open Charge Map connection type; ok is false
+// for types we do not support (domestic sockets, Tesla, ...)
+func OCMConnectionPlug(connectionTypeID int) (PlugName, bool) {
+	plug, ok := ocmConnectionPlugs[connectionTypeID]
+	return plug, ok
+}
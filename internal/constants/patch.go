@@ -0,0 +1,7 @@
+package constants
+
+// Content types accepted by PATCH endpoints
+const (
+	MergePatchContentType = "application/merge-patch+json" // RFC 7386
+	JSONPatchContentType  = "application/json-patch+json"  // RFC 6902
+)
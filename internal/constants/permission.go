@@ -0,0 +1,20 @@
+package constants
+
+// Permission names one kind of action a role can be granted; see internal/authz for the grants
+type Permission string
+
+const (
+	PermStationRead       Permission = "station:read"
+	PermStationWrite      Permission = "station:write"
+	PermStationTrash      Permission = "station:trash"
+	PermStationImport     Permission = "station:import"
+	PermStationHistory    Permission = "station:history"
+	PermMaintenanceManage Permission = "maintenance:manage"
+	PermBookingRead       Permission = "booking:read"
+	PermBookingWrite      Permission = "booking:write"
+	PermBookingOnBehalf   Permission = "booking:on_behalf"
+	PermVehicleManage     Permission = "vehicle:manage"
+	PermAuditRead         Permission = "audit:read"
+	PermOCPIPartnerManage Permission = "ocpi:partner:manage"
+	PermUserManage        Permission = "user:manage"
+)
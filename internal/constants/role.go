@@ -0,0 +1,6 @@
+package constants
+
+const (
+	RoleAdmin = "ADMIN"
+	RoleUser  = "USER"
+)
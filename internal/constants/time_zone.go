@@ -0,0 +1,4 @@
+package constants
+
+// DefaultTimeZone is used for stations that do not declare their own IANA time zone
+const DefaultTimeZone = "Asia/Bangkok"
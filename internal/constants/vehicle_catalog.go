@@ -0,0 +1,38 @@
+package constants
+
+// VehicleCatalogEntry is a built-in vehicle specification users can base a profile on
+type VehicleCatalogEntry struct {
+	ID             string
+	Make           string
+	Model          string
+	BatteryKWh     float64
+	SupportedPlugs []PlugName
+	MaxACPowerKW   float64
+	MaxDCPowerKW   float64
+}
+
+// VehicleCatalog is the seed list of common vehicle models (usable battery and peak charge power)
+var VehicleCatalog = []VehicleCatalogEntry{
+	{ID: "tesla-model-3-lr", Make: "Tesla", Model: "Model 3 Long Range", BatteryKWh: 75, SupportedPlugs: []PlugName{Type2, CCSType2}, MaxACPowerKW: 11, MaxDCPowerKW: 250},
+	{ID: "tesla-model-y-lr", Make: "Tesla", Model: "Model Y Long Range", BatteryKWh: 75, SupportedPlugs: []PlugName{Type2, CCSType2}, MaxACPowerKW: 11, MaxDCPowerKW: 250},
+	{ID: "byd-atto-3", Make: "BYD", Model: "Atto 3", BatteryKWh: 60.5, SupportedPlugs: []PlugName{Type2, CCSType2}, MaxACPowerKW: 7, MaxDCPowerKW: 88},
+	{ID: "byd-dolphin", Make: "BYD", Model: "Dolphin", BatteryKWh: 44.9, SupportedPlugs: []PlugName{Type2, CCSType2}, MaxACPowerKW: 7, MaxDCPowerKW: 60},
+	{ID: "mg-zs-ev", Make: "MG", Model: "ZS EV", BatteryKWh: 50.3, SupportedPlugs: []PlugName{Type2, CCSType2}, MaxACPowerKW: 7, MaxDCPowerKW: 76},
+	{ID: "mg-4", Make: "MG", Model: "MG4 Electric", BatteryKWh: 51, SupportedPlugs: []PlugName{Type2, CCSType2}, MaxACPowerKW: 6.6, MaxDCPowerKW: 117},
+	{ID: "gwm-ora-good-cat", Make: "GWM", Model: "ORA Good Cat", BatteryKWh: 47.8, SupportedPlugs: []PlugName{Type2, CCSType2}, MaxACPowerKW: 6.6, MaxDCPowerKW: 64},
+	{ID: "hyundai-ioniq-5-lr", Make: "Hyundai", Model: "IONIQ 5 Long Range", BatteryKWh: 77.4, SupportedPlugs: []PlugName{Type2, CCSType2}, MaxACPowerKW: 11, MaxDCPowerKW: 220},
+	{ID: "nissan-leaf-40", Make: "Nissan", Model: "Leaf 40 kWh", BatteryKWh: 39, SupportedPlugs: []PlugName{J1772, CHAdeMO}, MaxACPowerKW: 6.6, MaxDCPowerKW: 50},
+	{ID: "chevrolet-bolt-ev", Make: "Chevrolet", Model: "Bolt EV", BatteryKWh: 65, SupportedPlugs: []PlugName{J1772, CCSType1}, MaxACPowerKW: 11, MaxDCPowerKW: 55},
+	{ID: "wuling-mini-ev", Make: "Wuling", Model: "Hongguang Mini EV", BatteryKWh: 13.9, SupportedPlugs: []PlugName{GBTAC}, MaxACPowerKW: 3.3, MaxDCPowerKW: 0},
+	{ID: "byd-han-gbt", Make: "BYD", Model: "Han EV (China)", BatteryKWh: 85.4, SupportedPlugs: []PlugName{GBTAC, GBTDC}, MaxACPowerKW: 7, MaxDCPowerKW: 120},
+}
+
+// FindVehicleCatalogEntry looks up a catalog entry by its ID
+func FindVehicleCatalogEntry(id string) (VehicleCatalogEntry, bool) {
+	for _, entry := range VehicleCatalog {
+		if entry.ID == id {
+			return entry, true
+		}
+	}
+	return VehicleCatalogEntry{}, false
+}
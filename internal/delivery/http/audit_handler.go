@@ -0,0 +1,36 @@
+package http
+
+import (
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditUsecase usecase.AuditUsecase
+}
+
+func NewAuditHandler(usecase usecase.AuditUsecase) *AuditHandler {
+	return &AuditHandler{auditUsecase: usecase}
+}
+
+func (h *AuditHandler) GetAuditEntries(c *gin.Context) {
+	var filterReq request.AuditLogFilterRequest
+	if err := c.ShouldBindQuery(&filterReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid audit filter", "detail": err.Error()})
+		return
+	}
+
+	entries, err := h.auditUsecase.GetAuditEntries(c.Request.Context(), filterReq)
+	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
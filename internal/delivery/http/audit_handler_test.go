@@ -0,0 +1,85 @@
+package http_test
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	deliveryHttp "Ev-Charge-Hub/Server/internal/delivery/http"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/middleware"
+	"Ev-Charge-Hub/Server/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAuditRouter(mockUsecase *mocks.MockAuditUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	handler := deliveryHttp.NewAuditHandler(mockUsecase)
+
+	group := r.Group("/admin")
+	group.Use(middleware.AuthMiddleware(nil), middleware.Authorize())
+	group.GET("/audit", handler.GetAuditEntries)
+	return r
+}
+
+func TestGetAuditEntries_RequiresAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router := setupAuditRouter(mocks.NewMockAuditUsecase(ctrl))
+
+	token, _ := utils.CreateToken("u123", "testuser", constants.RoleUser)
+	req := httptest.NewRequest("GET", "/admin/audit", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestGetAuditEntries_PassesFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsecase := mocks.NewMockAuditUsecase(ctrl)
+	router := setupAuditRouter(mockUsecase)
+
+	mockUsecase.EXPECT().
+		GetAuditEntries(gomock.Any(), request.AuditLogFilterRequest{
+			Action:   "STATION_EDIT",
+			TargetID: "station123",
+			From:     "2025-05-01T00:00:00",
+			Limit:    20,
+		}).
+		Return([]response.AuditEntryResponse{{ID: "a1", ActorName: "admin", Action: constants.AuditStationEdit}}, nil)
+
+	token, _ := utils.CreateToken("a1", "admin", constants.RoleAdmin)
+	req := httptest.NewRequest("GET", "/admin/audit?action=STATION_EDIT&target_id=station123&from=2025-05-01T00:00:00&limit=20", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"action":"STATION_EDIT"`)
+}
+
+func TestGetAuditEntries_InvalidFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router := setupAuditRouter(mocks.NewMockAuditUsecase(ctrl))
+
+	token, _ := utils.CreateToken("a1", "admin", constants.RoleAdmin)
+	req := httptest.NewRequest("GET", "/admin/audit?from=yesterday&limit=5000", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
@@ -2,6 +2,7 @@
 package http
 
 import (
+	"Ev-Charge-Hub/Server/middleware"
 	"Ev-Charge-Hub/Server/utils"
 	"net/http"
 	"strings"
@@ -9,7 +10,14 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func TokenValidationHandler(c *gin.Context) {
+// TokenValidationHandler checks the signature, expiry and, with a non-nil checker, revocation of a token
+func TokenValidationHandler(revocations middleware.TokenRevocationChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		validateToken(c, revocations)
+	}
+}
+
+func validateToken(c *gin.Context, revocations middleware.TokenRevocationChecker) {
 	authHeader := c.GetHeader("Authorization")
 	if authHeader == "" {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header missing", "valid": false})
@@ -28,6 +36,17 @@ func TokenValidationHandler(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token", "valid": false})
 		return
 	}
+	if revocations != nil && claims.ID != "" {
+		revoked, err := revocations.IsAccessTokenRevoked(c.Request.Context(), claims.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to verify token", "valid": false})
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked", "valid": false})
+			return
+		}
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"valid": true,
@@ -15,7 +15,7 @@ import (
 func setupSecurityRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
-	r.GET("/security/validate-token", deliveryHttp.TokenValidationHandler)
+	r.GET("/security/validate-token", deliveryHttp.TokenValidationHandler(nil))
 	return r
 }
 
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"Ev-Charge-Hub/Server/internal/authz"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondForbidden answers 403 when a usecase refused the caller's role; it reports false for any other error
+func respondForbidden(c *gin.Context, err error) bool {
+	if !errors.Is(err, authz.ErrForbidden) {
+		return false
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+	return true
+}
@@ -0,0 +1,83 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	deliveryHttp "Ev-Charge-Hub/Server/internal/delivery/http"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupEmailVerificationRouter(mockUsecase *mocks.MockEmailVerificationUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	handler := deliveryHttp.NewEmailVerificationHandler(mockUsecase)
+	r.GET("/users/verify", handler.VerifyEmail)
+	r.POST("/users/verify/resend", func(c *gin.Context) {
+		c.Set("userID", "u1")
+		c.Next()
+	}, handler.ResendVerification)
+	return r
+}
+
+func TestVerifyEmail_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEmailVerificationUsecase(ctrl)
+	router := setupEmailVerificationRouter(mockUsecase)
+
+	mockUsecase.EXPECT().VerifyEmail(gomock.Any(), request.VerifyEmailRequest{Token: "abc"}).Return(nil)
+
+	req := httptest.NewRequest("GET", "/users/verify?token=abc", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestVerifyEmail_MissingOrInvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEmailVerificationUsecase(ctrl)
+	router := setupEmailVerificationRouter(mockUsecase)
+
+	mockUsecase.EXPECT().VerifyEmail(gomock.Any(), gomock.Any()).Return(usecase.ErrInvalidVerificationToken)
+
+	for _, target := range []string{"/users/verify", "/users/verify?token=expired"} {
+		req := httptest.NewRequest("GET", target, nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code, target)
+	}
+}
+
+func TestResendVerification_AlreadyVerifiedIs409(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEmailVerificationUsecase(ctrl)
+	router := setupEmailVerificationRouter(mockUsecase)
+
+	mockUsecase.EXPECT().
+		ResendVerification(gomock.Any(), request.ResendVerificationRequest{UserID: "u1"}).
+		Return(usecase.ErrEmailAlreadyVerified)
+
+	req := httptest.NewRequest("POST", "/users/verify/resend", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}
@@ -0,0 +1,14 @@
+package http
+
+import (
+	"Ev-Charge-Hub/Server/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler publishes the public JWT keys so other services can verify our tokens without JWT_SECRET
+func JWKSHandler(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{"keys": utils.CurrentKeySet().JWKS()})
+}
@@ -0,0 +1,169 @@
+package http_test
+
+import (
+	deliveryHttp "Ev-Charge-Hub/Server/internal/delivery/http"
+	"Ev-Charge-Hub/Server/utils"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEd25519Key(t *testing.T, id string, activatesAt time.Time) utils.JWTKey {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	key, err := utils.ParseJWTKey(id, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), activatesAt)
+	require.NoError(t, err)
+	return key
+}
+
+func newRSAKey(t *testing.T, id string) utils.JWTKey {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	key, err := utils.ParseJWTKey(id, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(private)}), time.Time{})
+	require.NoError(t, err)
+	return key
+}
+
+// useKeySet installs keys for the test and restores the previous set afterwards
+func useKeySet(t *testing.T, keys []utils.JWTKey, acceptHS256 bool) {
+	keySet, err := utils.NewKeySet(keys, acceptHS256)
+	require.NoError(t, err)
+	previous := utils.CurrentKeySet()
+	utils.SetKeySet(keySet)
+	t.Cleanup(func() { utils.SetKeySet(previous) })
+}
+
+func tokenHeader(t *testing.T, tokenString string) map[string]interface{} {
+	token, _, err := new(jwt.Parser).ParseUnverified(tokenString, &utils.Claims{})
+	require.NoError(t, err)
+	return token.Header
+}
+
+func TestJWKSHandler_PublishesPublicKeys(t *testing.T) {
+	useKeySet(t, []utils.JWTKey{newRSAKey(t, "rsa-1"), newEd25519Key(t, "ed-2", time.Now().Add(time.Hour))}, true)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/.well-known/jwks.json", deliveryHttp.JWKSHandler)
+
+	req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Header().Get("Cache-Control"), "max-age")
+	var body struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	require.Len(t, body.Keys, 2)
+	assert.Equal(t, "rsa-1", body.Keys[0]["kid"])
+	assert.Equal(t, "RSA", body.Keys[0]["kty"])
+	assert.Equal(t, "RS256", body.Keys[0]["alg"])
+	assert.Equal(t, "AQAB", body.Keys[0]["e"])
+	assert.Equal(t, "ed-2", body.Keys[1]["kid"])
+	assert.Equal(t, "OKP", body.Keys[1]["kty"])
+	assert.Equal(t, "Ed25519", body.Keys[1]["crv"])
+	assert.NotContains(t, resp.Body.String(), `"d"`)
+}
+
+func TestJWTKeys_SignsWithActiveKeyAndValidates(t *testing.T) {
+	useKeySet(t, []utils.JWTKey{
+		newRSAKey(t, "old"),
+		newEd25519Key(t, "current", time.Now().Add(-time.Hour)),
+		newEd25519Key(t, "next", time.Now().Add(time.Hour)),
+	}, true)
+
+	token, err := utils.CreateToken("u1", "alice", "USER")
+	require.NoError(t, err)
+
+	header := tokenHeader(t, token)
+	assert.Equal(t, "current", header["kid"])
+	assert.Equal(t, "EdDSA", header["alg"])
+	claims, err := utils.ValidateToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", claims.Username)
+}
+
+func TestJWTKeys_RetiredKeyStillVerifies(t *testing.T) {
+	old := newRSAKey(t, "old")
+	useKeySet(t, []utils.JWTKey{old}, true)
+	token, err := utils.CreateToken("u1", "alice", "USER")
+	require.NoError(t, err)
+
+	useKeySet(t, []utils.JWTKey{old, newEd25519Key(t, "new", time.Time{})}, true)
+
+	_, err = utils.ValidateToken(token)
+	assert.NoError(t, err)
+}
+
+func TestJWTKeys_HS256AcceptedOnlyDuringTransition(t *testing.T) {
+	hs256Token, err := utils.CreateToken("u1", "alice", "USER")
+	require.NoError(t, err)
+	assert.Equal(t, "HS256", tokenHeader(t, hs256Token)["alg"])
+
+	key := newEd25519Key(t, "k1", time.Time{})
+	useKeySet(t, []utils.JWTKey{key}, true)
+	_, err = utils.ValidateToken(hs256Token)
+	assert.NoError(t, err)
+
+	useKeySet(t, []utils.JWTKey{key}, false)
+	_, err = utils.ValidateToken(hs256Token)
+	assert.Error(t, err)
+}
+
+func TestJWTKeys_RejectsUnknownKidAndMismatchedAlgorithm(t *testing.T) {
+	useKeySet(t, []utils.JWTKey{newEd25519Key(t, "k1", time.Time{})}, false)
+
+	other := newEd25519Key(t, "k1", time.Time{})
+	forged := jwt.NewWithClaims(jwt.SigningMethodEdDSA, utils.Claims{Username: "mallory"})
+	forged.Header["kid"] = "k1"
+	signed, err := forged.SignedString(other.PrivateKey)
+	require.NoError(t, err)
+	_, err = utils.ValidateToken(signed)
+	assert.Error(t, err)
+
+	unknown := jwt.NewWithClaims(jwt.SigningMethodEdDSA, utils.Claims{Username: "mallory"})
+	unknown.Header["kid"] = "k2"
+	signed, err = unknown.SignedString(other.PrivateKey)
+	require.NoError(t, err)
+	_, err = utils.ValidateToken(signed)
+	assert.Error(t, err)
+
+	rsaKey := newRSAKey(t, "r1")
+	mismatched := jwt.NewWithClaims(jwt.SigningMethodRS256, utils.Claims{Username: "mallory"})
+	mismatched.Header["kid"] = "k1"
+	signed, err = mismatched.SignedString(rsaKey.PrivateKey)
+	require.NoError(t, err)
+	_, err = utils.ValidateToken(signed)
+	assert.Error(t, err)
+}
+
+func TestNewKeySet_RequiresSigningKeyWithoutHS256(t *testing.T) {
+	key := newEd25519Key(t, "k1", time.Time{})
+	verifyOnly := utils.JWTKey{ID: key.ID, Method: key.Method, PublicKey: key.PublicKey}
+
+	_, err := utils.NewKeySet([]utils.JWTKey{verifyOnly}, false)
+	assert.Error(t, err)
+	scheduled := newEd25519Key(t, "k2", time.Now().Add(time.Hour))
+	_, err = utils.NewKeySet([]utils.JWTKey{scheduled}, false)
+	assert.Error(t, err)
+	_, err = utils.NewKeySet([]utils.JWTKey{key, scheduled}, false)
+	assert.NoError(t, err)
+	_, err = utils.NewKeySet([]utils.JWTKey{key, key}, true)
+	assert.Error(t, err)
+}
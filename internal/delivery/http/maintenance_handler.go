@@ -0,0 +1,97 @@
+package http
+
+import (
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MaintenanceHandler struct {
+	maintenanceUsecase usecase.MaintenanceUsecase
+}
+
+func NewMaintenanceHandler(usecase usecase.MaintenanceUsecase) *MaintenanceHandler {
+	return &MaintenanceHandler{maintenanceUsecase: usecase}
+}
+
+func (h *MaintenanceHandler) GetMaintenanceWindows(c *gin.Context) {
+	windows, err := h.maintenanceUsecase.GetMaintenanceWindows(c.Request.Context(), request.GetMaintenanceWindowsRequest{StationID: c.Param("id")})
+	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, windows)
+}
+
+func (h *MaintenanceHandler) CreateMaintenanceWindow(c *gin.Context) {
+	var windowReq request.MaintenanceWindowRequest
+	if err := c.ShouldBindJSON(&windowReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid maintenance window data", "detail": err.Error()})
+		return
+	}
+
+	windowReq.StationID = c.Param("id")
+	windowReq.CreatedBy = c.GetString("userName")
+
+	created, err := h.maintenanceUsecase.CreateMaintenanceWindow(c.Request.Context(), windowReq)
+	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Maintenance window created successfully",
+		"window":         created.Window,
+		"notified_users": created.NotifiedUsers,
+	})
+}
+
+func (h *MaintenanceHandler) EditMaintenanceWindow(c *gin.Context) {
+	var windowReq request.MaintenanceWindowRequest
+	if err := c.ShouldBindJSON(&windowReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid maintenance window data", "detail": err.Error()})
+		return
+	}
+
+	windowReq.StationID = c.Param("id")
+	windowReq.WindowID = c.Param("window_id")
+
+	updated, err := h.maintenanceUsecase.EditMaintenanceWindow(c.Request.Context(), windowReq)
+	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Maintenance window updated successfully",
+		"window":  updated,
+	})
+}
+
+func (h *MaintenanceHandler) RemoveMaintenanceWindow(c *gin.Context) {
+	err := h.maintenanceUsecase.RemoveMaintenanceWindow(c.Request.Context(), request.RemoveMaintenanceWindowRequest{
+		StationID: c.Param("id"),
+		WindowID:  c.Param("window_id"),
+	})
+	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Maintenance window removed successfully"})
+}
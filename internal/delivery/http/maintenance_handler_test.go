@@ -0,0 +1,106 @@
+package http_test
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	deliveryHttp "Ev-Charge-Hub/Server/internal/delivery/http"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/middleware"
+	"Ev-Charge-Hub/Server/utils"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMaintenanceRouter(mockUsecase *mocks.MockMaintenanceUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	handler := deliveryHttp.NewMaintenanceHandler(mockUsecase)
+
+	group := r.Group("/stations/:id/maintenance")
+	group.Use(middleware.AuthMiddleware(nil), middleware.Authorize())
+	group.GET("", handler.GetMaintenanceWindows)
+	group.POST("", handler.CreateMaintenanceWindow)
+	group.DELETE("/:window_id", handler.RemoveMaintenanceWindow)
+	return r
+}
+
+func TestCreateMaintenanceWindow_RequiresAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockMaintenanceUsecase(ctrl)
+	router := setupMaintenanceRouter(mockUsecase)
+
+	token, _ := utils.CreateToken("u123", "testuser", constants.RoleUser)
+	body := `{"start_time":"2030-01-01T10:00:00","end_time":"2030-01-01T12:00:00","reason":"Cable replacement"}`
+	req := httptest.NewRequest("POST", "/stations/station123/maintenance", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Insufficient permissions")
+}
+
+func TestCreateMaintenanceWindow_Admin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockMaintenanceUsecase(ctrl)
+	router := setupMaintenanceRouter(mockUsecase)
+
+	expectedReq := request.MaintenanceWindowRequest{
+		StationID: "station123",
+		CreatedBy: "admin",
+		StartTime: "2030-01-01T10:00:00",
+		EndTime:   "2030-01-01T12:00:00",
+		Reason:    "Cable replacement",
+		Notify:    true,
+	}
+	mockUsecase.EXPECT().
+		CreateMaintenanceWindow(gomock.Any(), expectedReq).
+		Return(&response.CreateMaintenanceWindowResponse{
+			Window:        response.MaintenanceWindowResponse{ID: "w1", Scope: constants.MaintenanceScopeStation},
+			NotifiedUsers: []string{"alice"},
+		}, nil)
+
+	token, _ := utils.CreateToken("a1", "admin", constants.RoleAdmin)
+	body := `{"start_time":"2030-01-01T10:00:00","end_time":"2030-01-01T12:00:00","reason":"Cable replacement","notify":true}`
+	req := httptest.NewRequest("POST", "/stations/station123/maintenance", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "alice")
+}
+
+func TestCreateMaintenanceWindow_InvalidTimeFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockMaintenanceUsecase(ctrl)
+	router := setupMaintenanceRouter(mockUsecase)
+
+	token, _ := utils.CreateToken("a1", "admin", constants.RoleAdmin)
+	body := `{"start_time":"tomorrow","end_time":"2030-01-01T12:00:00","reason":"Cable replacement"}`
+	req := httptest.NewRequest("POST", "/stations/station123/maintenance", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
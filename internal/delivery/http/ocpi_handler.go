@@ -0,0 +1,193 @@
+package http
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OCPIHandler serves the OCPI 2.2.1 endpoints for roaming partners (wrapped in the OCPI
+// response envelope) and the admin endpoints that manage those partners (plain JSON)
+type OCPIHandler struct {
+	ocpiUsecase usecase.OCPIUsecase
+}
+
+func NewOCPIHandler(usecase usecase.OCPIUsecase) *OCPIHandler {
+	return &OCPIHandler{ocpiUsecase: usecase}
+}
+
+func (h *OCPIHandler) GetVersions(c *gin.Context) {
+	respondOCPI(c, h.ocpiUsecase.GetVersions(c.Request.Context()))
+}
+
+func (h *OCPIHandler) GetVersionDetails(c *gin.Context) {
+	respondOCPI(c, h.ocpiUsecase.GetVersionDetails(c.Request.Context()))
+}
+
+func (h *OCPIHandler) GetCredentials(c *gin.Context) {
+	respondOCPI(c, h.ocpiUsecase.GetCredentials(c.Request.Context(), c.GetString("ocpiToken")))
+}
+
+// PostCredentials registers the partner (token A) and answers with token C
+func (h *OCPIHandler) PostCredentials(c *gin.Context) {
+	var credentialsReq request.OCPICredentialsRequest
+	if err := c.ShouldBindJSON(&credentialsReq); err != nil {
+		respondOCPIError(c, http.StatusBadRequest, constants.OCPIStatusInvalidParams, err.Error())
+		return
+	}
+
+	credentials, err := h.ocpiUsecase.RegisterCredentials(c.Request.Context(), c.GetString("ocpiPartnerID"), credentialsReq)
+	if err != nil {
+		respondOCPICredentialsError(c, err)
+		return
+	}
+	respondOCPI(c, credentials)
+}
+
+func (h *OCPIHandler) PutCredentials(c *gin.Context) {
+	var credentialsReq request.OCPICredentialsRequest
+	if err := c.ShouldBindJSON(&credentialsReq); err != nil {
+		respondOCPIError(c, http.StatusBadRequest, constants.OCPIStatusInvalidParams, err.Error())
+		return
+	}
+
+	credentials, err := h.ocpiUsecase.UpdateCredentials(c.Request.Context(), c.GetString("ocpiPartnerID"), credentialsReq)
+	if err != nil {
+		respondOCPICredentialsError(c, err)
+		return
+	}
+	respondOCPI(c, credentials)
+}
+
+func (h *OCPIHandler) DeleteCredentials(c *gin.Context) {
+	if err := h.ocpiUsecase.DeleteCredentials(c.Request.Context(), c.GetString("ocpiPartnerID")); err != nil {
+		respondOCPICredentialsError(c, err)
+		return
+	}
+	respondOCPI(c, nil)
+}
+
+// GetLocations pages with offset/limit and announces the next page in the Link header
+func (h *OCPIHandler) GetLocations(c *gin.Context) {
+	var locationsReq request.OCPILocationsRequest
+	if err := c.ShouldBindQuery(&locationsReq); err != nil {
+		respondOCPIError(c, http.StatusBadRequest, constants.OCPIStatusInvalidParams, err.Error())
+		return
+	}
+
+	page, err := h.ocpiUsecase.GetLocations(c.Request.Context(), locationsReq)
+	if err != nil {
+		if errors.Is(err, usecase.ErrOCPIInvalidParams) {
+			respondOCPIError(c, http.StatusBadRequest, constants.OCPIStatusInvalidParams, err.Error())
+			return
+		}
+		respondOCPIError(c, http.StatusInternalServerError, constants.OCPIStatusServerError, err.Error())
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(page.Total, 10))
+	c.Header("X-Limit", strconv.FormatInt(page.Limit, 10))
+	if page.NextURL != "" {
+		c.Header("Link", "<"+page.NextURL+`>; rel="next"`)
+	}
+	respondOCPI(c, page.Locations)
+}
+
+func (h *OCPIHandler) GetLocation(c *gin.Context) {
+	var locationReq request.OCPILocationRequest
+	if err := c.ShouldBindUri(&locationReq); err != nil {
+		respondOCPIError(c, http.StatusBadRequest, constants.OCPIStatusInvalidParams, err.Error())
+		return
+	}
+
+	var (
+		object interface{}
+		err    error
+	)
+	switch {
+	case locationReq.ConnectorID != "":
+		object, err = h.ocpiUsecase.GetConnector(c.Request.Context(), locationReq)
+	case locationReq.EVSEUID != "":
+		object, err = h.ocpiUsecase.GetEVSE(c.Request.Context(), locationReq)
+	default:
+		object, err = h.ocpiUsecase.GetLocation(c.Request.Context(), locationReq)
+	}
+	if err != nil {
+		if errors.Is(err, usecase.ErrOCPIUnknownLocation) {
+			respondOCPIError(c, http.StatusNotFound, constants.OCPIStatusUnknownLocation, err.Error())
+			return
+		}
+		respondOCPIError(c, http.StatusInternalServerError, constants.OCPIStatusServerError, err.Error())
+		return
+	}
+	respondOCPI(c, object)
+}
+
+// CreatePartner (ADMIN) returns the token A to hand to the partner out of band
+func (h *OCPIHandler) CreatePartner(c *gin.Context) {
+	var partnerReq request.CreateOCPIPartnerRequest
+	if err := c.ShouldBindJSON(&partnerReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	partner, err := h.ocpiUsecase.CreatePartner(c.Request.Context(), partnerReq)
+	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, partner)
+}
+
+func (h *OCPIHandler) GetPartners(c *gin.Context) {
+	partners, err := h.ocpiUsecase.GetPartners(c.Request.Context())
+	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, partners)
+}
+
+func respondOCPI(c *gin.Context, data interface{}) {
+	c.JSON(http.StatusOK, response.OCPIResponse{
+		Data:          data,
+		StatusCode:    constants.OCPIStatusSuccess,
+		StatusMessage: "Success",
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+func respondOCPIError(c *gin.Context, httpStatus int, statusCode int, message string) {
+	c.JSON(httpStatus, response.OCPIResponse{
+		StatusCode:    statusCode,
+		StatusMessage: message,
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// respondOCPICredentialsError answers 405 for calls out of handshake order, as OCPI requires
+func respondOCPICredentialsError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrOCPIAlreadyRegistered), errors.Is(err, usecase.ErrOCPINotRegistered):
+		respondOCPIError(c, http.StatusMethodNotAllowed, constants.OCPIStatusClientError, err.Error())
+	case errors.Is(err, usecase.ErrOCPIUnsupportedVersion):
+		respondOCPIError(c, http.StatusOK, constants.OCPIStatusUnsupportedVersion, err.Error())
+	case errors.Is(err, usecase.ErrOCPIPartnerUnreachable):
+		respondOCPIError(c, http.StatusOK, constants.OCPIStatusUnableToUseAPI, err.Error())
+	default:
+		respondOCPIError(c, http.StatusInternalServerError, constants.OCPIStatusServerError, err.Error())
+	}
+}
@@ -0,0 +1,146 @@
+package http_test
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	deliveryHttp "Ev-Charge-Hub/Server/internal/delivery/http"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/ocpi"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"Ev-Charge-Hub/Server/middleware"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupOCPIRouter(mockUsecase *mocks.MockOCPIUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	handler := deliveryHttp.NewOCPIHandler(mockUsecase)
+
+	group := r.Group("/ocpi/2.2.1")
+	group.POST("/credentials", middleware.OCPITokenAuth(mockUsecase, false), handler.PostCredentials)
+	locations := group.Group("/locations", middleware.OCPITokenAuth(mockUsecase, true))
+	locations.GET("", handler.GetLocations)
+	locations.GET("/:location_id", handler.GetLocation)
+	locations.GET("/:location_id/:evse_uid", handler.GetLocation)
+	return r
+}
+
+func TestOCPILocations_RequiresToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	router := setupOCPIRouter(mocks.NewMockOCPIUsecase(ctrl))
+
+	req := httptest.NewRequest("GET", "/ocpi/2.2.1/locations", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	var body response.OCPIResponse
+	_ = json.Unmarshal(resp.Body.Bytes(), &body)
+	assert.Equal(t, constants.OCPIStatusClientError, body.StatusCode)
+}
+
+func TestOCPILocations_RejectsUnregisteredPartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsecase := mocks.NewMockOCPIUsecase(ctrl)
+	router := setupOCPIRouter(mockUsecase)
+
+	mockUsecase.EXPECT().AuthenticateToken(gomock.Any(), "token-a").
+		Return(&models.OCPIPartner{ID: "p1", Status: constants.OCPIPartnerPending}, nil)
+
+	req := httptest.NewRequest("GET", "/ocpi/2.2.1/locations", nil)
+	req.Header.Set("Authorization", ocpi.AuthorizationHeader("token-a"))
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func TestOCPILocations_PaginationHeaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsecase := mocks.NewMockOCPIUsecase(ctrl)
+	router := setupOCPIRouter(mockUsecase)
+
+	mockUsecase.EXPECT().AuthenticateToken(gomock.Any(), "token-c").
+		Return(&models.OCPIPartner{ID: "p1", Status: constants.OCPIPartnerRegistered}, nil)
+	mockUsecase.EXPECT().
+		GetLocations(gomock.Any(), request.OCPILocationsRequest{Offset: 0, Limit: 1}).
+		Return(&response.OCPILocationPage{
+			Locations: []response.OCPILocation{{ID: "s1"}},
+			Total:     2,
+			Limit:     1,
+			NextURL:   "https://hub.example.com/ocpi/2.2.1/locations?limit=1&offset=1",
+		}, nil)
+
+	req := httptest.NewRequest("GET", "/ocpi/2.2.1/locations?limit=1", nil)
+	req.Header.Set("Authorization", ocpi.AuthorizationHeader("token-c"))
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "2", resp.Header().Get("X-Total-Count"))
+	assert.Equal(t, "1", resp.Header().Get("X-Limit"))
+	assert.Equal(t, `<https://hub.example.com/ocpi/2.2.1/locations?limit=1&offset=1>; rel="next"`, resp.Header().Get("Link"))
+	assert.Contains(t, resp.Body.String(), `"status_code":1000`)
+	assert.Contains(t, resp.Body.String(), `"id":"s1"`)
+}
+
+func TestOCPIGetEVSE_UnknownLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsecase := mocks.NewMockOCPIUsecase(ctrl)
+	router := setupOCPIRouter(mockUsecase)
+
+	mockUsecase.EXPECT().AuthenticateToken(gomock.Any(), "token-c").
+		Return(&models.OCPIPartner{ID: "p1", Status: constants.OCPIPartnerRegistered}, nil)
+	mockUsecase.EXPECT().
+		GetEVSE(gomock.Any(), request.OCPILocationRequest{LocationID: "s1", EVSEUID: "CT-99"}).
+		Return(nil, usecase.ErrOCPIUnknownLocation)
+
+	req := httptest.NewRequest("GET", "/ocpi/2.2.1/locations/s1/CT-99", nil)
+	req.Header.Set("Authorization", ocpi.AuthorizationHeader("token-c"))
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status_code":2003`)
+}
+
+func TestOCPIPostCredentials_AlreadyRegistered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsecase := mocks.NewMockOCPIUsecase(ctrl)
+	router := setupOCPIRouter(mockUsecase)
+
+	mockUsecase.EXPECT().AuthenticateToken(gomock.Any(), "token-c").
+		Return(&models.OCPIPartner{ID: "p1", Status: constants.OCPIPartnerRegistered}, nil)
+	mockUsecase.EXPECT().
+		RegisterCredentials(gomock.Any(), "p1", gomock.Any()).
+		Return(nil, usecase.ErrOCPIAlreadyRegistered)
+
+	body := `{"token":"token-b","url":"https://partner.example.com/ocpi/versions","roles":[{"role":"EMSP","party_id":"FRM","country_code":"NL","business_details":{"name":"Fake Roaming"}}]}`
+	req := httptest.NewRequest("POST", "/ocpi/2.2.1/credentials", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", ocpi.AuthorizationHeader("token-c"))
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+}
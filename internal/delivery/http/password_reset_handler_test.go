@@ -0,0 +1,104 @@
+package http_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	deliveryHttp "Ev-Charge-Hub/Server/internal/delivery/http"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupPasswordResetRouter(mockUsecase *mocks.MockPasswordResetUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	handler := deliveryHttp.NewPasswordResetHandler(mockUsecase)
+	r.POST("/users/password/forgot", handler.ForgotPassword)
+	r.POST("/users/password/reset", handler.ResetPassword)
+	return r
+}
+
+func TestForgotPassword_SameResponseForAnyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockPasswordResetUsecase(ctrl)
+	router := setupPasswordResetRouter(mockUsecase)
+
+	mockUsecase.EXPECT().RequestPasswordReset(gomock.Any(), request.ForgotPasswordRequest{Email: "alice@example.com"}).Return(nil)
+	mockUsecase.EXPECT().RequestPasswordReset(gomock.Any(), request.ForgotPasswordRequest{Email: "nobody@example.com"}).Return(nil)
+
+	var bodies []string
+	for _, email := range []string{"alice@example.com", "nobody@example.com"} {
+		req := httptest.NewRequest("POST", "/users/password/forgot", bytes.NewBufferString(`{"email":"`+email+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusAccepted, resp.Code)
+		bodies = append(bodies, resp.Body.String())
+	}
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestForgotPassword_InvalidEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router := setupPasswordResetRouter(mocks.NewMockPasswordResetUsecase(ctrl))
+
+	req := httptest.NewRequest("POST", "/users/password/forgot", bytes.NewBufferString(`{"email":"not-an-email"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestResetPassword_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockPasswordResetUsecase(ctrl)
+	router := setupPasswordResetRouter(mockUsecase)
+
+	mockUsecase.EXPECT().
+		ResetPassword(gomock.Any(), request.ResetPasswordRequest{Token: "t1", NewPassword: "new-secret"}).
+		Return(nil)
+
+	req := httptest.NewRequest("POST", "/users/password/reset", bytes.NewBufferString(`{"token":"t1","new_password":"new-secret"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestResetPassword_InvalidTokenIs400(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockPasswordResetUsecase(ctrl)
+	router := setupPasswordResetRouter(mockUsecase)
+
+	mockUsecase.EXPECT().ResetPassword(gomock.Any(), gomock.Any()).Return(usecase.ErrInvalidResetToken)
+
+	req := httptest.NewRequest("POST", "/users/password/reset", bytes.NewBufferString(`{"token":"t1","new_password":"new-secret"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "invalid or expired password reset token")
+}
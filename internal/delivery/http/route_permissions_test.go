@@ -0,0 +1,156 @@
+package http_test
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	deliveryHttp "Ev-Charge-Hub/Server/internal/delivery/http"
+	"Ev-Charge-Hub/Server/routes"
+	"Ev-Charge-Hub/Server/utils"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// userAllowedRoutes lists every route behind Authorize and whether a USER may call it; ADMIN may call all of them
+var userAllowedRoutes = map[string]bool{
+	"GET /stations":                                 true,
+	"GET /stations/filter":                          true,
+	"GET /stations/estimate":                        true,
+	"GET /stations/export":                          true,
+	"GET /stations/:id":                             true,
+	"GET /stations/connector/:connector_id":         true,
+	"PUT /stations/set-booking":                     true,
+	"GET /stations/booking/:username":               true,
+	"GET /stations/bookings/:username":              true,
+	"GET /stations/username/:username":              true,
+	"POST /stations/create":                         false,
+	"PUT /stations/:id":                             false,
+	"PATCH /stations/:id":                           false,
+	"DELETE /stations/:id":                          false,
+	"POST /stations/:id/connectors":                 false,
+	"POST /stations/:id/connectors/:connector_id":   false,
+	"PUT /stations/:id/connectors/:connector_id":    false,
+	"DELETE /stations/:id/connectors/:connector_id": false,
+	"GET /stations/trash":                           false,
+	"POST /stations/:id/restore":                    false,
+	"POST /stations/import":                         false,
+	"GET /stations/:id/revisions":                   false,
+	"POST /stations/:id/revisions/:version/revert":  false,
+	"GET /stations/:id/maintenance":                 false,
+	"POST /stations/:id/maintenance":                false,
+	"PUT /stations/:id/maintenance/:window_id":      false,
+	"DELETE /stations/:id/maintenance/:window_id":   false,
+	"GET /vehicles/catalog":                         true,
+	"GET /vehicles":                                 true,
+	"POST /vehicles":                                true,
+	"GET /vehicles/:id":                             true,
+	"PUT /vehicles/:id":                             true,
+	"DELETE /vehicles/:id":                          true,
+	"GET /admin/audit":                              false,
+	"POST /admin/ocpi/partners":                     false,
+	"GET /admin/ocpi/partners":                      false,
+	"POST /admin/users":                             false,
+	"GET /admin/users/admins":                       false,
+	"PUT /admin/users/:id/role":                     false,
+	"POST /admin/users/:id/unlock":                  false,
+}
+
+var routeParam = regexp.MustCompile(`:[a-z_]+`)
+
+// setupFullRouter registers the real routes with handlers that have no usecase, so a request
+// that gets past the middleware panics and is recovered as a 500
+func setupFullRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(gin.RecoveryWithWriter(io.Discard))
+	routes.SetupRoutes(r,
+		deliveryHttp.NewUserHandler(nil),
+		deliveryHttp.NewSessionHandler(nil),
+		deliveryHttp.NewPasswordResetHandler(nil),
+		deliveryHttp.NewEmailVerificationHandler(nil),
+		deliveryHttp.NewTwoFactorHandler(nil),
+		deliveryHttp.NewEVStationHandler(nil),
+		deliveryHttp.NewVehicleHandler(nil),
+		deliveryHttp.NewMaintenanceHandler(nil),
+		deliveryHttp.NewAuditHandler(nil),
+		deliveryHttp.NewOCPIHandler(nil),
+		nil,
+		nil)
+	return r
+}
+
+func isAuthorizedRoute(path string) bool {
+	return strings.HasPrefix(path, "/stations") || strings.HasPrefix(path, "/vehicles") || strings.HasPrefix(path, "/admin")
+}
+
+func TestRoutePermissions_EveryAuthorizedRouteIsListed(t *testing.T) {
+	router := setupFullRouter()
+
+	registered := map[string]bool{}
+	for _, route := range router.Routes() {
+		if !isAuthorizedRoute(route.Path) {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		registered[key] = true
+		_, listed := userAllowedRoutes[key]
+		assert.True(t, listed, "route %s has no expected permission", key)
+	}
+	for key := range userAllowedRoutes {
+		assert.True(t, registered[key], "route %s is not registered", key)
+	}
+}
+
+func TestRoutePermissions_EachRoleAgainstEachRoute(t *testing.T) {
+	router := setupFullRouter()
+	adminToken, err := utils.CreateToken("admin-1", "admin", constants.RoleAdmin)
+	require.NoError(t, err)
+	userToken, err := utils.CreateToken("user-1", "alice", constants.RoleUser)
+	require.NoError(t, err)
+
+	for key, userAllowed := range userAllowedRoutes {
+		method, path, _ := strings.Cut(key, " ")
+		target := routeParam.ReplaceAllString(path, "abc123")
+
+		cases := []struct {
+			role    string
+			token   string
+			allowed bool
+		}{
+			{constants.RoleAdmin, adminToken, true},
+			{constants.RoleUser, userToken, userAllowed},
+		}
+		for _, tc := range cases {
+			t.Run(tc.role+" "+key, func(t *testing.T) {
+				req := httptest.NewRequest(method, target, nil)
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+				resp := httptest.NewRecorder()
+
+				router.ServeHTTP(resp, req)
+
+				if tc.allowed {
+					assert.NotEqual(t, http.StatusForbidden, resp.Code)
+					assert.NotEqual(t, http.StatusUnauthorized, resp.Code)
+				} else {
+					assert.Equal(t, http.StatusForbidden, resp.Code)
+					assert.Contains(t, resp.Body.String(), "Insufficient permissions")
+				}
+			})
+		}
+
+		t.Run("anonymous "+key, func(t *testing.T) {
+			req := httptest.NewRequest(method, target, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package http_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	deliveryHttp "Ev-Charge-Hub/Server/internal/delivery/http"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSessionRouter(mockUsecase *mocks.MockSessionUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	handler := deliveryHttp.NewSessionHandler(mockUsecase)
+	r.POST("/users/refresh", handler.RefreshToken)
+	r.POST("/users/logout", handler.Logout)
+	return r
+}
+
+func TestRefreshToken_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockSessionUsecase(ctrl)
+	router := setupSessionRouter(mockUsecase)
+
+	mockUsecase.EXPECT().
+		RefreshSession(gomock.Any(), request.RefreshTokenRequest{RefreshToken: "r1"}).
+		Return(&response.LoginResponse{Token: "access", RefreshToken: "r2", ExpiresIn: 900}, nil)
+
+	req := httptest.NewRequest("POST", "/users/refresh", bytes.NewBufferString(`{"refresh_token":"r1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"token":"access","refresh_token":"r2","expires_in":900}`, resp.Body.String())
+}
+
+func TestRefreshToken_ReuseIs401(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockSessionUsecase(ctrl)
+	router := setupSessionRouter(mockUsecase)
+
+	mockUsecase.EXPECT().
+		RefreshSession(gomock.Any(), gomock.Any()).
+		Return(nil, usecase.ErrRefreshTokenReused)
+
+	req := httptest.NewRequest("POST", "/users/refresh", bytes.NewBufferString(`{"refresh_token":"r1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func TestLogout_MissingRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockSessionUsecase(ctrl)
+	router := setupSessionRouter(mockUsecase)
+
+	req := httptest.NewRequest("POST", "/users/logout", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
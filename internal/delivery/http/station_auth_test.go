@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 // mock route ที่ถูก protect ด้วย middleware
@@ -21,7 +22,7 @@ func setupProtectedStationRoute(mockUsecase *mocks.MockEVStationUsecase) *gin.En
 	handler := deliveryHttp.NewEVStationHandler(mockUsecase)
 
 	protected := r.Group("/stations")
-	protected.Use(middleware.AuthMiddleware())
+	protected.Use(middleware.AuthMiddleware(nil))
 	protected.GET("", handler.ShowAllStations)
 
 	return r
@@ -101,3 +102,27 @@ func TestAuthMiddleware_ValidToken_Success(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.Code)
 	assert.Contains(t, resp.Body.String(), "Authorized Station")
 }
+
+func TestAuthMiddleware_RevokedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessions := mocks.NewMockSessionUsecase(ctrl)
+	mockSessions.EXPECT().IsAccessTokenRevoked(gomock.Any(), "jti-1").Return(true, nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/stations", middleware.AuthMiddleware(mockSessions), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	token, _ := utils.CreateAccessToken("u123", "testuser", "USER", "jti-1", time.Minute)
+	req := httptest.NewRequest("GET", "/stations", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp := httptest.NewRecorder()
+
+	r.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Token has been revoked")
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stationETag renders a station version as a strong entity tag
+func stationETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// requireIfMatch reads the expected station version from If-Match. It answers 428 when the
+// header is missing and 412 when it cannot name a version; "*" yields nil (any version).
+func requireIfMatch(c *gin.Context) (*int64, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required; use the ETag from GET /stations/:id"})
+		return nil, false
+	}
+	if header == "*" {
+		return nil, true
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(header, `"`), 10, 64)
+	if err != nil || strings.HasPrefix(header, "W/") {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "If-Match must be a station ETag"})
+		return nil, false
+	}
+	return &version, true
+}
+
+// respondStationWriteError answers 412 for stale versions, 403 for refused roles, 422 with the
+// field errors for invalid stations and the given status otherwise
+func respondStationWriteError(c *gin.Context, err error, status int) {
+	if errors.Is(err, usecase.ErrPreconditionFailed) {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+		return
+	}
+	if respondForbidden(c, err) || respondStationValidationError(c, err) {
+		return
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
+
+// respondStationValidationError answers 422 with the field errors of a StationValidationError;
+// it reports false for any other error
+func respondStationValidationError(c *gin.Context, err error) bool {
+	var validationErr *usecase.StationValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+	c.JSON(http.StatusUnprocessableEntity, gin.H{
+		"error":  usecase.ErrInvalidStationDocument.Error(),
+		"fields": validationErr.Fields,
+	})
+	return true
+}
@@ -0,0 +1,256 @@
+package http
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// stationExporter writes stations to the response one at a time; begin and end frame the file
+type stationExporter interface {
+	contentType() string
+	begin() error
+	write(station response.EVStationResponse) error
+	end() error
+}
+
+func newStationExporter(format string, w io.Writer) stationExporter {
+	switch format {
+	case constants.ExportFormatGeoJSON:
+		return &geoJSONStationExporter{w: w}
+	case constants.ExportFormatKML:
+		return &kmlStationExporter{w: w, encoder: xml.NewEncoder(w)}
+	default:
+		return &csvStationExporter{w: csv.NewWriter(w)}
+	}
+}
+
+// csvStationExporter writes one line per connector, repeating the station columns;
+// stations without connectors get a single line with empty connector columns
+type csvStationExporter struct {
+	w *csv.Writer
+}
+
+// csvExportHeader is the import's column list with the export-only station_id and is_open added,
+// so an exported file can be imported again as it is
+var csvExportHeader = func() []string {
+	header := []string{"station_id"}
+	header = append(header, constants.StationCSVStationColumns...)
+	header = append(header, "is_open")
+	return append(header, constants.StationCSVConnectorColumns...)
+}()
+
+func (e *csvStationExporter) contentType() string { return "text/csv; charset=utf-8" }
+
+func (e *csvStationExporter) begin() error {
+	return e.w.Write(csvExportHeader)
+}
+
+func (e *csvStationExporter) write(station response.EVStationResponse) error {
+	openHours, closeHours := dailyHours(station.Status)
+	stationColumns := []string{
+		station.ID,
+		station.ExternalRef,
+		station.Name,
+		strconv.FormatFloat(station.Latitude, 'f', -1, 64),
+		strconv.FormatFloat(station.Longitude, 'f', -1, 64),
+		station.Company,
+		station.Status.TimeZone,
+		strconv.FormatBool(station.Status.AlwaysOpen),
+		openHours,
+		closeHours,
+		strconv.FormatBool(station.Status.IsOpen),
+	}
+
+	if len(station.Connectors) == 0 {
+		return e.w.Write(append(stationColumns, make([]string, len(constants.StationCSVConnectorColumns))...))
+	}
+	for _, c := range station.Connectors {
+		record := append(append([]string{}, stationColumns...),
+			c.ConnectorID,
+			string(c.Type),
+			string(c.PlugName),
+			strconv.FormatFloat(c.PricePerUnit, 'f', -1, 64),
+			strconv.Itoa(c.PowerOutput),
+		)
+		if err := e.w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *csvStationExporter) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// dailyHours renders a schedule that is the same single interval every day as the open_hours and
+// close_hours the import accepts; any other schedule leaves both empty
+func dailyHours(status response.StationStatusResponse) (string, string) {
+	if status.AlwaysOpen || len(status.Weekly) != 7 {
+		return "", ""
+	}
+	first := status.Weekly[0].Intervals
+	for _, day := range status.Weekly {
+		if len(day.Intervals) != 1 || len(first) != 1 || day.Intervals[0] != first[0] {
+			return "", ""
+		}
+	}
+	return first[0].Open, first[0].Close
+}
+
+// exportConnector is the connector shape used by the GeoJSON export; bookings are left out
+type exportConnector struct {
+	ConnectorID  string                  `json:"connector_id"`
+	Type         constants.ConnectorType `json:"type"`
+	PlugName     constants.PlugName      `json:"plug_name"`
+	PricePerUnit float64                 `json:"price_per_unit"`
+	PowerOutput  int                     `json:"power_output"`
+}
+
+type geoJSONFeature struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id"`
+	Geometry   geoJSONPoint      `json:"geometry"`
+	Properties geoJSONProperties `json:"properties"`
+}
+
+type geoJSONPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+type geoJSONProperties struct {
+	ExternalRef string            `json:"external_ref,omitempty"`
+	Name        string            `json:"name"`
+	Company     string            `json:"company"`
+	TimeZone    string            `json:"time_zone"`
+	AlwaysOpen  bool              `json:"always_open"`
+	IsOpen      bool              `json:"is_open"`
+	Connectors  []exportConnector `json:"connectors"`
+}
+
+// geoJSONStationExporter writes an RFC 7946 FeatureCollection with one Point feature per station
+type geoJSONStationExporter struct {
+	w     io.Writer
+	count int
+}
+
+func (e *geoJSONStationExporter) contentType() string { return "application/geo+json" }
+
+func (e *geoJSONStationExporter) begin() error {
+	_, err := io.WriteString(e.w, `{"type":"FeatureCollection","features":[`)
+	return err
+}
+
+func (e *geoJSONStationExporter) write(station response.EVStationResponse) error {
+	connectors := make([]exportConnector, 0, len(station.Connectors))
+	for _, c := range station.Connectors {
+		connectors = append(connectors, exportConnector{
+			ConnectorID:  c.ConnectorID,
+			Type:         c.Type,
+			PlugName:     c.PlugName,
+			PricePerUnit: c.PricePerUnit,
+			PowerOutput:  c.PowerOutput,
+		})
+	}
+
+	feature, err := json.Marshal(geoJSONFeature{
+		Type: "Feature",
+		ID:   station.ID,
+		// GeoJSON positions are longitude first
+		Geometry: geoJSONPoint{Type: "Point", Coordinates: [2]float64{station.Longitude, station.Latitude}},
+		Properties: geoJSONProperties{
+			ExternalRef: station.ExternalRef,
+			Name:        station.Name,
+			Company:     station.Company,
+			TimeZone:    station.Status.TimeZone,
+			AlwaysOpen:  station.Status.AlwaysOpen,
+			IsOpen:      station.Status.IsOpen,
+			Connectors:  connectors,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if e.count > 0 {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.count++
+	_, err = e.w.Write(feature)
+	return err
+}
+
+func (e *geoJSONStationExporter) end() error {
+	_, err := io.WriteString(e.w, "]}")
+	return err
+}
+
+type kmlPlacemark struct {
+	XMLName     xml.Name  `xml:"Placemark"`
+	ID          string    `xml:"id,attr"`
+	Name        string    `xml:"name"`
+	Description string    `xml:"description"`
+	Data        []kmlData `xml:"ExtendedData>Data"`
+	Coordinates string    `xml:"Point>coordinates"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// kmlStationExporter writes a KML 2.2 document with one Placemark per station;
+// the connectors are listed in the description
+type kmlStationExporter struct {
+	w       io.Writer
+	encoder *xml.Encoder
+}
+
+func (e *kmlStationExporter) contentType() string { return "application/vnd.google-earth.kml+xml" }
+
+func (e *kmlStationExporter) begin() error {
+	_, err := io.WriteString(e.w, xml.Header+`<kml xmlns="http://www.opengis.net/kml/2.2"><Document><name>EV Stations</name>`)
+	return err
+}
+
+func (e *kmlStationExporter) write(station response.EVStationResponse) error {
+	connectors := make([]string, 0, len(station.Connectors))
+	for _, c := range station.Connectors {
+		connectors = append(connectors, fmt.Sprintf("%s %s (%s, %d kW, %s per unit)",
+			c.ConnectorID, c.PlugName, c.Type, c.PowerOutput, strconv.FormatFloat(c.PricePerUnit, 'f', -1, 64)))
+	}
+
+	placemark := kmlPlacemark{
+		ID:          station.ID,
+		Name:        station.Name,
+		Description: strings.Join(connectors, "\n"),
+		Data: []kmlData{
+			{Name: "external_ref", Value: station.ExternalRef},
+			{Name: "company", Value: station.Company},
+			{Name: "time_zone", Value: station.Status.TimeZone},
+			{Name: "is_open", Value: strconv.FormatBool(station.Status.IsOpen)},
+		},
+		// KML coordinates are longitude,latitude
+		Coordinates: strconv.FormatFloat(station.Longitude, 'f', -1, 64) + "," + strconv.FormatFloat(station.Latitude, 'f', -1, 64),
+	}
+	if err := e.encoder.Encode(placemark); err != nil {
+		return err
+	}
+	return e.encoder.Flush()
+}
+
+func (e *kmlStationExporter) end() error {
+	_, err := io.WriteString(e.w, "</Document></kml>")
+	return err
+}
@@ -1,9 +1,15 @@
 package http
 
 import (
+	"Ev-Charge-Hub/Server/internal/constants"
 	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
 	"Ev-Charge-Hub/Server/internal/usecase"
+	"Ev-Charge-Hub/Server/utils"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -26,16 +32,74 @@ func (h *EVStationHandler) FilterStations(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	filterRequest.UserID = c.GetString("userID")
 
-	stations, err := h.stationUsecase.FilterStations(c, filterRequest)
+	stations, err := h.stationUsecase.FilterStations(c.Request.Context(), filterRequest)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondStationQueryError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, stations)
 }
 
+// ExportStations streams the stations matching the FilterStations query as a CSV, GeoJSON or
+// KML download. The file is started with the first station, so errors before it still get JSON.
+func (h *EVStationHandler) ExportStations(c *gin.Context) {
+	var exportRequest request.ExportStationsRequest
+	if err := c.ShouldBindQuery(&exportRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	exportRequest.UserID = c.GetString("userID")
+
+	exporter := newStationExporter(exportRequest.Format, c.Writer)
+	started := false
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		c.Header("Content-Type", exporter.contentType())
+		c.Header("Content-Disposition", `attachment; filename="stations.`+exportRequest.Format+`"`)
+		c.Status(http.StatusOK)
+		return exporter.begin()
+	}
+
+	err := h.stationUsecase.ExportStations(c.Request.Context(), exportRequest.StationFilterRequest, func(station response.EVStationResponse) error {
+		if err := start(); err != nil {
+			return err
+		}
+		return exporter.write(station)
+	})
+	if err == nil {
+		if err = start(); err == nil {
+			err = exporter.end()
+		}
+	}
+	if err != nil {
+		if !started {
+			respondStationQueryError(c, err)
+			return
+		}
+		// the download is already under way; leave it unterminated and record the cause for the logger
+		_ = c.Error(err)
+	}
+}
+
+// respondStationQueryError answers 400 for bad filter or estimate parameters and 404 for an
+// unknown vehicle or connector
+func respondStationQueryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidStationQuery):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrVehicleNotFound), errors.Is(err, usecase.ErrConnectorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
 func (h *EVStationHandler) ShowAllStations(c *gin.Context) {
 	stations, err := h.stationUsecase.ShowAllStations(c)
 	if err != nil {
@@ -49,12 +113,18 @@ func (h *EVStationHandler) ShowAllStations(c *gin.Context) {
 func (h *EVStationHandler) GetStationByID(c *gin.Context) {
 	id := c.Param("id")
 
+	if asOf := c.Query("as_of"); asOf != "" {
+		h.getStationAsOf(c, request.GetStationAsOfRequest{ID: id, AsOf: asOf})
+		return
+	}
+
 	station, err := h.stationUsecase.GetStationByID(c.Request.Context(), request.GetStationByIDRequest{ID: id})
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Station not found"})
 		return
 	}
 
+	c.Header("ETag", stationETag(station.Version))
 	c.JSON(http.StatusOK, station)
 }
 
@@ -70,6 +140,17 @@ func (h *EVStationHandler) SetBooking(c *gin.Context) {
 	//  Call Usecase
 	err := h.stationUsecase.SetBooking(c.Request.Context(), bookingReq)
 	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrEmailNotVerified) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -92,6 +173,9 @@ func (h *EVStationHandler) CreateStation(c *gin.Context) {
 
 	// ส่งต่อ request ไป Usecase เลย
 	if err := h.stationUsecase.CreateStation(c.Request.Context(), stationRequest); err != nil {
+		if respondForbidden(c, err) || respondStationValidationError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -102,6 +186,11 @@ func (h *EVStationHandler) CreateStation(c *gin.Context) {
 func (h *EVStationHandler) EditStation(c *gin.Context) {
 	id := c.Param("id")
 
+	expectedVersion, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	var stationReq request.EVStationRequest
 	if err := c.ShouldBindJSON(&stationReq); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -112,21 +201,25 @@ func (h *EVStationHandler) EditStation(c *gin.Context) {
 	}
 
 	editReq := request.EditStationRequest{
-		ID:         id,
-		Name:       &stationReq.Name,
-		Latitude:   &stationReq.Latitude,
-		Longitude:  &stationReq.Longitude,
-		Company:    &stationReq.Company,
-		Status:     &stationReq.Status,
-		Connectors: &stationReq.Connectors,
+		ID:          id,
+		ExternalRef: &stationReq.ExternalRef,
+		Name:        &stationReq.Name,
+		Latitude:    &stationReq.Latitude,
+		Longitude:   &stationReq.Longitude,
+		Company:     &stationReq.Company,
+		Status:      &stationReq.Status,
+		Connectors:  &stationReq.Connectors,
+
+		ExpectedVersion: expectedVersion,
 	}
 
 	updated, err := h.stationUsecase.EditStation(c.Request.Context(), editReq)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondStationWriteError(c, err, http.StatusNotFound)
 		return
 	}
 
+	c.Header("ETag", stationETag(updated.Version))
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Station updated successfully",
 		"station": updated,
@@ -134,11 +227,77 @@ func (h *EVStationHandler) EditStation(c *gin.Context) {
 }
 
 
+// PatchStation accepts application/merge-patch+json or application/json-patch+json
+func (h *EVStationHandler) PatchStation(c *gin.Context) {
+	contentType := c.ContentType()
+	if contentType != constants.MergePatchContentType && contentType != constants.JSONPatchContentType {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"error": "Content-Type must be " + constants.MergePatchContentType + " or " + constants.JSONPatchContentType,
+		})
+		return
+	}
+
+	expectedVersion, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid patch body"})
+		return
+	}
+
+	updated, err := h.stationUsecase.PatchStation(c.Request.Context(), request.PatchStationRequest{
+		ID:        c.Param("id"),
+		PatchType: contentType,
+		Patch:     body,
+
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		if respondForbidden(c, err) || respondStationValidationError(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, usecase.ErrPreconditionFailed):
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrInvalidStationDocument):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"validation_error": err.Error()})
+		case errors.Is(err, utils.ErrPatchTestFailed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, utils.ErrInvalidPatch):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Header("ETag", stationETag(updated.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Station updated successfully",
+		"station": updated,
+	})
+}
+
 func (h *EVStationHandler) RemoveStation(c *gin.Context) {
-	id := c.Param("id")
+	removeReq := request.RemoveStationRequest{ID: c.Param("id")}
+	if err := c.ShouldBindQuery(&removeReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	removeReq.DeletedBy = c.GetString("userName")
 
-	err := h.stationUsecase.RemoveStation(c.Request.Context(), request.RemoveStationRequest{ID: id})
+	err := h.stationUsecase.RemoveStation(c.Request.Context(), removeReq)
 	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrStationHasActiveBookings) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": "Station not found"})
 		return
 	}
@@ -146,6 +305,250 @@ func (h *EVStationHandler) RemoveStation(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Station removed successfully"})
 }
 
+// getStationAsOf serves a historical snapshot; it has no ETag because it cannot be edited
+func (h *EVStationHandler) getStationAsOf(c *gin.Context, asOfReq request.GetStationAsOfRequest) {
+	station, err := h.stationUsecase.GetStationAsOf(c.Request.Context(), asOfReq)
+	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrRevisionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No revision of this station recorded at or before as_of"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, station)
+}
+
+func (h *EVStationHandler) GetStationRevisions(c *gin.Context) {
+	revisions, err := h.stationUsecase.GetStationRevisions(c.Request.Context(), request.GetStationRevisionsRequest{ID: c.Param("id")})
+	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+func (h *EVStationHandler) RevertStation(c *gin.Context) {
+	version, err := strconv.ParseInt(c.Param("version"), 10, 64)
+	if err != nil || version <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision version"})
+		return
+	}
+
+	expectedVersion, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	reverted, err := h.stationUsecase.RevertStation(c.Request.Context(), request.RevertStationRequest{
+		ID:              c.Param("id"),
+		Version:         version,
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrRevisionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		respondStationWriteError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.Header("ETag", stationETag(reverted.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Station reverted successfully",
+		"station": reverted,
+	})
+}
+
+// maxImportFileSize caps the body of a bulk station import
+const maxImportFileSize = 10 << 20
+
+// ImportStations accepts a CSV or JSON file either as the request body or as the "file" field
+// of a multipart form. The format comes from ?format=, the Content-Type or the data itself.
+func (h *EVStationHandler) ImportStations(c *gin.Context) {
+	var importReq request.ImportStationsRequest
+	if err := c.ShouldBindQuery(&importReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+	contentType := c.ContentType()
+	var data []byte
+	var err error
+	if contentType == "multipart/form-data" {
+		file, _, formErr := c.Request.FormFile("file")
+		if formErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Missing import file"})
+			return
+		}
+		defer file.Close()
+		data, err = io.ReadAll(file)
+	} else {
+		data, err = c.GetRawData()
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import file"})
+		return
+	}
+	importReq.Data = data
+
+	if importReq.Format == "" {
+		switch contentType {
+		case "text/csv":
+			importReq.Format = constants.ImportFormatCSV
+		case "application/json":
+			importReq.Format = constants.ImportFormatJSON
+		}
+	}
+
+	report, err := h.stationUsecase.ImportStations(c.Request.Context(), importReq)
+	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidImportFile) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *EVStationHandler) GetDeletedStations(c *gin.Context) {
+	stations, err := h.stationUsecase.GetDeletedStations(c.Request.Context())
+	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stations)
+}
+
+func (h *EVStationHandler) RestoreStation(c *gin.Context) {
+	restored, err := h.stationUsecase.RestoreStation(c.Request.Context(), request.RestoreStationRequest{ID: c.Param("id")})
+	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrExternalRefInUse) || errors.Is(err, usecase.ErrConnectorIDInUse) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", stationETag(restored.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Station restored successfully",
+		"station": restored,
+	})
+}
+
+func (h *EVStationHandler) AddConnector(c *gin.Context) {
+	expectedVersion, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	var connectorReq request.StationConnectorRequest
+	if err := c.ShouldBindJSON(&connectorReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connector data", "detail": err.Error()})
+		return
+	}
+
+	connectorReq.StationID = c.Param("id")
+	connectorReq.ConnectorID = c.Param("connector_id")
+	connectorReq.ExpectedVersion = expectedVersion
+
+	updated, err := h.stationUsecase.AddConnector(c.Request.Context(), connectorReq)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrStationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrConnectorIDInUse):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			respondStationWriteError(c, err, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Header("ETag", stationETag(updated.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Connector added successfully",
+		"station": updated,
+	})
+}
+
+func (h *EVStationHandler) EditConnector(c *gin.Context) {
+	expectedVersion, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	var connectorReq request.StationConnectorRequest
+	if err := c.ShouldBindJSON(&connectorReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connector data", "detail": err.Error()})
+		return
+	}
+
+	connectorReq.StationID = c.Param("id")
+	connectorReq.ConnectorID = c.Param("connector_id")
+	connectorReq.ExpectedVersion = expectedVersion
+
+	updated, err := h.stationUsecase.EditConnector(c.Request.Context(), connectorReq)
+	if err != nil {
+		respondStationWriteError(c, err, http.StatusNotFound)
+		return
+	}
+
+	c.Header("ETag", stationETag(updated.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Connector updated successfully",
+		"station": updated,
+	})
+}
+
+func (h *EVStationHandler) RemoveConnector(c *gin.Context) {
+	expectedVersion, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.stationUsecase.RemoveConnector(c.Request.Context(), request.RemoveConnectorRequest{
+		StationID:       c.Param("id"),
+		ConnectorID:     c.Param("connector_id"),
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		respondStationWriteError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.Header("ETag", stationETag(updated.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Connector removed successfully",
+		"station": updated,
+	})
+}
+
 func (h *EVStationHandler) GetBookingByUserName(c *gin.Context) {
 	username := c.Param("username")
 	if username == "" {
@@ -155,6 +558,9 @@ func (h *EVStationHandler) GetBookingByUserName(c *gin.Context) {
 
 	booking, err := h.stationUsecase.GetBookingByUserName(c.Request.Context(), request.GetBookingRequest{Username: username})
 	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
@@ -171,6 +577,9 @@ func (h *EVStationHandler) GetBookingsByUserName(c *gin.Context) {
 
 	bookings, err := h.stationUsecase.GetBookingsByUserName(c.Request.Context(), request.GetBookingsRequest{Username: username})
 	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
@@ -190,6 +599,23 @@ func (h *EVStationHandler) GetStationByConnectorID(c *gin.Context) {
 	c.JSON(http.StatusOK, station)
 }
 
+func (h *EVStationHandler) EstimateCharge(c *gin.Context) {
+	var estimateRequest request.ChargeEstimateRequest
+	if err := c.ShouldBindQuery(&estimateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	estimateRequest.UserID = c.GetString("userID")
+
+	estimate, err := h.stationUsecase.EstimateCharge(c.Request.Context(), estimateRequest)
+	if err != nil {
+		respondStationQueryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, estimate)
+}
+
 func (h *EVStationHandler) GetStationByUserName(c *gin.Context) {
 	username := c.Param("username")
 	if username == "" {
@@ -199,6 +625,9 @@ func (h *EVStationHandler) GetStationByUserName(c *gin.Context) {
 
 	station, err := h.stationUsecase.GetStationByUserName(c.Request.Context(), request.GetStationByUsernameRequest{Username: username})
 	if err != nil {
+		if respondForbidden(c, err) {
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
@@ -2,16 +2,23 @@ package http_test
 
 import (
 	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"Ev-Charge-Hub/Server/internal/authz"
 	deliveryHttp "Ev-Charge-Hub/Server/internal/delivery/http"
 	"Ev-Charge-Hub/Server/internal/dto/request"
 	"Ev-Charge-Hub/Server/internal/dto/response"
 	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/usecase"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -25,11 +32,19 @@ func setupRouterWithStationHandler(mockUsecase *mocks.MockEVStationUsecase) *gin
 
 	r.GET("/stations", handler.ShowAllStations)
 	r.GET("/stations/filter", handler.FilterStations)
+	r.GET("/stations/export", handler.ExportStations)
 	r.POST("/stations", handler.CreateStation)
 	r.POST("/stations/booking", handler.SetBooking)
+	r.POST("/stations/import", handler.ImportStations)
 	r.GET("/stations/:id", handler.GetStationByID)
 	r.PUT("/stations/:id", handler.EditStation)
+	r.PATCH("/stations/:id", handler.PatchStation)
 	r.DELETE("/stations/:id", handler.RemoveStation)
+	r.POST("/stations/:id/connectors", handler.AddConnector)
+	r.PUT("/stations/:id/connectors/:connector_id", handler.EditConnector)
+	r.DELETE("/stations/:id/connectors/:connector_id", handler.RemoveConnector)
+	r.GET("/stations/:id/revisions", handler.GetStationRevisions)
+	r.POST("/stations/:id/revisions/:version/revert", handler.RevertStation)
 
 	return r
 }
@@ -63,7 +78,7 @@ func TestFilterStations_InvalidQuery(t *testing.T) {
 	mockUsecase.
 		EXPECT().
 		FilterStations(gomock.Any(), gomock.Any()).
-		Return(nil, errors.New("invalid status value: unknown"))
+		Return(nil, fmt.Errorf("%w: invalid status value: unknown", usecase.ErrInvalidStationQuery))
 
 	router := setupRouterWithStationHandler(mockUsecase)
 
@@ -71,10 +86,30 @@ func TestFilterStations_InvalidQuery(t *testing.T) {
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 
-	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
 	assert.Contains(t, resp.Body.String(), "invalid status value")
 }
 
+func TestFilterStations_ErrorMapping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	gomock.InOrder(
+		mockUsecase.EXPECT().FilterStations(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrVehicleNotFound),
+		mockUsecase.EXPECT().FilterStations(gomock.Any(), gomock.Any()).Return(nil, errors.New("error finding stations: timeout")),
+	)
+
+	for _, want := range []int{http.StatusNotFound, http.StatusInternalServerError} {
+		req := httptest.NewRequest("GET", "/stations/filter?vehicle_id=v1", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, want, resp.Code)
+	}
+}
+
 func TestCreateStation_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -90,7 +125,6 @@ func TestCreateStation_Success(t *testing.T) {
 		Status: request.StationStatusRequest{
 			OpenHours:  "09:00",
 			CloseHours: "19:00",
-			IsOpen:     true,
 		},
 		Connectors: []request.ConnectorRequest{
 			{
@@ -114,6 +148,36 @@ func TestCreateStation_Success(t *testing.T) {
 	assert.Contains(t, resp.Body.String(), "Station created successfully")
 }
 
+func TestCreateStation_ValidationErrorsAre422(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	body := request.EVStationRequest{
+		Name:       "Mixed Up",
+		Latitude:   13.5,
+		Longitude:  100.5,
+		Company:    "EV Co",
+		Status:     request.StationStatusRequest{AlwaysOpen: true},
+		Connectors: []request.ConnectorRequest{{Type: "DC", PlugName: "TYPE 2", PricePerUnit: 10, PowerOutput: 22}},
+	}
+
+	mockUsecase.EXPECT().CreateStation(gomock.Any(), body).Return(&usecase.StationValidationError{
+		Fields: []response.FieldError{{Field: "connectors[0].type", Message: "plug TYPE 2 is AC, not DC"}},
+	})
+
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/stations", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.JSONEq(t, `{"error": "invalid station document", "fields": [{"field": "connectors[0].type", "message": "plug TYPE 2 is AC, not DC"}]}`, resp.Body.String())
+}
+
 func TestSetBooking_Fail_InvalidFormat(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -160,6 +224,25 @@ func TestSetBooking_UsecaseError(t *testing.T) {
 	assert.Contains(t, resp.Body.String(), "booking failed")
 }
 
+func TestSetBooking_UnverifiedEmailIs403(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.EXPECT().SetBooking(gomock.Any(), gomock.Any()).Return(usecase.ErrEmailNotVerified)
+
+	req := httptest.NewRequest("POST", "/stations/booking", bytes.NewBufferString(`{"connector_id":"abc123","booking_end_time":"2025-12-31T10:00:00"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	assert.Contains(t, resp.Body.String(), "email address must be verified")
+}
+
 func TestEditStation_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -176,7 +259,6 @@ func TestEditStation_Success(t *testing.T) {
 		Status: request.StationStatusRequest{
 			OpenHours:  "09:00",
 			CloseHours: "19:00",
-			IsOpen:     true,
 		},
 		Connectors: []request.ConnectorRequest{
 			{
@@ -196,6 +278,7 @@ func TestEditStation_Success(t *testing.T) {
 	jsonBody, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("PUT", "/stations/"+id, bytes.NewReader(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"3"`)
 	resp := httptest.NewRecorder()
 
 	router.ServeHTTP(resp, req)
@@ -204,6 +287,63 @@ func TestEditStation_Success(t *testing.T) {
 	assert.Contains(t, resp.Body.String(), "Updated Station")
 }
 
+func TestEditStation_PassesExternalRef(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.
+		EXPECT().
+		EditStation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req request.EditStationRequest) (*response.EVStationResponse, error) {
+			assert.Equal(t, "OP-42", *req.ExternalRef)
+			return &response.EVStationResponse{Name: "Updated Station"}, nil
+		})
+
+	body := `{"external_ref": "OP-42", "name": "Updated Station", "latitude": 13.5, "longitude": 100.5, "company": "Updated Co",
+		"status": {"open_hours": "09:00", "close_hours": "19:00"},
+		"connectors": [{"type": "AC", "plug_name": "Type 2", "price_per_unit": 10, "power_output": 22}]}`
+	req := httptest.NewRequest("PUT", "/stations/abc123", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"3"`)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestAddConnector_ErrorMapping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	cases := []struct {
+		err    error
+		status int
+	}{
+		{usecase.ErrStationNotFound, http.StatusNotFound},
+		{fmt.Errorf("%w: C1", usecase.ErrConnectorIDInUse), http.StatusConflict},
+		{usecase.ErrPreconditionFailed, http.StatusPreconditionFailed},
+		{errors.New("connection reset"), http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		mockUsecase.EXPECT().AddConnector(gomock.Any(), gomock.Any()).Return(nil, tc.err)
+
+		body := `{"type": "AC", "plug_name": "TYPE 2", "price_per_unit": 5, "power_output": 22}`
+		req := httptest.NewRequest("POST", "/stations/abc123/connectors", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"3"`)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, tc.status, resp.Code, tc.err.Error())
+	}
+}
+
 func TestGetStationByID_NotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -263,3 +403,556 @@ func TestGetStationByUserName_EmptyParam(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, resp.Code)
 	assert.Contains(t, resp.Body.String(), "username is required")
 }
+
+func TestGetBookingsByUserName_OtherUserIs403(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := gin.Default()
+	handler := deliveryHttp.NewEVStationHandler(mockUsecase)
+	router.GET("/stations/bookings/:username", handler.GetBookingsByUserName)
+
+	mockUsecase.EXPECT().
+		GetBookingsByUserName(gomock.Any(), request.GetBookingsRequest{Username: "bob"}).
+		Return(nil, authz.ErrForbidden)
+
+	req := httptest.NewRequest("GET", "/stations/bookings/bob", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestEstimateCharge_MissingTargetSoC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := gin.Default()
+	handler := deliveryHttp.NewEVStationHandler(mockUsecase)
+	router.GET("/stations/estimate", handler.EstimateCharge)
+
+	req := httptest.NewRequest("GET", "/stations/estimate?connector_id=C1&battery_kwh=50", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestEstimateCharge_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := gin.Default()
+	handler := deliveryHttp.NewEVStationHandler(mockUsecase)
+	router.GET("/stations/estimate", handler.EstimateCharge)
+
+	mockUsecase.EXPECT().
+		EstimateCharge(gomock.Any(), request.ChargeEstimateRequest{ConnectorID: "C1", BatteryKWh: 50, StartSoC: 20, TargetSoC: 80}).
+		Return(&response.ChargeEstimateResponse{ConnectorID: "C1", EstimatedMinutes: 36}, nil)
+
+	req := httptest.NewRequest("GET", "/stations/estimate?connector_id=C1&battery_kwh=50&start_soc=20&target_soc=80", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"estimated_minutes":36`)
+}
+
+func TestEstimateCharge_ErrorMapping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := gin.Default()
+	handler := deliveryHttp.NewEVStationHandler(mockUsecase)
+	router.GET("/stations/estimate", handler.EstimateCharge)
+
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("%w: battery_kwh or vehicle_id is required", usecase.ErrInvalidStationQuery), http.StatusBadRequest},
+		{usecase.ErrConnectorNotFound, http.StatusNotFound},
+		{usecase.ErrVehicleNotFound, http.StatusNotFound},
+		{errors.New("error finding station: timeout"), http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		mockUsecase.EXPECT().EstimateCharge(gomock.Any(), gomock.Any()).Return(nil, tc.err)
+
+		req := httptest.NewRequest("GET", "/stations/estimate?connector_id=C1&start_soc=20&target_soc=80", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		assert.Equal(t, tc.want, resp.Code, tc.err.Error())
+	}
+}
+
+func TestEditConnector_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	version := int64(4)
+	mockUsecase.
+		EXPECT().
+		EditConnector(gomock.Any(), request.StationConnectorRequest{
+			StationID:       "abc123",
+			ConnectorID:     "C1",
+			ExpectedVersion: &version,
+			Type:            "DC",
+			PlugName:        "CCS2",
+			PricePerUnit:    7.5,
+			PowerOutput:     120,
+		}).
+		Return(&response.EVStationResponse{ID: "abc123", Version: 5}, nil)
+
+	body := `{"type":"DC","plug_name":"CCS2","price_per_unit":7.5,"power_output":120}`
+	req := httptest.NewRequest("PUT", "/stations/abc123/connectors/C1", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"4"`)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Connector updated successfully")
+	assert.Equal(t, `"5"`, resp.Header().Get("ETag"))
+}
+
+func TestRemoveConnector_ActiveBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.
+		EXPECT().
+		RemoveConnector(gomock.Any(), request.RemoveConnectorRequest{StationID: "abc123", ConnectorID: "C1"}).
+		Return(nil, errors.New("connector has an active booking until 2030-01-01T10:00:00"))
+
+	req := httptest.NewRequest("DELETE", "/stations/abc123/connectors/C1", nil)
+	req.Header.Set("If-Match", "*")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "active booking")
+}
+
+func TestPatchStation_UnsupportedMediaType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	req := httptest.NewRequest("PATCH", "/stations/abc123", bytes.NewBufferString(`{"name":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.Code)
+}
+
+func TestPatchStation_MergePatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	version := int64(2)
+	mockUsecase.
+		EXPECT().
+		PatchStation(gomock.Any(), request.PatchStationRequest{
+			ID:        "abc123",
+			PatchType: "application/merge-patch+json",
+			Patch:     []byte(`{"name":"Central Plaza"}`),
+
+			ExpectedVersion: &version,
+		}).
+		Return(&response.EVStationResponse{ID: "abc123", Name: "Central Plaza"}, nil)
+
+	req := httptest.NewRequest("PATCH", "/stations/abc123", bytes.NewBufferString(`{"name":"Central Plaza"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("If-Match", `"2"`)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Central Plaza")
+}
+
+func TestPatchStation_InvalidDocument(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.
+		EXPECT().
+		PatchStation(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("%w: name is required", usecase.ErrInvalidStationDocument))
+
+	req := httptest.NewRequest("PATCH", "/stations/abc123", bytes.NewBufferString(`[{"op":"remove","path":"/name"}]`))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	req.Header.Set("If-Match", `"2"`)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.Contains(t, resp.Body.String(), "validation_error")
+}
+
+func TestGetStationByID_ReturnsETag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.
+		EXPECT().
+		GetStationByID(gomock.Any(), request.GetStationByIDRequest{ID: "abc123"}).
+		Return(&response.EVStationResponse{ID: "abc123", Version: 7}, nil)
+
+	req := httptest.NewRequest("GET", "/stations/abc123", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `"7"`, resp.Header().Get("ETag"))
+}
+
+func TestEditStation_MissingIfMatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	req := httptest.NewRequest("PUT", "/stations/abc123", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusPreconditionRequired, resp.Code)
+}
+
+func TestPatchStation_StaleVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.
+		EXPECT().
+		PatchStation(gomock.Any(), gomock.Any()).
+		Return(nil, usecase.ErrPreconditionFailed)
+
+	req := httptest.NewRequest("PATCH", "/stations/abc123", bytes.NewBufferString(`{"name":"x"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("If-Match", `"1"`)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, resp.Code)
+}
+
+func TestRemoveStation_ActiveBookings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.
+		EXPECT().
+		RemoveStation(gomock.Any(), request.RemoveStationRequest{ID: "abc123"}).
+		Return(usecase.ErrStationHasActiveBookings)
+	mockUsecase.
+		EXPECT().
+		RemoveStation(gomock.Any(), request.RemoveStationRequest{ID: "abc123", Force: true}).
+		Return(nil)
+
+	req := httptest.NewRequest("DELETE", "/stations/abc123", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	req = httptest.NewRequest("DELETE", "/stations/abc123?force=true", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestGetStationByID_AsOf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.
+		EXPECT().
+		GetStationAsOf(gomock.Any(), request.GetStationAsOfRequest{ID: "abc123", AsOf: "2025-04-01T00:00:00"}).
+		Return(&response.EVStationResponse{ID: "abc123", Name: "Old name", Version: 3}, nil)
+	mockUsecase.
+		EXPECT().
+		GetStationAsOf(gomock.Any(), request.GetStationAsOfRequest{ID: "abc123", AsOf: "2000-01-01T00:00:00"}).
+		Return(nil, usecase.ErrRevisionNotFound)
+
+	req := httptest.NewRequest("GET", "/stations/abc123?as_of=2025-04-01T00:00:00", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Old name")
+	assert.Empty(t, resp.Header().Get("ETag"))
+
+	req = httptest.NewRequest("GET", "/stations/abc123?as_of=2000-01-01T00:00:00", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	mockUsecase.
+		EXPECT().
+		GetStationAsOf(gomock.Any(), request.GetStationAsOfRequest{ID: "abc123", AsOf: "2025-05-01T00:00:00"}).
+		Return(nil, authz.ErrForbidden)
+	req = httptest.NewRequest("GET", "/stations/abc123?as_of=2025-05-01T00:00:00", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestRevertStation_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	expected := int64(9)
+	mockUsecase.
+		EXPECT().
+		RevertStation(gomock.Any(), request.RevertStationRequest{ID: "abc123", Version: 4, ExpectedVersion: &expected}).
+		Return(&response.EVStationResponse{ID: "abc123", Version: 10}, nil)
+
+	req := httptest.NewRequest("POST", "/stations/abc123/revisions/4/revert", nil)
+	req.Header.Set("If-Match", `"9"`)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `"10"`, resp.Header().Get("ETag"))
+}
+
+func TestRevertStation_InvalidVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	req := httptest.NewRequest("POST", "/stations/abc123/revisions/latest/revert", nil)
+	req.Header.Set("If-Match", "*")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestImportStations_CSVBodyDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	body := "external_ref,name,latitude,longitude,company,connector_type,plug_name,price_per_unit,power_output\n"
+	mockUsecase.EXPECT().
+		ImportStations(gomock.Any(), request.ImportStationsRequest{Format: "csv", DryRun: true, Data: []byte(body)}).
+		Return(&response.StationImportReport{DryRun: true, Total: 1, Failed: 1}, nil)
+
+	req := httptest.NewRequest("POST", "/stations/import?dry_run=true", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "text/csv")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var report response.StationImportReport
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &report))
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Failed)
+}
+
+func TestImportStations_InvalidFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.EXPECT().
+		ImportStations(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("%w: missing column %q", usecase.ErrInvalidImportFile, "name"))
+
+	req := httptest.NewRequest("POST", "/stations/import", bytes.NewBufferString("external_ref\n"))
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func exportTestStations(_ context.Context, _ request.StationFilterRequest, emit func(response.EVStationResponse) error) error {
+	var daily []response.DayScheduleResponse
+	for _, day := range []string{"MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"} {
+		daily = append(daily, response.DayScheduleResponse{Day: day, Intervals: []response.TimeIntervalResponse{{Open: "08:00", Close: "20:00"}}})
+	}
+
+	for _, station := range []response.EVStationResponse{
+		{ID: "s1", Name: "Central, Plaza", Latitude: 13.75, Longitude: 100.5, Company: "EV Co", Status: response.StationStatusResponse{TimeZone: "Asia/Bangkok", Weekly: daily}, Connectors: []response.ConnectorResponse{
+			{ConnectorID: "C1", Type: "AC", PlugName: "TYPE 2", PricePerUnit: 5, PowerOutput: 22},
+			{ConnectorID: "C2", Type: "DC", PlugName: "CCS TYPE 2", PricePerUnit: 9.5, PowerOutput: 120},
+		}},
+		{ID: "s2", Name: "Empty", Latitude: 13.8, Longitude: 100.6, Company: "EV Co"},
+	} {
+		if err := emit(station); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportStations_CSVFlattensConnectors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.EXPECT().
+		ExportStations(gomock.Any(), request.StationFilterRequest{Company: "EV Co"}, gomock.Any()).
+		DoAndReturn(exportTestStations)
+
+	req := httptest.NewRequest("GET", "/stations/export?format=csv&company=EV+Co", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 4)
+	assert.Equal(t, []string{"station_id", "external_ref", "name", "latitude", "longitude", "company", "time_zone", "always_open", "open_hours", "close_hours", "is_open",
+		"connector_id", "connector_type", "plug_name", "price_per_unit", "power_output"}, records[0])
+	assert.Equal(t, []string{"s1", "", "Central, Plaza", "13.75", "100.5", "EV Co", "Asia/Bangkok", "false", "08:00", "20:00", "false", "C2", "DC", "CCS TYPE 2", "9.5", "120"}, records[2])
+	assert.Equal(t, []string{"", ""}, records[3][8:10])
+	assert.Equal(t, "", records[3][11])
+}
+
+func TestExportStations_GeoJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.EXPECT().ExportStations(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(exportTestStations)
+
+	req := httptest.NewRequest("GET", "/stations/export?format=geojson", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			ID       string `json:"id"`
+			Geometry struct {
+				Coordinates []float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties struct {
+				Connectors []map[string]interface{} `json:"connectors"`
+			} `json:"properties"`
+		} `json:"features"`
+	}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &collection))
+	assert.Equal(t, "FeatureCollection", collection.Type)
+	assert.Len(t, collection.Features, 2)
+	assert.Equal(t, []float64{100.5, 13.75}, collection.Features[0].Geometry.Coordinates)
+	assert.Len(t, collection.Features[0].Properties.Connectors, 2)
+}
+
+func TestExportStations_KML(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.EXPECT().ExportStations(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(exportTestStations)
+
+	req := httptest.NewRequest("GET", "/stations/export?format=kml", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var document struct {
+		Placemarks []struct {
+			Name        string `xml:"name"`
+			Coordinates string `xml:"Point>coordinates"`
+		} `xml:"Document>Placemark"`
+	}
+	assert.NoError(t, xml.Unmarshal(resp.Body.Bytes(), &document))
+	assert.Len(t, document.Placemarks, 2)
+	assert.Equal(t, "Central, Plaza", document.Placemarks[0].Name)
+	assert.Equal(t, "100.5,13.75", document.Placemarks[0].Coordinates)
+}
+
+func TestExportStations_InvalidFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	req := httptest.NewRequest("GET", "/stations/export?format=xlsx", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestExportStations_ErrorBeforeFirstStation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockEVStationUsecase(ctrl)
+	router := setupRouterWithStationHandler(mockUsecase)
+
+	mockUsecase.EXPECT().ExportStations(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: invalid status value: maybe", usecase.ErrInvalidStationQuery))
+
+	req := httptest.NewRequest("GET", "/stations/export?format=csv&status=maybe", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "invalid status value")
+}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorHandler struct {
+	twoFactorUsecase usecase.TwoFactorUsecase
+}
+
+func NewTwoFactorHandler(usecase usecase.TwoFactorUsecase) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorUsecase: usecase}
+}
+
+// VerifyLogin completes a login that answered with a challenge token
+func (h *TwoFactorHandler) VerifyLogin(c *gin.Context) {
+	var req request.TwoFactorLoginRequest
+	if !bindTwoFactorRequest(c, &req) {
+		return
+	}
+	req.ClientIP = c.ClientIP()
+
+	tokens, err := h.twoFactorUsecase.VerifyLoginChallenge(c.Request.Context(), req)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// SetupFromChallenge starts the enrollment an account must complete before it can log in
+func (h *TwoFactorHandler) SetupFromChallenge(c *gin.Context) {
+	var req request.TwoFactorChallengeSetupRequest
+	if !bindTwoFactorRequest(c, &req) {
+		return
+	}
+
+	setup, err := h.twoFactorUsecase.SetupFromChallenge(c.Request.Context(), req)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+// SetupTOTP starts an enrollment for the authenticated user
+func (h *TwoFactorHandler) SetupTOTP(c *gin.Context) {
+	setup, err := h.twoFactorUsecase.SetupTOTP(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+// ActivateTOTP confirms the enrollment with a code from the authenticator app
+func (h *TwoFactorHandler) ActivateTOTP(c *gin.Context) {
+	var req request.TOTPCodeRequest
+	if !bindTwoFactorRequest(c, &req) {
+		return
+	}
+	req.UserID = c.GetString("userID")
+
+	codes, err := h.twoFactorUsecase.ActivateTOTP(c.Request.Context(), req)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the authenticated user
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req request.TOTPCodeRequest
+	if !bindTwoFactorRequest(c, &req) {
+		return
+	}
+	req.UserID = c.GetString("userID")
+
+	codes, err := h.twoFactorUsecase.RegenerateRecoveryCodes(c.Request.Context(), req)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+// DisableTOTP turns two-factor authentication off for the authenticated user
+func (h *TwoFactorHandler) DisableTOTP(c *gin.Context) {
+	var req request.TOTPCodeRequest
+	if !bindTwoFactorRequest(c, &req) {
+		return
+	}
+	req.UserID = c.GetString("userID")
+
+	if err := h.twoFactorUsecase.DisableTOTP(c.Request.Context(), req); err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+func bindTwoFactorRequest(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return false
+	}
+
+	if err := validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"validation_error": err.Error()})
+		return false
+	}
+	return true
+}
+
+func respondTwoFactorError(c *gin.Context, err error) {
+	if respondLoginThrottled(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, usecase.ErrInvalidTwoFactorChallenge), errors.Is(err, usecase.ErrInvalidTOTPCode), errors.Is(err, usecase.ErrInvalidEnrollmentCode):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrTOTPRequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrTOTPAlreadyEnabled), errors.Is(err, usecase.ErrTOTPNotEnabled), errors.Is(err, usecase.ErrTOTPSetupNotStarted):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
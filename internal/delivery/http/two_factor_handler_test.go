@@ -0,0 +1,108 @@
+package http_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	deliveryHttp "Ev-Charge-Hub/Server/internal/delivery/http"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTwoFactorRouter(mockUsecase *mocks.MockTwoFactorUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	handler := deliveryHttp.NewTwoFactorHandler(mockUsecase)
+	r.POST("/users/login/2fa", handler.VerifyLogin)
+
+	authenticated := r.Group("/users/2fa", func(c *gin.Context) { c.Set("userID", "u1") })
+	authenticated.POST("/setup", handler.SetupTOTP)
+	authenticated.POST("/disable", handler.DisableTOTP)
+	return r
+}
+
+func postJSON(router *gin.Engine, path string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestVerifyLogin_ReturnsTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockTwoFactorUsecase(ctrl)
+	router := setupTwoFactorRouter(mockUsecase)
+
+	mockUsecase.EXPECT().
+		VerifyLoginChallenge(gomock.Any(), request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456", ClientIP: "192.0.2.1"}).
+		Return(&response.LoginResponse{Token: "access", RefreshToken: "refresh"}, nil)
+
+	resp := postJSON(router, "/users/login/2fa", `{"challenge_token":"challenge","code":"123456"}`)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"token":"access"`)
+}
+
+func TestVerifyLogin_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockTwoFactorUsecase(ctrl)
+	router := setupTwoFactorRouter(mockUsecase)
+
+	gomock.InOrder(
+		mockUsecase.EXPECT().VerifyLoginChallenge(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrInvalidTOTPCode),
+		mockUsecase.EXPECT().VerifyLoginChallenge(gomock.Any(), gomock.Any()).Return(nil, &usecase.LoginThrottledError{RetryAfter: 10 * time.Second, Locked: true}),
+	)
+
+	body := `{"challenge_token":"challenge","code":"000000"}`
+	assert.Equal(t, http.StatusUnauthorized, postJSON(router, "/users/login/2fa", body).Code)
+
+	resp := postJSON(router, "/users/login/2fa", body)
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "10", resp.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusBadRequest, postJSON(router, "/users/login/2fa", `{"code":"000000"}`).Code)
+}
+
+func TestSetupTOTP_UsesAuthenticatedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockTwoFactorUsecase(ctrl)
+	router := setupTwoFactorRouter(mockUsecase)
+
+	mockUsecase.EXPECT().SetupTOTP(gomock.Any(), "u1").
+		Return(&response.TOTPSetupResponse{Secret: "SECRET", ProvisioningURI: "otpauth://totp/x"}, nil)
+
+	resp := postJSON(router, "/users/2fa/setup", ``)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"provisioning_uri":"otpauth://totp/x"`)
+}
+
+func TestDisableTOTP_RequiredIs403(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockTwoFactorUsecase(ctrl)
+	router := setupTwoFactorRouter(mockUsecase)
+
+	mockUsecase.EXPECT().DisableTOTP(gomock.Any(), request.TOTPCodeRequest{UserID: "u1", Code: "123456"}).Return(usecase.ErrTOTPRequired)
+
+	resp := postJSON(router, "/users/2fa/disable", `{"code":"123456"}`)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+}
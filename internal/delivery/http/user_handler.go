@@ -3,7 +3,10 @@ package http
 import (
 	"Ev-Charge-Hub/Server/internal/dto/request"
 	"Ev-Charge-Hub/Server/internal/usecase"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator"
@@ -14,6 +17,10 @@ var validate = validator.New()
 type UserHandlerInterface interface {
 	RegisterUser(c *gin.Context)
 	LoginUser(c *gin.Context)
+	ProvisionUser(c *gin.Context)
+	ChangeUserRole(c *gin.Context)
+	GetAdminAccounts(c *gin.Context)
+	UnlockUser(c *gin.Context)
 }
 
 // define class userHandler
@@ -40,6 +47,10 @@ func (h *userHandler) RegisterUser(c *gin.Context) {
 	}
 
 	if err := h.userUsecase.RegisterUser(c.Request.Context(), req); err != nil {
+		if errors.Is(err, usecase.ErrEmailAlreadyExists) || errors.Is(err, usecase.ErrUsernameAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -60,7 +71,12 @@ func (h *userHandler) LoginUser(c *gin.Context) {
 		return
 	}
 
+	req.ClientIP = c.ClientIP()
+
 	token, err := h.userUsecase.LoginUser(c.Request.Context(), req)
+	if respondLoginThrottled(c, err) {
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
@@ -68,3 +84,97 @@ func (h *userHandler) LoginUser(c *gin.Context) {
 
 	c.JSON(http.StatusOK, token)
 }
+
+
+// ProvisionUser creates an account with any role (ADMIN only)
+func (h *userHandler) ProvisionUser(c *gin.Context) {
+	var req request.ProvisionUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"validation_error": err.Error()})
+		return
+	}
+
+	user, err := h.userUsecase.ProvisionUser(c.Request.Context(), req)
+	if err != nil {
+		respondUserAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// ChangeUserRole promotes or demotes an account (ADMIN only)
+func (h *userHandler) ChangeUserRole(c *gin.Context) {
+	var req request.ChangeUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"validation_error": err.Error()})
+		return
+	}
+	req.UserID = c.Param("id")
+
+	user, err := h.userUsecase.ChangeUserRole(c.Request.Context(), req)
+	if err != nil {
+		respondUserAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// GetAdminAccounts lists the ADMIN accounts; those without created_by were self-registered
+func (h *userHandler) GetAdminAccounts(c *gin.Context) {
+	admins, err := h.userUsecase.GetAdminAccounts(c.Request.Context())
+	if err != nil {
+		respondUserAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, admins)
+}
+
+// UnlockUser lifts a login lockout of the account (ADMIN only)
+func (h *userHandler) UnlockUser(c *gin.Context) {
+	if err := h.userUsecase.UnlockUser(c.Request.Context(), c.Param("id")); err != nil {
+		respondUserAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User unlocked"})
+}
+
+// respondLoginThrottled answers 429 with Retry-After in seconds when err is a *usecase.LoginThrottledError
+func respondLoginThrottled(c *gin.Context, err error) bool {
+	var throttled *usecase.LoginThrottledError
+	if !errors.As(err, &throttled) {
+		return false
+	}
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	return true
+}
+
+func respondUserAdminError(c *gin.Context, err error) {
+	if respondForbidden(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, usecase.ErrInvalidRole):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrEmailAlreadyExists), errors.Is(err, usecase.ErrUsernameAlreadyExists), errors.Is(err, usecase.ErrLastAdmin):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
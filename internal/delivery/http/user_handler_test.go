@@ -5,12 +5,14 @@ import (
 	"Ev-Charge-Hub/Server/internal/dto/request"
 	"Ev-Charge-Hub/Server/internal/dto/response"
 	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/usecase"
 	"bytes"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -41,7 +43,6 @@ func TestRegisterUser_Success(t *testing.T) {
 		Username: "test",
 		Email:    "test@example.com",
 		Password: "password123",
-		Role:     "USER",
 	}
 	mockUsecase.EXPECT().RegisterUser(gomock.Any(), reqBody).Return(nil)
 
@@ -69,6 +70,7 @@ func TestLoginUser_Success(t *testing.T) {
 	reqBody := request.LoginRequest{
 		UsernameOrEmail: "test@example.com",
 		Password:        "password123",
+		ClientIP:        "192.0.2.1", // httptest.NewRequest's remote address
 	}
 	expectedResp := &response.LoginResponse{Token: "mocked-jwt-token"}
 	mockUsecase.EXPECT().LoginUser(gomock.Any(), reqBody).Return(expectedResp, nil)
@@ -117,6 +119,7 @@ func TestLoginUser_WrongPassword(t *testing.T) {
 	loginReq := request.LoginRequest{
 		UsernameOrEmail: "user@example.com",
 		Password:        "wrongpass",
+		ClientIP:        "192.0.2.1",
 	}
 
 	mockUsecase.
@@ -146,7 +149,6 @@ func TestRegisterUser_UsecaseError(t *testing.T) {
 		Username: "test",
 		Email:    "test@example.com",
 		Password: "password123",
-		Role:     "USER",
 	}
 
 	mockUsecase.
@@ -164,3 +166,154 @@ func TestRegisterUser_UsecaseError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
 	assert.Contains(t, resp.Body.String(), "email already exists")
 }
+
+func TestRegisterUser_RoleInBodyIsIgnored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUserUsecaseInterface(ctrl)
+	router := setupRouterWithUserHandler(mockUsecase)
+
+	mockUsecase.EXPECT().
+		RegisterUser(gomock.Any(), request.RegisterUserRequest{Username: "mallory", Email: "m@example.com", Password: "password123"}).
+		Return(nil)
+
+	body := `{"username":"mallory","email":"m@example.com","password":"password123","role":"ADMIN"}`
+	req := httptest.NewRequest("POST", "/register", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func setupAdminUserRouter(mockUsecase *mocks.MockUserUsecaseInterface) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	handler := deliveryHttp.NewUserHandler(mockUsecase)
+	router.POST("/admin/users", handler.ProvisionUser)
+	router.PUT("/admin/users/:id/role", handler.ChangeUserRole)
+	router.POST("/admin/users/:id/unlock", handler.UnlockUser)
+	return router
+}
+
+func TestProvisionUser_Created(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUserUsecaseInterface(ctrl)
+	router := setupAdminUserRouter(mockUsecase)
+
+	reqBody := request.ProvisionUserRequest{Username: "ops1", Email: "ops@example.com", Password: "password123", Role: "ADMIN"}
+	mockUsecase.EXPECT().
+		ProvisionUser(gomock.Any(), reqBody).
+		Return(&response.UserResponse{ID: "a2", Username: "ops1", Role: "ADMIN", CreatedBy: "root"}, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/admin/users", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"created_by":"root"`)
+}
+
+func TestChangeUserRole_LastAdminIs409(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUserUsecaseInterface(ctrl)
+	router := setupAdminUserRouter(mockUsecase)
+
+	mockUsecase.EXPECT().
+		ChangeUserRole(gomock.Any(), request.ChangeUserRoleRequest{UserID: "a1", Role: "USER"}).
+		Return(nil, usecase.ErrLastAdmin)
+
+	req := httptest.NewRequest("PUT", "/admin/users/a1/role", bytes.NewBufferString(`{"role":"USER"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}
+
+func TestChangeUserRole_InvalidRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUserUsecaseInterface(ctrl)
+	router := setupAdminUserRouter(mockUsecase)
+
+	req := httptest.NewRequest("PUT", "/admin/users/a1/role", bytes.NewBufferString(`{"role":"ROOT"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestRegisterUser_DuplicateIs409(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUserUsecaseInterface(ctrl)
+	router := setupRouterWithUserHandler(mockUsecase)
+
+	mockUsecase.EXPECT().RegisterUser(gomock.Any(), gomock.Any()).Return(usecase.ErrUsernameAlreadyExists)
+
+	body, _ := json.Marshal(request.RegisterUserRequest{Username: "alice", Email: "alice@example.com", Password: "password123"})
+	req := httptest.NewRequest("POST", "/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Contains(t, resp.Body.String(), "username already exists")
+}
+
+func TestLoginUser_ThrottledIs429WithRetryAfter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUserUsecaseInterface(ctrl)
+	router := setupRouterWithUserHandler(mockUsecase)
+
+	mockUsecase.EXPECT().
+		LoginUser(gomock.Any(), gomock.Any()).
+		Return(nil, &usecase.LoginThrottledError{RetryAfter: 1500 * time.Millisecond})
+
+	body := `{"username_or_email":"user@example.com","password":"password123"}`
+	req := httptest.NewRequest("POST", "/login", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "2", resp.Header().Get("Retry-After"))
+}
+
+func TestUnlockUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUserUsecaseInterface(ctrl)
+	router := setupAdminUserRouter(mockUsecase)
+
+	mockUsecase.EXPECT().UnlockUser(gomock.Any(), "u1").Return(nil)
+	mockUsecase.EXPECT().UnlockUser(gomock.Any(), "missing").Return(usecase.ErrUserNotFound)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest("POST", "/admin/users/u1/unlock", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest("POST", "/admin/users/missing/unlock", nil))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VehicleHandler struct {
+	vehicleUsecase usecase.VehicleUsecase
+}
+
+func NewVehicleHandler(usecase usecase.VehicleUsecase) *VehicleHandler {
+	return &VehicleHandler{vehicleUsecase: usecase}
+}
+
+func (h *VehicleHandler) GetVehicleCatalog(c *gin.Context) {
+	c.JSON(http.StatusOK, h.vehicleUsecase.GetVehicleCatalog(c.Request.Context()))
+}
+
+func (h *VehicleHandler) GetVehicles(c *gin.Context) {
+	vehicles, err := h.vehicleUsecase.GetVehicles(c.Request.Context(), request.GetVehiclesRequest{UserID: c.GetString("userID")})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, vehicles)
+}
+
+func (h *VehicleHandler) GetVehicleByID(c *gin.Context) {
+	vehicle, err := h.vehicleUsecase.GetVehicleByID(c.Request.Context(), request.GetVehicleByIDRequest{
+		ID:     c.Param("id"),
+		UserID: c.GetString("userID"),
+	})
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, vehicle)
+}
+
+func (h *VehicleHandler) CreateVehicle(c *gin.Context) {
+	var vehicleReq request.VehicleRequest
+	if err := c.ShouldBindJSON(&vehicleReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vehicle data"})
+		return
+	}
+
+	if err := validate.Struct(vehicleReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"validation_error": err.Error()})
+		return
+	}
+
+	vehicleReq.UserID = c.GetString("userID")
+
+	vehicle, err := h.vehicleUsecase.CreateVehicle(c.Request.Context(), vehicleReq)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Vehicle created successfully",
+		"vehicle": vehicle,
+	})
+}
+
+func (h *VehicleHandler) EditVehicle(c *gin.Context) {
+	var vehicleReq request.VehicleRequest
+	if err := c.ShouldBindJSON(&vehicleReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vehicle data"})
+		return
+	}
+
+	if err := validate.Struct(vehicleReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"validation_error": err.Error()})
+		return
+	}
+
+	vehicleReq.ID = c.Param("id")
+	vehicleReq.UserID = c.GetString("userID")
+
+	vehicle, err := h.vehicleUsecase.EditVehicle(c.Request.Context(), vehicleReq)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Vehicle updated successfully",
+		"vehicle": vehicle,
+	})
+}
+
+func (h *VehicleHandler) RemoveVehicle(c *gin.Context) {
+	err := h.vehicleUsecase.RemoveVehicle(c.Request.Context(), request.RemoveVehicleRequest{
+		ID:     c.Param("id"),
+		UserID: c.GetString("userID"),
+	})
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Vehicle removed successfully"})
+}
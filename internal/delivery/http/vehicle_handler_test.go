@@ -0,0 +1,92 @@
+package http_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	deliveryHttp "Ev-Charge-Hub/Server/internal/delivery/http"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRouterWithVehicleHandler(mockUsecase *mocks.MockVehicleUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	handler := deliveryHttp.NewVehicleHandler(mockUsecase)
+
+	// simulate AuthMiddleware
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user1")
+		c.Next()
+	})
+	r.GET("/vehicles/catalog", handler.GetVehicleCatalog)
+	r.POST("/vehicles", handler.CreateVehicle)
+	r.GET("/vehicles/:id", handler.GetVehicleByID)
+
+	return r
+}
+
+func TestGetVehicleCatalog_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockVehicleUsecase(ctrl)
+	router := setupRouterWithVehicleHandler(mockUsecase)
+
+	mockUsecase.EXPECT().GetVehicleCatalog(gomock.Any()).Return([]response.VehicleCatalogResponse{
+		{CatalogID: "nissan-leaf-40", Make: "Nissan"},
+	})
+
+	req := httptest.NewRequest("GET", "/vehicles/catalog", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "nissan-leaf-40")
+}
+
+func TestCreateVehicle_UsesAuthenticatedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockVehicleUsecase(ctrl)
+	router := setupRouterWithVehicleHandler(mockUsecase)
+
+	mockUsecase.EXPECT().
+		CreateVehicle(gomock.Any(), request.VehicleRequest{UserID: "user1", CatalogID: "mg-4"}).
+		Return(&response.VehicleResponse{ID: "v1", Make: "MG"}, nil)
+
+	req := httptest.NewRequest("POST", "/vehicles", bytes.NewBufferString(`{"catalog_id":"mg-4"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Vehicle created successfully")
+}
+
+func TestGetVehicleByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockVehicleUsecase(ctrl)
+	router := setupRouterWithVehicleHandler(mockUsecase)
+
+	mockUsecase.EXPECT().
+		GetVehicleByID(gomock.Any(), request.GetVehicleByIDRequest{ID: "v9", UserID: "user1"}).
+		Return(nil, errors.New("vehicle not found"))
+
+	req := httptest.NewRequest("GET", "/vehicles/v9", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
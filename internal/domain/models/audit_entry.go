@@ -0,0 +1,40 @@
+package models
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	"time"
+)
+
+// AuditEntry is one immutable record of a change made to a station or a user
+type AuditEntry struct {
+	ID         string
+	ActorID    string
+	ActorName  string
+	Action     constants.AuditAction
+	TargetType string
+	TargetID   string
+	RequestID  string
+	Timestamp  time.Time
+	Changes    []AuditChange
+}
+
+// AuditChange is the before/after value of one field, addressed by a dotted path such as "connectors.0.price_per_unit"
+type AuditChange struct {
+	Field  string
+	Before interface{}
+	After  interface{}
+}
+
+// AuditFilter selects audit entries; zero values are ignored
+type AuditFilter struct {
+	ActorID    string
+	ActorName  string
+	Action     constants.AuditAction
+	TargetType string
+	TargetID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int64
+	Skip       int64
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	"math"
+)
+
+const (
+	// DC charging slows down once the battery passes this state of charge (%)
+	dcTaperStartSoC = 80.0
+	// fraction of the peak DC power still delivered at 100% state of charge
+	dcTaperFloor = 0.25
+	// integration step (% state of charge)
+	socStep = 0.1
+)
+
+type ChargeEstimate struct {
+	EnergyKWh      float64
+	Minutes        float64
+	Cost           float64
+	AveragePowerKW float64
+}
+
+// EstimateCharge estimates how long and how much it costs to charge from startSoC to targetSoC (%).
+// AC charging is modelled at constant power; DC charging tapers linearly above 80%.
+func EstimateCharge(connectorType constants.ConnectorType, powerKW float64, batteryKWh float64, startSoC float64, targetSoC float64, pricePerUnit float64) ChargeEstimate {
+	if powerKW <= 0 || batteryKWh <= 0 || targetSoC <= startSoC {
+		return ChargeEstimate{}
+	}
+
+	energy := batteryKWh * (targetSoC - startSoC) / 100
+	steps := int(math.Max(1, math.Round((targetSoC-startSoC)/socStep)))
+	step := (targetSoC - startSoC) / float64(steps)
+
+	hours := 0.0
+	for i := 0; i < steps; i++ {
+		midpoint := startSoC + (float64(i)+0.5)*step
+		hours += (batteryKWh * step / 100) / chargePowerAt(connectorType, powerKW, midpoint)
+	}
+
+	return ChargeEstimate{
+		EnergyKWh:      round2(energy),
+		Minutes:        math.Round(hours*600) / 10,
+		Cost:           round2(energy * pricePerUnit),
+		AveragePowerKW: round2(energy / hours),
+	}
+}
+
+func chargePowerAt(connectorType constants.ConnectorType, powerKW float64, soc float64) float64 {
+	if connectorType != constants.DC || soc <= dcTaperStartSoC {
+		return powerKW
+	}
+	progress := (soc - dcTaperStartSoC) / (100 - dcTaperStartSoC)
+	return powerKW * (1 - (1-dcTaperFloor)*progress)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,8 @@
+package models
+
+// DuplicateUserGroup is a set of accounts whose username or email differ only in letter case
+type DuplicateUserGroup struct {
+	Field string // "username" or "email"
+	Value string // the shared value, lower-cased
+	Users []UserModel
+}
@@ -8,19 +8,20 @@ import (
 )
 
 type EVStation struct {
-	ID         primitive.ObjectID
-	Name       string
-	Latitude   float64
-	Longitude  float64
-	Company    string
-	Status     StationStatus
-	Connectors []Connector
+	ID                 primitive.ObjectID
+	ExternalRef        string
+	Name               string
+	Latitude           float64
+	Longitude          float64
+	Company            string
+	Status             StationStatus
+	Connectors         []Connector
+	MaintenanceWindows []MaintenanceWindow
+	Version            int64 // version the station was read at; writes fail if it has moved
 }
 
 type StationStatus struct {
-	OpenHours  string
-	CloseHours string
-	IsOpen     bool
+	Schedule OpeningHours
 }
 
 type Connector struct {
@@ -36,3 +37,24 @@ type Booking struct {
 	Username       string
 	BookingEndTime time.Time
 }
+
+// MaintenanceWindow takes the whole station (empty ConnectorID) or one connector out of service
+type MaintenanceWindow struct {
+	ID          string
+	Scope       constants.MaintenanceScope
+	ConnectorID string
+	StartTime   time.Time
+	EndTime     time.Time
+	Reason      string
+	CreatedBy   string
+}
+
+// Covers reports whether the window applies to the connector
+func (w MaintenanceWindow) Covers(connectorID string) bool {
+	return w.Scope == constants.MaintenanceScopeStation || w.ConnectorID == connectorID
+}
+
+// Overlaps reports whether the window intersects [from, to)
+func (w MaintenanceWindow) Overlaps(from time.Time, to time.Time) bool {
+	return w.StartTime.Before(to) && from.Before(w.EndTime)
+}
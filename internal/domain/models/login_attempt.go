@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// LoginAttempt counts the recent failed logins of one key, an account ("user:<id>") or a
+// client IP ("ip:<address>")
+type LoginAttempt struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// OCPIPartner is a roaming platform allowed to pull our locations over OCPI.
+// Tokens we issue are only kept as SHA-256 hashes; TokenB is the partner's token for calling them.
+type OCPIPartner struct {
+	ID          string
+	Name        string
+	Status      string
+	TokenAHash  string
+	TokenCHash  string
+	TokenB      string
+	VersionsURL string
+	Version     string
+	Endpoints   []OCPIEndpoint
+	Roles       []OCPIRole
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// OCPIEndpoint is one module the partner exposes for the negotiated version
+type OCPIEndpoint struct {
+	Identifier string
+	Role       string
+	URL        string
+}
+
+// OCPIRole is one party (country code + party ID) the partner acts for
+type OCPIRole struct {
+	Role         string
+	CountryCode  string
+	PartyID      string
+	BusinessName string
+}
@@ -0,0 +1,236 @@
+package models
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// embed the IANA database so station time zones resolve on minimal images
+	_ "time/tzdata"
+)
+
+// OpeningHours is a station's weekly schedule evaluated in its own time zone
+type OpeningHours struct {
+	TimeZone   string
+	AlwaysOpen bool
+	Weekly     []DaySchedule
+	Exceptions []ScheduleException
+}
+
+type DaySchedule struct {
+	Day       string // MON ... SUN
+	Intervals []TimeInterval
+}
+
+// TimeInterval is an "HH:MM" range; a Close at or before Open runs past midnight
+type TimeInterval struct {
+	Open  string
+	Close string
+}
+
+// ScheduleException overrides the weekly schedule for one date (holidays, special hours)
+type ScheduleException struct {
+	Date      string // YYYY-MM-DD in the station time zone
+	Closed    bool
+	Intervals []TimeInterval
+	Reason    string
+}
+
+const exceptionDateLayout = "2006-01-02"
+
+var weekdayCodes = map[string]time.Weekday{
+	"SUN": time.Sunday,
+	"MON": time.Monday,
+	"TUE": time.Tuesday,
+	"WED": time.Wednesday,
+	"THU": time.Thursday,
+	"FRI": time.Friday,
+	"SAT": time.Saturday,
+}
+
+// WeekdayCodes lists the accepted day codes in calendar order starting Monday
+var WeekdayCodes = []string{"MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"}
+
+// Location resolves the schedule time zone, falling back to the service default
+func (h OpeningHours) Location() *time.Location {
+	tz := h.TimeZone
+	if tz == "" {
+		tz = constants.DefaultTimeZone
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// IsOpenAt reports whether the station is open at the given instant
+func (h OpeningHours) IsOpenAt(t time.Time) bool {
+	local := t.In(h.Location())
+	minute := local.Hour()*60 + local.Minute()
+
+	for _, iv := range h.intervalsOn(local) {
+		open, close, err := iv.minutes()
+		if err != nil {
+			continue
+		}
+		if close <= open {
+			if minute >= open {
+				return true
+			}
+		} else if minute >= open && minute < close {
+			return true
+		}
+	}
+
+	// overnight intervals that started the day before
+	for _, iv := range h.intervalsOn(local.AddDate(0, 0, -1)) {
+		open, close, err := iv.minutes()
+		if err != nil {
+			continue
+		}
+		if close <= open && minute < close {
+			return true
+		}
+	}
+	return false
+}
+
+// intervalsOn returns the effective intervals for the calendar day of t
+func (h OpeningHours) intervalsOn(t time.Time) []TimeInterval {
+	date := t.Format(exceptionDateLayout)
+	for _, ex := range h.Exceptions {
+		if ex.Date == date {
+			if ex.Closed {
+				return nil
+			}
+			return ex.Intervals
+		}
+	}
+
+	if h.AlwaysOpen {
+		return []TimeInterval{{Open: "00:00", Close: "24:00"}}
+	}
+
+	var intervals []TimeInterval
+	for _, day := range h.Weekly {
+		if weekdayCodes[strings.ToUpper(day.Day)] == t.Weekday() {
+			intervals = append(intervals, day.Intervals...)
+		}
+	}
+	return intervals
+}
+
+// Validate checks the time zone, day codes, times and exception dates
+func (h OpeningHours) Validate() error {
+	if h.TimeZone != "" {
+		if _, err := time.LoadLocation(h.TimeZone); err != nil {
+			return fmt.Errorf("invalid time_zone: %s", h.TimeZone)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, day := range h.Weekly {
+		code := strings.ToUpper(day.Day)
+		if _, ok := weekdayCodes[code]; !ok {
+			return fmt.Errorf("invalid day: %s", day.Day)
+		}
+		if seen[code] {
+			return fmt.Errorf("duplicate day: %s", day.Day)
+		}
+		seen[code] = true
+		if err := validateIntervals(day.Intervals); err != nil {
+			return fmt.Errorf("%s: %v", code, err)
+		}
+	}
+
+	for _, ex := range h.Exceptions {
+		if _, err := time.Parse(exceptionDateLayout, ex.Date); err != nil {
+			return fmt.Errorf("invalid exception date: %s", ex.Date)
+		}
+		if !ex.Closed && len(ex.Intervals) == 0 {
+			return fmt.Errorf("exception %s must be closed or have intervals", ex.Date)
+		}
+		if err := validateIntervals(ex.Intervals); err != nil {
+			return fmt.Errorf("%s: %v", ex.Date, err)
+		}
+	}
+
+	if !h.AlwaysOpen && len(h.Weekly) == 0 {
+		return errors.New("weekly schedule is required unless always_open is set")
+	}
+	return nil
+}
+
+// LegacyOpeningHours converts the old free-text open/close pair into a daily schedule
+func LegacyOpeningHours(openHours string, closeHours string, timeZone string) (OpeningHours, error) {
+	iv := TimeInterval{Open: strings.TrimSpace(openHours), Close: strings.TrimSpace(closeHours)}
+	if _, _, err := iv.minutes(); err != nil {
+		return OpeningHours{}, fmt.Errorf("cannot convert hours %q-%q: %v", openHours, closeHours, err)
+	}
+
+	hours := OpeningHours{TimeZone: timeZone}
+	if iv.Open == "00:00" && (iv.Close == "23:59" || iv.Close == "24:00" || iv.Close == "00:00") {
+		hours.AlwaysOpen = true
+		return hours, nil
+	}
+
+	for _, code := range WeekdayCodes {
+		hours.Weekly = append(hours.Weekly, DaySchedule{Day: code, Intervals: []TimeInterval{iv}})
+	}
+	return hours, nil
+}
+
+func validateIntervals(intervals []TimeInterval) error {
+	for _, iv := range intervals {
+		open, close, err := iv.minutes()
+		if err != nil {
+			return err
+		}
+		if open == close {
+			return fmt.Errorf("interval %s-%s is empty", iv.Open, iv.Close)
+		}
+	}
+	return nil
+}
+
+func (iv TimeInterval) minutes() (int, int, error) {
+	open, err := parseClock(iv.Open)
+	if err != nil {
+		return 0, 0, err
+	}
+	close, err := parseClock(iv.Close)
+	if err != nil {
+		return 0, 0, err
+	}
+	if open == 24*60 {
+		return 0, 0, fmt.Errorf("invalid open time: %s", iv.Open)
+	}
+	return open, close, nil
+}
+
+// parseClock parses "HH:MM" into minutes after midnight; "24:00" is allowed as an end of day
+func parseClock(value string) (int, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("invalid time: %q", value)
+	}
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid time: %q", value)
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid time: %q", value)
+	}
+	if hour == 24 && minute == 0 {
+		return 24 * 60, nil
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("invalid time: %q", value)
+	}
+	return hour*60 + minute, nil
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// PasswordResetToken is an emailed reset link; only the SHA-256 hash of the token is stored
+type PasswordResetToken struct {
+	ID        string
+	TokenHash string
+	UserID    string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time // set when the token is redeemed or superseded by a newer one
+}
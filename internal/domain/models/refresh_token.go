@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// RefreshToken is one link of a login session's rotation chain. Refreshing marks it used and
+// issues the next link in the same family; presenting a used link again revokes the family.
+type RefreshToken struct {
+	ID        string
+	TokenHash string
+	FamilyID  string
+	UserID    string
+	AccessJTI string // jti of the access token issued together with this refresh token
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+}
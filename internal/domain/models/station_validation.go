@@ -0,0 +1,56 @@
+package models
+
+import "fmt"
+
+// FieldError is a broken station rule; Field is the JSON path of the offending field
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Validate checks the coordinates and every connector. Opening hours are checked by OpeningHours.Validate.
+func (s EVStation) Validate() []FieldError {
+	var fieldErrors []FieldError
+	if s.Latitude < -90 || s.Latitude > 90 {
+		fieldErrors = append(fieldErrors, FieldError{Field: "latitude", Message: "must be between -90 and 90"})
+	}
+	if s.Longitude < -180 || s.Longitude > 180 {
+		fieldErrors = append(fieldErrors, FieldError{Field: "longitude", Message: "must be between -180 and 180"})
+	}
+
+	for i, c := range s.Connectors {
+		prefix := fmt.Sprintf("connectors[%d].", i)
+		for _, e := range c.Validate() {
+			fieldErrors = append(fieldErrors, FieldError{Field: prefix + e.Field, Message: e.Message})
+		}
+	}
+	return fieldErrors
+}
+
+// Validate checks that the plug is known and carries the connector's current, that the power
+// output is in range for the current and that the price is positive. Fields are relative to the connector.
+func (c Connector) Validate() []FieldError {
+	var fieldErrors []FieldError
+
+	current, known := c.PlugName.ConnectorType()
+	mismatched := known && c.Type != current
+	if !known {
+		fieldErrors = append(fieldErrors, FieldError{Field: "plug_name", Message: fmt.Sprintf("unknown plug %q", c.PlugName)})
+	} else if mismatched {
+		fieldErrors = append(fieldErrors, FieldError{Field: "type", Message: fmt.Sprintf("plug %s is %s, not %s", c.PlugName, current, c.Type)})
+	}
+
+	// the power range is only meaningful once the current is settled
+	if min, max, ok := c.Type.PowerRange(); !ok {
+		if !known {
+			fieldErrors = append(fieldErrors, FieldError{Field: "type", Message: fmt.Sprintf("unknown connector type %q", c.Type)})
+		}
+	} else if !mismatched && (c.PowerOutput < min || c.PowerOutput > max) {
+		fieldErrors = append(fieldErrors, FieldError{Field: "power_output", Message: fmt.Sprintf("must be between %d and %d kW for %s", min, max, c.Type)})
+	}
+
+	if c.PricePerUnit <= 0 {
+		fieldErrors = append(fieldErrors, FieldError{Field: "price_per_unit", Message: "must be positive"})
+	}
+	return fieldErrors
+}
@@ -5,14 +5,27 @@ import (
 )
 
 type UserModel struct {
-	ID        string    
-	Username  string
-	Email     string
-	Password  string
-	Role      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID       string
+	Username string
+	Email    string
+	Password string
+	Role     string
+	// CreatedBy is who provisioned the account; empty for self-registration
+	CreatedBy string
+	// EmailVerified is set once the user opens the link mailed at registration
+	EmailVerified   bool
+	EmailVerifiedAt *time.Time
+	// TOTPEnabled is set once an authenticator app is confirmed; TOTPPendingSecret holds the
+	// secret of an enrollment that has not been confirmed yet
+	TOTPEnabled            bool
+	TOTPSecret             string
+	TOTPPendingSecret      string
+	TOTPRecoveryCodeHashes []string
+	// TOTPEnrollmentCodeHash is the hash of the code mailed for an enrollment started at login
+	TOTPEnrollmentCodeHash string
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
 }
 
 // json: key name when Post to client(ถูกแปลงเป็น JSON)
-// bson: key name when Get from DB(ถูกใช้เก็บหรือดึงข้อมูลจาก MongoDB)
\ No newline at end of file
+// bson: key name when Get from DB(ถูกใช้เก็บหรือดึงข้อมูลจาก MongoDB)
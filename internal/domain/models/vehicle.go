@@ -0,0 +1,55 @@
+package models
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	"time"
+)
+
+type Vehicle struct {
+	ID             string
+	UserID         string
+	CatalogID      string
+	Make           string
+	Model          string
+	BatteryKWh     float64
+	SupportedPlugs []constants.PlugName
+	MaxACPowerKW   float64
+	MaxDCPowerKW   float64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// SupportsPlug reports whether the vehicle can physically connect to the plug
+func (v Vehicle) SupportsPlug(plug constants.PlugName) bool {
+	for _, p := range v.SupportedPlugs {
+		if p == plug {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxPowerFor returns the maximum power (kW) the vehicle accepts for a connector type
+func (v Vehicle) MaxPowerFor(connectorType constants.ConnectorType) float64 {
+	switch connectorType {
+	case constants.AC:
+		return v.MaxACPowerKW
+	case constants.DC:
+		return v.MaxDCPowerKW
+	}
+	return 0
+}
+
+// CanUse reports whether the vehicle can charge on a connector with the given type and plug
+func (v Vehicle) CanUse(connectorType constants.ConnectorType, plug constants.PlugName) bool {
+	return v.SupportsPlug(plug) && v.MaxPowerFor(connectorType) > 0
+}
+
+// EffectivePower caps the connector output by what the vehicle can accept
+func (v Vehicle) EffectivePower(connectorType constants.ConnectorType, powerOutput int) float64 {
+	limit := v.MaxPowerFor(connectorType)
+	if float64(powerOutput) < limit {
+		return float64(powerOutput)
+	}
+	return limit
+}
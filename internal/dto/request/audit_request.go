@@ -0,0 +1,15 @@
+package request
+
+// AuditLogFilterRequest filters GET /admin/audit; from/to are inclusive UTC times
+type AuditLogFilterRequest struct {
+	ActorID    string `form:"actor_id"`
+	Actor      string `form:"actor"`
+	Action     string `form:"action"`
+	TargetType string `form:"target_type"`
+	TargetID   string `form:"target_id"`
+	RequestID  string `form:"request_id"`
+	From       string `form:"from" binding:"omitempty,datetime=2006-01-02T15:04:05"`
+	To         string `form:"to" binding:"omitempty,datetime=2006-01-02T15:04:05"`
+	Limit      int64  `form:"limit" binding:"gte=0,lte=1000"`
+	Offset     int64  `form:"offset" binding:"gte=0"`
+}
@@ -2,7 +2,8 @@ package request
 
 type SetBookingRequest struct {
 	ConnectorId    string `json:"connector_id" binding:"required"`
-	Username       string `json:"username" binding:"required"`
+	// Username defaults to the caller; only admins may book for someone else
+	Username       string `json:"username"`
 	BookingEndTime string `json:"booking_end_time" binding:"required,datetime=2006-01-02T15:04:05"`
 }
 
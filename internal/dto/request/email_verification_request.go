@@ -0,0 +1,10 @@
+package request
+
+type VerifyEmailRequest struct {
+	Token string `form:"token" validate:"required"`
+}
+
+// ResendVerificationRequest mails a new verification link to the authenticated user
+type ResendVerificationRequest struct {
+	UserID string `json:"-"`
+}
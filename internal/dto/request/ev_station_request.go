@@ -5,21 +5,47 @@ import (
 )
 
 type EVStationRequest struct {
-	Name       string               `json:"name" binding:"required"`
-	Latitude   float64              `json:"latitude" binding:"required"`
-	Longitude  float64              `json:"longitude" binding:"required"`
-	Company    string               `json:"company" binding:"required"`
-	Status     StationStatusRequest `json:"status" binding:"required"`
-	Connectors []ConnectorRequest   `json:"connectors" binding:"required,dive"`
+	ExternalRef string               `json:"external_ref,omitempty"`
+	Name        string               `json:"name" binding:"required"`
+	Latitude    float64              `json:"latitude" binding:"required"`
+	Longitude   float64              `json:"longitude" binding:"required"`
+	Company     string               `json:"company" binding:"required"`
+	Status      StationStatusRequest `json:"status" binding:"required"`
+	Connectors  []ConnectorRequest   `json:"connectors" binding:"required,dive"`
 }
 
+// StationStatusRequest describes opening hours. Either a weekly schedule (or always_open)
+// or the legacy open_hours/close_hours pair, which is applied to every day.
 type StationStatusRequest struct {
-	OpenHours  string `json:"open_hours" binding:"required"`
-	CloseHours string `json:"close_hours" binding:"required"`
-	IsOpen     bool   `json:"is_open" binding:"required"`
+	TimeZone   string                     `json:"time_zone"`
+	AlwaysOpen bool                       `json:"always_open"`
+	Weekly     []DayScheduleRequest       `json:"weekly" binding:"dive"`
+	Exceptions []ScheduleExceptionRequest `json:"exceptions" binding:"dive"`
+	OpenHours  string                     `json:"open_hours,omitempty"`
+	CloseHours string                     `json:"close_hours,omitempty"`
 }
 
+type DayScheduleRequest struct {
+	Day       string                `json:"day" binding:"required"`
+	Intervals []TimeIntervalRequest `json:"intervals" binding:"required,dive"`
+}
+
+type TimeIntervalRequest struct {
+	Open  string `json:"open" binding:"required"`
+	Close string `json:"close" binding:"required"`
+}
+
+type ScheduleExceptionRequest struct {
+	Date      string                `json:"date" binding:"required"`
+	Closed    bool                  `json:"closed"`
+	Intervals []TimeIntervalRequest `json:"intervals" binding:"dive"`
+	Reason    string                `json:"reason"`
+}
+
+// ConnectorRequest describes one connector. ConnectorID is optional and lets
+// whole-station edits keep the identity (and booking) of an existing connector.
 type ConnectorRequest struct {
+	ConnectorID  string                  `json:"connector_id,omitempty"`
 	Type         constants.ConnectorType `json:"type" binding:"required"`
 	PlugName     constants.PlugName      `json:"plug_name" binding:"required"`
 	PricePerUnit float64                 `json:"price_per_unit" binding:"required"`
@@ -45,15 +71,114 @@ type GetStationByIDRequest struct {
 }
 
 type EditStationRequest struct {
-	ID         string                `json:"id" binding:"required"`
-	Name       *string               `json:"name,omitempty"`
-	Latitude   *float64              `json:"latitude,omitempty"`
-	Longitude  *float64              `json:"longitude,omitempty"`
-	Company    *string               `json:"company,omitempty"`
-	Status     *StationStatusRequest `json:"status,omitempty"`
-	Connectors *[]ConnectorRequest   `json:"connectors,omitempty"`
+	ID          string                `json:"id" binding:"required"`
+	ExternalRef *string               `json:"external_ref,omitempty"`
+	Name        *string               `json:"name,omitempty"`
+	Latitude    *float64              `json:"latitude,omitempty"`
+	Longitude   *float64              `json:"longitude,omitempty"`
+	Company     *string               `json:"company,omitempty"`
+	Status      *StationStatusRequest `json:"status,omitempty"`
+	Connectors  *[]ConnectorRequest   `json:"connectors,omitempty"`
+
+	// ExpectedVersion comes from If-Match; nil (If-Match: *) accepts the current version
+	ExpectedVersion *int64 `json:"-"`
+}
+
+type StationConnectorRequest struct {
+	StationID       string                  `json:"-"`
+	ConnectorID     string                  `json:"-"`
+	ExpectedVersion *int64                  `json:"-"`
+	Type            constants.ConnectorType `json:"type" binding:"required"`
+	PlugName        constants.PlugName      `json:"plug_name" binding:"required"`
+	PricePerUnit    float64                 `json:"price_per_unit" binding:"required"`
+	PowerOutput     int                     `json:"power_output" binding:"required"`
+}
+
+type RemoveConnectorRequest struct {
+	StationID       string `json:"station_id" binding:"required"`
+	ConnectorID     string `json:"connector_id" binding:"required"`
+	ExpectedVersion *int64 `json:"-"`
+}
+
+// PatchStationRequest carries a raw merge patch or JSON patch, told apart by PatchType
+type PatchStationRequest struct {
+	ID              string `json:"id" binding:"required"`
+	PatchType       string `json:"-"`
+	Patch           []byte `json:"-"`
+	ExpectedVersion *int64 `json:"-"`
 }
 
 type RemoveStationRequest struct {
+	ID        string `json:"id" binding:"required"`
+	Force     bool   `form:"force"` // delete even when connectors have active bookings
+	DeletedBy string `json:"-"`
+}
+
+type RestoreStationRequest struct {
+	ID string `json:"id" binding:"required"`
+}
+
+type GetStationRevisionsRequest struct {
 	ID string `json:"id" binding:"required"`
 }
+
+// GetStationAsOfRequest fetches the station as it was at AsOf (2006-01-02T15:04:05 UTC or RFC3339)
+type GetStationAsOfRequest struct {
+	ID   string `json:"id" binding:"required"`
+	AsOf string `form:"as_of" binding:"required"`
+}
+
+// RevertStationRequest restores the editable fields of the station from an earlier revision
+type RevertStationRequest struct {
+	ID              string `json:"id" binding:"required"`
+	Version         int64  `json:"version" binding:"required"`
+	ExpectedVersion *int64 `json:"-"`
+}
+
+type ChargeEstimateRequest struct {
+	ConnectorID string  `form:"connector_id" binding:"required"`
+	VehicleID   string  `form:"vehicle_id"`
+	BatteryKWh  float64 `form:"battery_kwh" binding:"gte=0"`
+	StartSoC    float64 `form:"start_soc" binding:"gte=0,lte=100"`
+	TargetSoC   float64 `form:"target_soc" binding:"required,gt=0,lte=100"`
+	UserID      string  `form:"-"`
+}
+
+type MaintenanceWindowRequest struct {
+	StationID   string `json:"-"`
+	WindowID    string `json:"-"`
+	CreatedBy   string `json:"-"`
+	ConnectorID string `json:"connector_id"`
+	StartTime   string `json:"start_time" binding:"required,datetime=2006-01-02T15:04:05"`
+	EndTime     string `json:"end_time" binding:"required,datetime=2006-01-02T15:04:05"`
+	Reason      string `json:"reason" binding:"required"`
+	Notify      bool   `json:"notify"`
+}
+
+type GetMaintenanceWindowsRequest struct {
+	StationID string `json:"station_id" binding:"required"`
+}
+
+type RemoveMaintenanceWindowRequest struct {
+	StationID string `json:"station_id" binding:"required"`
+	WindowID  string `json:"window_id" binding:"required"`
+}
+
+// ImportStationsRequest carries a CSV or JSON file of stations to upsert by external_ref.
+// Format may be left empty to detect it from the data.
+type ImportStationsRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv json"`
+	DryRun bool   `form:"dry_run"`
+	Data   []byte `form:"-"`
+}
+
+// ImportOpenChargeMapRequest carries an Open Charge Map JSON export. The dump has no prices or
+// opening hours, so every connector gets PricePerUnit and every station is always open in TimeZone.
+// Sites within DedupeRadius metres of a station with a similar name are skipped as duplicates.
+type ImportOpenChargeMapRequest struct {
+	DryRun       bool
+	PricePerUnit float64
+	TimeZone     string
+	DedupeRadius float64
+	Data         []byte
+}
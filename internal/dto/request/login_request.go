@@ -3,4 +3,5 @@ package request
 type LoginRequest struct {
 	UsernameOrEmail string `json:"username_or_email" validate:"required"`
 	Password        string `json:"password" validate:"required"`
+	ClientIP        string `json:"-"`
 }
\ No newline at end of file
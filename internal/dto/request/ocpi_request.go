@@ -0,0 +1,38 @@
+package request
+
+// OCPICredentialsRequest is the Credentials object a partner sends to register or update
+type OCPICredentialsRequest struct {
+	Token string                       `json:"token" binding:"required"`
+	URL   string                       `json:"url" binding:"required,url"`
+	Roles []OCPICredentialsRoleRequest `json:"roles" binding:"required,min=1,dive"`
+}
+
+type OCPICredentialsRoleRequest struct {
+	Role            string                     `json:"role" binding:"required"`
+	BusinessDetails OCPIBusinessDetailsRequest `json:"business_details"`
+	PartyID         string                     `json:"party_id" binding:"required,len=3"`
+	CountryCode     string                     `json:"country_code" binding:"required,len=2"`
+}
+
+type OCPIBusinessDetailsRequest struct {
+	Name string `json:"name"`
+}
+
+// OCPILocationsRequest pages through locations; date_from/date_to filter on last_updated (RFC3339)
+type OCPILocationsRequest struct {
+	DateFrom string `form:"date_from"`
+	DateTo   string `form:"date_to"`
+	Offset   int64  `form:"offset" binding:"gte=0"`
+	Limit    int64  `form:"limit" binding:"gte=0"`
+}
+
+// OCPILocationRequest addresses a location, one of its EVSEs or one EVSE connector
+type OCPILocationRequest struct {
+	LocationID  string `uri:"location_id" binding:"required"`
+	EVSEUID     string `uri:"evse_uid"`
+	ConnectorID string `uri:"connector_id"`
+}
+
+type CreateOCPIPartnerRequest struct {
+	Name string `json:"name" binding:"required"`
+}
@@ -0,0 +1,11 @@
+package request
+
+// ForgotPasswordRequest asks for a reset link; the response is the same whether or not the email is registered
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=6"`
+}
@@ -1,8 +1,8 @@
 package request
 
+// RegisterUserRequest is the public sign-up; it always creates a USER, see ProvisionUserRequest for admins
 type RegisterUserRequest struct {
 	Username string `json:"username" validate:"required,min=4,max=20"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6"`
-	Role     string `json:"role" validate:"required,eq=ADMIN|eq=USER"`
 }
\ No newline at end of file
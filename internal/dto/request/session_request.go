@@ -0,0 +1,14 @@
+package request
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// LogoutAllRequest revokes every session of the user, i.e. logs out all devices
+type LogoutAllRequest struct {
+	UserID string `json:"-"`
+}
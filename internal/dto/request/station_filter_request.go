@@ -1,9 +1,19 @@
 package request
 
 type StationFilterRequest struct {
-	Company  string `form:"company"`
-	Type     string `form:"type"`
-	PlugName string `form:"plug_name"`
-	Search   string `form:"search"`
-	Status	 string `form:"status"`
+	Company   string   `form:"company"`
+	Type      string   `form:"type"`
+	PlugName  string   `form:"plug_name"`
+	Search    string   `form:"search"`
+	Status    string   `form:"status"`
+	VehicleID string   `form:"vehicle_id"`
+	StartSoC  *float64 `form:"start_soc" binding:"omitempty,gte=0,lte=100"`
+	TargetSoC *float64 `form:"target_soc" binding:"omitempty,gt=0,lte=100"`
+	UserID    string   `form:"-"`
+}
+
+// ExportStationsRequest takes the FilterStations filters plus the file format to produce
+type ExportStationsRequest struct {
+	StationFilterRequest
+	Format string `form:"format" binding:"required,oneof=csv geojson kml"`
 }
@@ -0,0 +1,22 @@
+package request
+
+// TwoFactorLoginRequest completes a login with the challenge token returned by /users/login and
+// a code from the authenticator app or a recovery code. An enrollment started at login also needs
+// the EmailCode mailed by /users/login/2fa/setup.
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+	EmailCode      string `json:"email_code"`
+	ClientIP       string `json:"-"`
+}
+
+// TwoFactorChallengeSetupRequest starts the enrollment an ADMIN must complete before logging in
+type TwoFactorChallengeSetupRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+}
+
+// TOTPCodeRequest confirms a change to the authenticated user's two-factor settings
+type TOTPCodeRequest struct {
+	UserID string `json:"-"`
+	Code   string `json:"code" validate:"required"`
+}
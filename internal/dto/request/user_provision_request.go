@@ -0,0 +1,14 @@
+package request
+
+// ProvisionUserRequest creates an account with any role; only admins and the provisioning CLI may use it
+type ProvisionUserRequest struct {
+	Username string `json:"username" validate:"required,min=4,max=20"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=6"`
+	Role     string `json:"role" validate:"required,eq=ADMIN|eq=USER"`
+}
+
+type ChangeUserRoleRequest struct {
+	UserID string `json:"-"`
+	Role   string `json:"role" validate:"required,eq=ADMIN|eq=USER"`
+}
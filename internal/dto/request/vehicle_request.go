@@ -0,0 +1,31 @@
+package request
+
+import "Ev-Charge-Hub/Server/internal/constants"
+
+// VehicleRequest creates or edits a vehicle profile. When CatalogID is set,
+// any field left empty is filled from the built-in catalog entry.
+type VehicleRequest struct {
+	ID             string               `json:"-"`
+	UserID         string               `json:"-"`
+	CatalogID      string               `json:"catalog_id"`
+	Make           string               `json:"make"`
+	Model          string               `json:"model"`
+	BatteryKWh     float64              `json:"battery_kwh" validate:"gte=0"`
+	SupportedPlugs []constants.PlugName `json:"supported_plugs"`
+	MaxACPowerKW   float64              `json:"max_ac_power_kw" validate:"gte=0"`
+	MaxDCPowerKW   float64              `json:"max_dc_power_kw" validate:"gte=0"`
+}
+
+type GetVehiclesRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+type GetVehicleByIDRequest struct {
+	ID     string `json:"id" binding:"required"`
+	UserID string `json:"user_id" binding:"required"`
+}
+
+type RemoveVehicleRequest struct {
+	ID     string `json:"id" binding:"required"`
+	UserID string `json:"user_id" binding:"required"`
+}
@@ -0,0 +1,21 @@
+package response
+
+import "Ev-Charge-Hub/Server/internal/constants"
+
+type AuditEntryResponse struct {
+	ID         string                `json:"id"`
+	ActorID    string                `json:"actor_id"`
+	ActorName  string                `json:"actor_name"`
+	Action     constants.AuditAction `json:"action"`
+	TargetType string                `json:"target_type"`
+	TargetID   string                `json:"target_id"`
+	RequestID  string                `json:"request_id,omitempty"`
+	Timestamp  string                `json:"timestamp"`
+	Changes    []AuditChangeResponse `json:"changes"`
+}
+
+type AuditChangeResponse struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
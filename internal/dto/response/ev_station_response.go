@@ -5,31 +5,91 @@ import (
 )
 
 type EVStationResponse struct {
-	ID         string                `json:"id"`
-	Name       string                `json:"name"`
-	Latitude   float64               `json:"latitude"`
-	Longitude  float64               `json:"longitude"`
-	Company    string                `json:"company"`
-	Status     StationStatusResponse `json:"status"`
-	Connectors []ConnectorResponse   `json:"connectors"`
+	ID                 string                      `json:"id"`
+	ExternalRef        string                      `json:"external_ref,omitempty"`
+	Name               string                      `json:"name"`
+	Latitude           float64                     `json:"latitude"`
+	Longitude          float64                     `json:"longitude"`
+	Company            string                      `json:"company"`
+	Status             StationStatusResponse       `json:"status"`
+	Connectors         []ConnectorResponse         `json:"connectors"`
+	MaintenanceWindows []MaintenanceWindowResponse `json:"maintenance_windows,omitempty"`
+	Version            int64                       `json:"version"`
+	DeletedAt          string                      `json:"deleted_at,omitempty"`
+	DeletedBy          string                      `json:"deleted_by,omitempty"`
 }
 
 type StationStatusResponse struct {
-	OpenHours  string `json:"open_hours"`
-	CloseHours string `json:"close_hours"`
-	IsOpen     bool   `json:"is_open"`
+	TimeZone   string                      `json:"time_zone"`
+	AlwaysOpen bool                        `json:"always_open"`
+	Weekly     []DayScheduleResponse       `json:"weekly"`
+	Exceptions []ScheduleExceptionResponse `json:"exceptions,omitempty"`
+	IsOpen     bool                        `json:"is_open"`
+}
+
+type DayScheduleResponse struct {
+	Day       string                 `json:"day"`
+	Intervals []TimeIntervalResponse `json:"intervals"`
+}
+
+type TimeIntervalResponse struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+type ScheduleExceptionResponse struct {
+	Date      string                 `json:"date"`
+	Closed    bool                   `json:"closed"`
+	Intervals []TimeIntervalResponse `json:"intervals,omitempty"`
+	Reason    string                 `json:"reason,omitempty"`
 }
 
 type ConnectorResponse struct {
-	ConnectorID  string                  `json:"connector_id"`
-	Type         constants.ConnectorType `json:"type"`
-	PlugName     constants.PlugName      `json:"plug_name"`
-	PricePerUnit float64                 `json:"price_per_unit"`
-	PowerOutput  int                     `json:"power_output"`
-	Booking      *BookingResponse        `json:"booking,omitempty"`
+	ConnectorID      string                  `json:"connector_id"`
+	Type             constants.ConnectorType `json:"type"`
+	PlugName         constants.PlugName      `json:"plug_name"`
+	PricePerUnit     float64                 `json:"price_per_unit"`
+	PowerOutput      int                     `json:"power_output"`
+	EffectivePower   float64                 `json:"effective_power,omitempty"`
+	Estimate         *ChargeEstimateResponse `json:"estimate,omitempty"`
+	UnderMaintenance bool                    `json:"under_maintenance,omitempty"`
+	Booking          *BookingResponse        `json:"booking,omitempty"`
 }
 
 type BookingResponse struct {
 	Username       string `json:"username"`
 	BookingEndTime string `json:"booking_end_time"`
 }
+
+type ChargeEstimateResponse struct {
+	ConnectorID      string  `json:"connector_id"`
+	StartSoC         float64 `json:"start_soc"`
+	TargetSoC        float64 `json:"target_soc"`
+	EnergyKWh        float64 `json:"energy_kwh"`
+	EstimatedMinutes float64 `json:"estimated_minutes"`
+	EstimatedCost    float64 `json:"estimated_cost"`
+	AveragePowerKW   float64 `json:"average_power_kw"`
+}
+
+type MaintenanceWindowResponse struct {
+	ID          string                     `json:"id"`
+	Scope       constants.MaintenanceScope `json:"scope"`
+	ConnectorID string                     `json:"connector_id,omitempty"`
+	StartTime   string                     `json:"start_time"`
+	EndTime     string                     `json:"end_time"`
+	Reason      string                     `json:"reason"`
+	CreatedBy   string                     `json:"created_by,omitempty"`
+}
+
+type CreateMaintenanceWindowResponse struct {
+	Window        MaintenanceWindowResponse `json:"window"`
+	NotifiedUsers []string                  `json:"notified_users"`
+}
+
+type StationRevisionResponse struct {
+	Version    int64             `json:"version"`
+	RecordedAt string            `json:"recorded_at"`
+	RecordedBy string            `json:"recorded_by"`
+	RequestID  string            `json:"request_id,omitempty"`
+	Station    EVStationResponse `json:"station"`
+}
@@ -0,0 +1,7 @@
+package response
+
+// FieldError points at one invalid field of a request body using its JSON path, e.g. "connectors[0].plug_name"
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
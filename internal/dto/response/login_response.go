@@ -1,5 +1,13 @@
 package response
 
+// LoginResponse carries either the tokens, or a challenge token when a second factor is needed
 type LoginResponse struct {
-	Token string `json:"token"`
-}
\ No newline at end of file
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"` // seconds until Token expires
+
+	TwoFactorRequired           bool     `json:"two_factor_required,omitempty"`
+	TwoFactorEnrollmentRequired bool     `json:"two_factor_enrollment_required,omitempty"` // set up TOTP with /users/login/2fa/setup first
+	ChallengeToken              string   `json:"challenge_token,omitempty"`
+	RecoveryCodes               []string `json:"recovery_codes,omitempty"` // when the login completed a mandatory enrollment
+}
@@ -0,0 +1,130 @@
+package response
+
+// OCPIResponse is the envelope every OCPI endpoint answers with
+type OCPIResponse struct {
+	Data          interface{} `json:"data,omitempty"`
+	StatusCode    int         `json:"status_code"`
+	StatusMessage string      `json:"status_message,omitempty"`
+	Timestamp     string      `json:"timestamp"`
+}
+
+type OCPIVersion struct {
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
+
+type OCPIVersionDetails struct {
+	Version   string         `json:"version"`
+	Endpoints []OCPIEndpoint `json:"endpoints"`
+}
+
+type OCPIEndpoint struct {
+	Identifier string `json:"identifier"`
+	Role       string `json:"role"`
+	URL        string `json:"url"`
+}
+
+type OCPICredentials struct {
+	Token string                `json:"token"`
+	URL   string                `json:"url"`
+	Roles []OCPICredentialsRole `json:"roles"`
+}
+
+type OCPICredentialsRole struct {
+	Role            string              `json:"role"`
+	BusinessDetails OCPIBusinessDetails `json:"business_details"`
+	PartyID         string              `json:"party_id"`
+	CountryCode     string              `json:"country_code"`
+}
+
+type OCPIBusinessDetails struct {
+	Name string `json:"name"`
+}
+
+type OCPILocation struct {
+	CountryCode  string               `json:"country_code"`
+	PartyID      string               `json:"party_id"`
+	ID           string               `json:"id"`
+	Publish      bool                 `json:"publish"`
+	Name         string               `json:"name,omitempty"`
+	Address      string               `json:"address"`
+	City         string               `json:"city"`
+	Country      string               `json:"country"`
+	Coordinates  OCPIGeoLocation      `json:"coordinates"`
+	EVSEs        []OCPIEVSE           `json:"evses,omitempty"`
+	Operator     *OCPIBusinessDetails `json:"operator,omitempty"`
+	TimeZone     string               `json:"time_zone"`
+	OpeningTimes *OCPIHours           `json:"opening_times,omitempty"`
+	LastUpdated  string               `json:"last_updated"`
+}
+
+// OCPIGeoLocation holds decimal degrees as strings, as OCPI requires
+type OCPIGeoLocation struct {
+	Latitude  string `json:"latitude"`
+	Longitude string `json:"longitude"`
+}
+
+type OCPIHours struct {
+	TwentyFourSeven     bool                    `json:"twentyfourseven"`
+	RegularHours        []OCPIRegularHours      `json:"regular_hours,omitempty"`
+	ExceptionalOpenings []OCPIExceptionalPeriod `json:"exceptional_openings,omitempty"`
+	ExceptionalClosings []OCPIExceptionalPeriod `json:"exceptional_closings,omitempty"`
+}
+
+// OCPIRegularHours uses weekday 1 (Monday) to 7 (Sunday) and local "HH:MM" times
+type OCPIRegularHours struct {
+	Weekday     int    `json:"weekday"`
+	PeriodBegin string `json:"period_begin"`
+	PeriodEnd   string `json:"period_end"`
+}
+
+type OCPIExceptionalPeriod struct {
+	PeriodBegin string `json:"period_begin"`
+	PeriodEnd   string `json:"period_end"`
+}
+
+type OCPIEVSE struct {
+	UID         string          `json:"uid"`
+	EVSEID      string          `json:"evse_id,omitempty"`
+	Status      string          `json:"status"`
+	Connectors  []OCPIConnector `json:"connectors"`
+	LastUpdated string          `json:"last_updated"`
+}
+
+type OCPIConnector struct {
+	ID               string `json:"id"`
+	Standard         string `json:"standard"`
+	Format           string `json:"format"`
+	PowerType        string `json:"power_type"`
+	MaxVoltage       int    `json:"max_voltage"`
+	MaxAmperage      int    `json:"max_amperage"`
+	MaxElectricPower int    `json:"max_electric_power,omitempty"`
+	LastUpdated      string `json:"last_updated"`
+}
+
+// OCPILocationPage is one page of locations plus what the handler needs for the pagination headers
+type OCPILocationPage struct {
+	Locations []OCPILocation
+	Total     int64
+	Limit     int64
+	NextURL   string
+}
+
+// OCPIPartnerResponse describes a roaming partner to admins; tokens are never included
+type OCPIPartnerResponse struct {
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Status      string                `json:"status"`
+	VersionsURL string                `json:"versions_url,omitempty"`
+	Version     string                `json:"version,omitempty"`
+	Roles       []OCPICredentialsRole `json:"roles,omitempty"`
+	CreatedAt   string                `json:"created_at"`
+	UpdatedAt   string                `json:"updated_at"`
+}
+
+// OCPIPartnerCreatedResponse carries the registration token (token A), shown only once
+type OCPIPartnerCreatedResponse struct {
+	OCPIPartnerResponse
+	Token          string `json:"token"`
+	OurVersionsURL string `json:"our_versions_url"`
+}
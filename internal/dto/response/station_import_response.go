@@ -0,0 +1,35 @@
+package response
+
+// StationImportReport summarises a bulk import; on a dry run the actions say what would happen
+type StationImportReport struct {
+	DryRun    bool                     `json:"dry_run"`
+	Total     int                      `json:"total"`
+	Created   int                      `json:"created"`
+	Updated   int                      `json:"updated"`
+	Unchanged int                      `json:"unchanged"`
+	Failed    int                      `json:"failed"`
+	Rows      []StationImportRowResult `json:"rows"`
+
+	// set by Open Charge Map imports only
+	Duplicates              int                      `json:"duplicates,omitempty"`
+	UnmappedConnectionTypes []UnmappedConnectionType `json:"unmapped_connection_types,omitempty"`
+}
+
+// StationImportRowResult is the outcome of one station. Row is the array index (from 1) for JSON
+// files and the line of the station's first row for CSV files.
+type StationImportRowResult struct {
+	Row         int          `json:"row"`
+	ExternalRef string       `json:"external_ref,omitempty"`
+	Action      string       `json:"action"`
+	StationID   string       `json:"station_id,omitempty"`
+	DuplicateOf string       `json:"duplicate_of,omitempty"`
+	Errors      []FieldError `json:"errors,omitempty"`
+}
+
+// UnmappedConnectionType counts the connections of an Open Charge Map type we have no plug for;
+// they are left out of the imported stations
+type UnmappedConnectionType struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Connections int    `json:"connections"`
+}
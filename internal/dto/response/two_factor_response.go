@@ -0,0 +1,12 @@
+package response
+
+// TOTPSetupResponse is shown once during enrollment; render ProvisioningURI as a QR code
+type TOTPSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// RecoveryCodesResponse is the only time the recovery codes are shown
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
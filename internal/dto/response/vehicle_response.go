@@ -0,0 +1,24 @@
+package response
+
+import "Ev-Charge-Hub/Server/internal/constants"
+
+type VehicleResponse struct {
+	ID             string               `json:"id"`
+	CatalogID      string               `json:"catalog_id,omitempty"`
+	Make           string               `json:"make"`
+	Model          string               `json:"model"`
+	BatteryKWh     float64              `json:"battery_kwh"`
+	SupportedPlugs []constants.PlugName `json:"supported_plugs"`
+	MaxACPowerKW   float64              `json:"max_ac_power_kw"`
+	MaxDCPowerKW   float64              `json:"max_dc_power_kw"`
+}
+
+type VehicleCatalogResponse struct {
+	CatalogID      string               `json:"catalog_id"`
+	Make           string               `json:"make"`
+	Model          string               `json:"model"`
+	BatteryKWh     float64              `json:"battery_kwh"`
+	SupportedPlugs []constants.PlugName `json:"supported_plugs"`
+	MaxACPowerKW   float64              `json:"max_ac_power_kw"`
+	MaxDCPowerKW   float64              `json:"max_dc_power_kw"`
+}
@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"context"
+	"log"
+	"time"
+)
+
+// StartStationPurgeJob hard-deletes trashed stations older than retention every interval
+// until ctx is cancelled, acting as audit.SystemActor. A zero interval disables the job.
+func StartStationPurgeJob(ctx context.Context, stationUsecase usecase.EVStationUsecase, retention time.Duration, interval time.Duration) {
+	if interval <= 0 {
+		log.Println("🗑️ station purge job disabled")
+		return
+	}
+
+	jobCtx := audit.WithActor(ctx, audit.SystemActor)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := stationUsecase.PurgeDeletedStations(jobCtx, retention)
+			if err != nil {
+				log.Printf("🗑️ station purge failed: %v", err)
+			} else if purged > 0 {
+				log.Printf("🗑️ purged %d station(s) deleted more than %s ago", purged, retention)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+//go:generate mockgen -source=mailer.go -destination=../mocks/mock_mailer.go -package=mocks
+
+// Message is a plain-text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers an email; see NewSMTPMailer, NewFileMailer and NewMemoryMailer
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// asyncSendTimeout bounds a background delivery started by an async mailer
+const asyncSendTimeout = 30 * time.Second
+
+type asyncMailer struct {
+	mailer Mailer
+}
+
+// NewAsyncMailer sends in the background and only logs failures, so a request takes the same
+// time whether or not an email goes out
+func NewAsyncMailer(mailer Mailer) Mailer {
+	return &asyncMailer{mailer: mailer}
+}
+
+func (m *asyncMailer) Send(ctx context.Context, msg Message) error {
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), asyncSendTimeout)
+		defer cancel()
+		if err := m.mailer.Send(sendCtx, msg); err != nil {
+			log.Printf("[MAIL] ❌ sending %q to %s failed: %v", msg.Subject, msg.To, err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: email_verification_usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "Ev-Charge-Hub/Server/internal/domain/models"
+	request "Ev-Charge-Hub/Server/internal/dto/request"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEmailVerificationUsecase is a mock of EmailVerificationUsecase interface.
+type MockEmailVerificationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationUsecaseMockRecorder
+}
+
+// MockEmailVerificationUsecaseMockRecorder is the mock recorder for MockEmailVerificationUsecase.
+type MockEmailVerificationUsecaseMockRecorder struct {
+	mock *MockEmailVerificationUsecase
+}
+
+// NewMockEmailVerificationUsecase creates a new mock instance.
+func NewMockEmailVerificationUsecase(ctrl *gomock.Controller) *MockEmailVerificationUsecase {
+	mock := &MockEmailVerificationUsecase{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationUsecase) EXPECT() *MockEmailVerificationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ResendVerification mocks base method.
+func (m *MockEmailVerificationUsecase) ResendVerification(ctx context.Context, req request.ResendVerificationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockEmailVerificationUsecaseMockRecorder) ResendVerification(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockEmailVerificationUsecase)(nil).ResendVerification), ctx, req)
+}
+
+// SendVerification mocks base method.
+func (m *MockEmailVerificationUsecase) SendVerification(ctx context.Context, user *models.UserModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerification", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendVerification indicates an expected call of SendVerification.
+func (mr *MockEmailVerificationUsecaseMockRecorder) SendVerification(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerification", reflect.TypeOf((*MockEmailVerificationUsecase)(nil).SendVerification), ctx, user)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockEmailVerificationUsecase) VerifyEmail(ctx context.Context, req request.VerifyEmailRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockEmailVerificationUsecaseMockRecorder) VerifyEmail(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockEmailVerificationUsecase)(nil).VerifyEmail), ctx, req)
+}
+
+// MockBookingPolicy is a mock of BookingPolicy interface.
+type MockBookingPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingPolicyMockRecorder
+}
+
+// MockBookingPolicyMockRecorder is the mock recorder for MockBookingPolicy.
+type MockBookingPolicyMockRecorder struct {
+	mock *MockBookingPolicy
+}
+
+// NewMockBookingPolicy creates a new mock instance.
+func NewMockBookingPolicy(ctrl *gomock.Controller) *MockBookingPolicy {
+	mock := &MockBookingPolicy{ctrl: ctrl}
+	mock.recorder = &MockBookingPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingPolicy) EXPECT() *MockBookingPolicyMockRecorder {
+	return m.recorder
+}
+
+// CheckCanBook mocks base method.
+func (m *MockBookingPolicy) CheckCanBook(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCanBook", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckCanBook indicates an expected call of CheckCanBook.
+func (mr *MockBookingPolicyMockRecorder) CheckCanBook(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCanBook", reflect.TypeOf((*MockBookingPolicy)(nil).CheckCanBook), ctx, username)
+}
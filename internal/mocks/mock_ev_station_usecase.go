@@ -9,6 +9,7 @@ import (
 	response "Ev-Charge-Hub/Server/internal/dto/response"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -36,6 +37,21 @@ func (m *MockEVStationUsecase) EXPECT() *MockEVStationUsecaseMockRecorder {
 	return m.recorder
 }
 
+// AddConnector mocks base method.
+func (m *MockEVStationUsecase) AddConnector(ctx context.Context, request request.StationConnectorRequest) (*response.EVStationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddConnector", ctx, request)
+	ret0, _ := ret[0].(*response.EVStationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddConnector indicates an expected call of AddConnector.
+func (mr *MockEVStationUsecaseMockRecorder) AddConnector(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddConnector", reflect.TypeOf((*MockEVStationUsecase)(nil).AddConnector), ctx, request)
+}
+
 // CreateStation mocks base method.
 func (m *MockEVStationUsecase) CreateStation(ctx context.Context, request request.EVStationRequest) error {
 	m.ctrl.T.Helper()
@@ -50,6 +66,21 @@ func (mr *MockEVStationUsecaseMockRecorder) CreateStation(ctx, request interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStation", reflect.TypeOf((*MockEVStationUsecase)(nil).CreateStation), ctx, request)
 }
 
+// EditConnector mocks base method.
+func (m *MockEVStationUsecase) EditConnector(ctx context.Context, request request.StationConnectorRequest) (*response.EVStationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditConnector", ctx, request)
+	ret0, _ := ret[0].(*response.EVStationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditConnector indicates an expected call of EditConnector.
+func (mr *MockEVStationUsecaseMockRecorder) EditConnector(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditConnector", reflect.TypeOf((*MockEVStationUsecase)(nil).EditConnector), ctx, request)
+}
+
 // EditStation mocks base method.
 func (m *MockEVStationUsecase) EditStation(ctx context.Context, req request.EditStationRequest) (*response.EVStationResponse, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +96,35 @@ func (mr *MockEVStationUsecaseMockRecorder) EditStation(ctx, req interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditStation", reflect.TypeOf((*MockEVStationUsecase)(nil).EditStation), ctx, req)
 }
 
+// EstimateCharge mocks base method.
+func (m *MockEVStationUsecase) EstimateCharge(ctx context.Context, request request.ChargeEstimateRequest) (*response.ChargeEstimateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateCharge", ctx, request)
+	ret0, _ := ret[0].(*response.ChargeEstimateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateCharge indicates an expected call of EstimateCharge.
+func (mr *MockEVStationUsecaseMockRecorder) EstimateCharge(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateCharge", reflect.TypeOf((*MockEVStationUsecase)(nil).EstimateCharge), ctx, request)
+}
+
+// ExportStations mocks base method.
+func (m *MockEVStationUsecase) ExportStations(ctx context.Context, request request.StationFilterRequest, emit func(response.EVStationResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportStations", ctx, request, emit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportStations indicates an expected call of ExportStations.
+func (mr *MockEVStationUsecaseMockRecorder) ExportStations(ctx, request, emit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportStations", reflect.TypeOf((*MockEVStationUsecase)(nil).ExportStations), ctx, request, emit)
+}
+
 // FilterStations mocks base method.
 func (m *MockEVStationUsecase) FilterStations(ctx context.Context, request request.StationFilterRequest) ([]response.EVStationResponse, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +170,36 @@ func (mr *MockEVStationUsecaseMockRecorder) GetBookingsByUserName(ctx, request i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByUserName", reflect.TypeOf((*MockEVStationUsecase)(nil).GetBookingsByUserName), ctx, request)
 }
 
+// GetDeletedStations mocks base method.
+func (m *MockEVStationUsecase) GetDeletedStations(ctx context.Context) ([]response.EVStationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedStations", ctx)
+	ret0, _ := ret[0].([]response.EVStationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedStations indicates an expected call of GetDeletedStations.
+func (mr *MockEVStationUsecaseMockRecorder) GetDeletedStations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedStations", reflect.TypeOf((*MockEVStationUsecase)(nil).GetDeletedStations), ctx)
+}
+
+// GetStationAsOf mocks base method.
+func (m *MockEVStationUsecase) GetStationAsOf(ctx context.Context, request request.GetStationAsOfRequest) (*response.EVStationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStationAsOf", ctx, request)
+	ret0, _ := ret[0].(*response.EVStationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStationAsOf indicates an expected call of GetStationAsOf.
+func (mr *MockEVStationUsecaseMockRecorder) GetStationAsOf(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStationAsOf", reflect.TypeOf((*MockEVStationUsecase)(nil).GetStationAsOf), ctx, request)
+}
+
 // GetStationByConnectorID mocks base method.
 func (m *MockEVStationUsecase) GetStationByConnectorID(ctx context.Context, request request.GetStationByConnectorIDRequest) (*response.EVStationResponse, error) {
 	m.ctrl.T.Helper()
@@ -155,6 +245,96 @@ func (mr *MockEVStationUsecaseMockRecorder) GetStationByUserName(ctx, request in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStationByUserName", reflect.TypeOf((*MockEVStationUsecase)(nil).GetStationByUserName), ctx, request)
 }
 
+// GetStationRevisions mocks base method.
+func (m *MockEVStationUsecase) GetStationRevisions(ctx context.Context, request request.GetStationRevisionsRequest) ([]response.StationRevisionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStationRevisions", ctx, request)
+	ret0, _ := ret[0].([]response.StationRevisionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStationRevisions indicates an expected call of GetStationRevisions.
+func (mr *MockEVStationUsecaseMockRecorder) GetStationRevisions(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStationRevisions", reflect.TypeOf((*MockEVStationUsecase)(nil).GetStationRevisions), ctx, request)
+}
+
+// ImportOpenChargeMap mocks base method.
+func (m *MockEVStationUsecase) ImportOpenChargeMap(ctx context.Context, request request.ImportOpenChargeMapRequest) (*response.StationImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportOpenChargeMap", ctx, request)
+	ret0, _ := ret[0].(*response.StationImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportOpenChargeMap indicates an expected call of ImportOpenChargeMap.
+func (mr *MockEVStationUsecaseMockRecorder) ImportOpenChargeMap(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportOpenChargeMap", reflect.TypeOf((*MockEVStationUsecase)(nil).ImportOpenChargeMap), ctx, request)
+}
+
+// ImportStations mocks base method.
+func (m *MockEVStationUsecase) ImportStations(ctx context.Context, request request.ImportStationsRequest) (*response.StationImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportStations", ctx, request)
+	ret0, _ := ret[0].(*response.StationImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportStations indicates an expected call of ImportStations.
+func (mr *MockEVStationUsecaseMockRecorder) ImportStations(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportStations", reflect.TypeOf((*MockEVStationUsecase)(nil).ImportStations), ctx, request)
+}
+
+// PatchStation mocks base method.
+func (m *MockEVStationUsecase) PatchStation(ctx context.Context, req request.PatchStationRequest) (*response.EVStationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchStation", ctx, req)
+	ret0, _ := ret[0].(*response.EVStationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchStation indicates an expected call of PatchStation.
+func (mr *MockEVStationUsecaseMockRecorder) PatchStation(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchStation", reflect.TypeOf((*MockEVStationUsecase)(nil).PatchStation), ctx, req)
+}
+
+// PurgeDeletedStations mocks base method.
+func (m *MockEVStationUsecase) PurgeDeletedStations(ctx context.Context, retention time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedStations", ctx, retention)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedStations indicates an expected call of PurgeDeletedStations.
+func (mr *MockEVStationUsecaseMockRecorder) PurgeDeletedStations(ctx, retention interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedStations", reflect.TypeOf((*MockEVStationUsecase)(nil).PurgeDeletedStations), ctx, retention)
+}
+
+// RemoveConnector mocks base method.
+func (m *MockEVStationUsecase) RemoveConnector(ctx context.Context, request request.RemoveConnectorRequest) (*response.EVStationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveConnector", ctx, request)
+	ret0, _ := ret[0].(*response.EVStationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveConnector indicates an expected call of RemoveConnector.
+func (mr *MockEVStationUsecaseMockRecorder) RemoveConnector(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveConnector", reflect.TypeOf((*MockEVStationUsecase)(nil).RemoveConnector), ctx, request)
+}
+
 // RemoveStation mocks base method.
 func (m *MockEVStationUsecase) RemoveStation(ctx context.Context, request request.RemoveStationRequest) error {
 	m.ctrl.T.Helper()
@@ -169,6 +349,36 @@ func (mr *MockEVStationUsecaseMockRecorder) RemoveStation(ctx, request interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveStation", reflect.TypeOf((*MockEVStationUsecase)(nil).RemoveStation), ctx, request)
 }
 
+// RestoreStation mocks base method.
+func (m *MockEVStationUsecase) RestoreStation(ctx context.Context, request request.RestoreStationRequest) (*response.EVStationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreStation", ctx, request)
+	ret0, _ := ret[0].(*response.EVStationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreStation indicates an expected call of RestoreStation.
+func (mr *MockEVStationUsecaseMockRecorder) RestoreStation(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreStation", reflect.TypeOf((*MockEVStationUsecase)(nil).RestoreStation), ctx, request)
+}
+
+// RevertStation mocks base method.
+func (m *MockEVStationUsecase) RevertStation(ctx context.Context, request request.RevertStationRequest) (*response.EVStationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertStation", ctx, request)
+	ret0, _ := ret[0].(*response.EVStationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertStation indicates an expected call of RevertStation.
+func (mr *MockEVStationUsecaseMockRecorder) RevertStation(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertStation", reflect.TypeOf((*MockEVStationUsecase)(nil).RevertStation), ctx, request)
+}
+
 // SetBooking mocks base method.
 func (m *MockEVStationUsecase) SetBooking(ctx context.Context, request request.SetBookingRequest) error {
 	m.ctrl.T.Helper()
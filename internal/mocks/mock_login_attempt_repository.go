@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: login_attempt_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "Ev-Charge-Hub/Server/internal/domain/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoginAttemptRepository is a mock of LoginAttemptRepository interface.
+type MockLoginAttemptRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptRepositoryMockRecorder
+}
+
+// MockLoginAttemptRepositoryMockRecorder is the mock recorder for MockLoginAttemptRepository.
+type MockLoginAttemptRepositoryMockRecorder struct {
+	mock *MockLoginAttemptRepository
+}
+
+// NewMockLoginAttemptRepository creates a new mock instance.
+func NewMockLoginAttemptRepository(ctrl *gomock.Controller) *MockLoginAttemptRepository {
+	mock := &MockLoginAttemptRepository{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptRepository) EXPECT() *MockLoginAttemptRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClearLoginAttempts mocks base method.
+func (m *MockLoginAttemptRepository) ClearLoginAttempts(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearLoginAttempts", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearLoginAttempts indicates an expected call of ClearLoginAttempts.
+func (mr *MockLoginAttemptRepositoryMockRecorder) ClearLoginAttempts(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLoginAttempts", reflect.TypeOf((*MockLoginAttemptRepository)(nil).ClearLoginAttempts), ctx, key)
+}
+
+// EnsureLoginAttemptIndexes mocks base method.
+func (m *MockLoginAttemptRepository) EnsureLoginAttemptIndexes(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureLoginAttemptIndexes", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureLoginAttemptIndexes indicates an expected call of EnsureLoginAttemptIndexes.
+func (mr *MockLoginAttemptRepositoryMockRecorder) EnsureLoginAttemptIndexes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureLoginAttemptIndexes", reflect.TypeOf((*MockLoginAttemptRepository)(nil).EnsureLoginAttemptIndexes), ctx)
+}
+
+// FindLoginAttempt mocks base method.
+func (m *MockLoginAttemptRepository) FindLoginAttempt(ctx context.Context, key string) (*models.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLoginAttempt", ctx, key)
+	ret0, _ := ret[0].(*models.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLoginAttempt indicates an expected call of FindLoginAttempt.
+func (mr *MockLoginAttemptRepositoryMockRecorder) FindLoginAttempt(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLoginAttempt", reflect.TypeOf((*MockLoginAttemptRepository)(nil).FindLoginAttempt), ctx, key)
+}
+
+// IncrementLoginFailures mocks base method.
+func (m *MockLoginAttemptRepository) IncrementLoginFailures(ctx context.Context, key string, now time.Time, window time.Duration) (*models.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementLoginFailures", ctx, key, now, window)
+	ret0, _ := ret[0].(*models.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementLoginFailures indicates an expected call of IncrementLoginFailures.
+func (mr *MockLoginAttemptRepositoryMockRecorder) IncrementLoginFailures(ctx, key, now, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementLoginFailures", reflect.TypeOf((*MockLoginAttemptRepository)(nil).IncrementLoginFailures), ctx, key, now, window)
+}
+
+// LockLogin mocks base method.
+func (m *MockLoginAttemptRepository) LockLogin(ctx context.Context, key string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLogin", ctx, key, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockLogin indicates an expected call of LockLogin.
+func (mr *MockLoginAttemptRepositoryMockRecorder) LockLogin(ctx, key, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLogin", reflect.TypeOf((*MockLoginAttemptRepository)(nil).LockLogin), ctx, key, until)
+}
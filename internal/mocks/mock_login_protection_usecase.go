@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: login_protection_usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoginProtectionUsecase is a mock of LoginProtectionUsecase interface.
+type MockLoginProtectionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginProtectionUsecaseMockRecorder
+}
+
+// MockLoginProtectionUsecaseMockRecorder is the mock recorder for MockLoginProtectionUsecase.
+type MockLoginProtectionUsecaseMockRecorder struct {
+	mock *MockLoginProtectionUsecase
+}
+
+// NewMockLoginProtectionUsecase creates a new mock instance.
+func NewMockLoginProtectionUsecase(ctrl *gomock.Controller) *MockLoginProtectionUsecase {
+	mock := &MockLoginProtectionUsecase{ctrl: ctrl}
+	mock.recorder = &MockLoginProtectionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginProtectionUsecase) EXPECT() *MockLoginProtectionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CheckLogin mocks base method.
+func (m *MockLoginProtectionUsecase) CheckLogin(ctx context.Context, accountKey, clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckLogin", ctx, accountKey, clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckLogin indicates an expected call of CheckLogin.
+func (mr *MockLoginProtectionUsecaseMockRecorder) CheckLogin(ctx, accountKey, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLogin", reflect.TypeOf((*MockLoginProtectionUsecase)(nil).CheckLogin), ctx, accountKey, clientIP)
+}
+
+// RecordLoginFailure mocks base method.
+func (m *MockLoginProtectionUsecase) RecordLoginFailure(ctx context.Context, accountKey, clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLoginFailure", ctx, accountKey, clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordLoginFailure indicates an expected call of RecordLoginFailure.
+func (mr *MockLoginProtectionUsecaseMockRecorder) RecordLoginFailure(ctx, accountKey, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginFailure", reflect.TypeOf((*MockLoginProtectionUsecase)(nil).RecordLoginFailure), ctx, accountKey, clientIP)
+}
+
+// RecordLoginSuccess mocks base method.
+func (m *MockLoginProtectionUsecase) RecordLoginSuccess(ctx context.Context, accountKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordLoginSuccess", ctx, accountKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordLoginSuccess indicates an expected call of RecordLoginSuccess.
+func (mr *MockLoginProtectionUsecaseMockRecorder) RecordLoginSuccess(ctx, accountKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginSuccess", reflect.TypeOf((*MockLoginProtectionUsecase)(nil).RecordLoginSuccess), ctx, accountKey)
+}
+
+// UnlockAccount mocks base method.
+func (m *MockLoginProtectionUsecase) UnlockAccount(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAccount", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockAccount indicates an expected call of UnlockAccount.
+func (mr *MockLoginProtectionUsecaseMockRecorder) UnlockAccount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAccount", reflect.TypeOf((*MockLoginProtectionUsecase)(nil).UnlockAccount), ctx, userID)
+}
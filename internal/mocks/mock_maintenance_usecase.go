@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maintenance_usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	request "Ev-Charge-Hub/Server/internal/dto/request"
+	response "Ev-Charge-Hub/Server/internal/dto/response"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMaintenanceUsecase is a mock of MaintenanceUsecase interface.
+type MockMaintenanceUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceUsecaseMockRecorder
+}
+
+// MockMaintenanceUsecaseMockRecorder is the mock recorder for MockMaintenanceUsecase.
+type MockMaintenanceUsecaseMockRecorder struct {
+	mock *MockMaintenanceUsecase
+}
+
+// NewMockMaintenanceUsecase creates a new mock instance.
+func NewMockMaintenanceUsecase(ctrl *gomock.Controller) *MockMaintenanceUsecase {
+	mock := &MockMaintenanceUsecase{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceUsecase) EXPECT() *MockMaintenanceUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateMaintenanceWindow mocks base method.
+func (m *MockMaintenanceUsecase) CreateMaintenanceWindow(ctx context.Context, req request.MaintenanceWindowRequest) (*response.CreateMaintenanceWindowResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMaintenanceWindow", ctx, req)
+	ret0, _ := ret[0].(*response.CreateMaintenanceWindowResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMaintenanceWindow indicates an expected call of CreateMaintenanceWindow.
+func (mr *MockMaintenanceUsecaseMockRecorder) CreateMaintenanceWindow(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMaintenanceWindow", reflect.TypeOf((*MockMaintenanceUsecase)(nil).CreateMaintenanceWindow), ctx, req)
+}
+
+// EditMaintenanceWindow mocks base method.
+func (m *MockMaintenanceUsecase) EditMaintenanceWindow(ctx context.Context, req request.MaintenanceWindowRequest) (*response.MaintenanceWindowResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditMaintenanceWindow", ctx, req)
+	ret0, _ := ret[0].(*response.MaintenanceWindowResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditMaintenanceWindow indicates an expected call of EditMaintenanceWindow.
+func (mr *MockMaintenanceUsecaseMockRecorder) EditMaintenanceWindow(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditMaintenanceWindow", reflect.TypeOf((*MockMaintenanceUsecase)(nil).EditMaintenanceWindow), ctx, req)
+}
+
+// GetMaintenanceWindows mocks base method.
+func (m *MockMaintenanceUsecase) GetMaintenanceWindows(ctx context.Context, req request.GetMaintenanceWindowsRequest) ([]response.MaintenanceWindowResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaintenanceWindows", ctx, req)
+	ret0, _ := ret[0].([]response.MaintenanceWindowResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMaintenanceWindows indicates an expected call of GetMaintenanceWindows.
+func (mr *MockMaintenanceUsecaseMockRecorder) GetMaintenanceWindows(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaintenanceWindows", reflect.TypeOf((*MockMaintenanceUsecase)(nil).GetMaintenanceWindows), ctx, req)
+}
+
+// RemoveMaintenanceWindow mocks base method.
+func (m *MockMaintenanceUsecase) RemoveMaintenanceWindow(ctx context.Context, req request.RemoveMaintenanceWindowRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMaintenanceWindow", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMaintenanceWindow indicates an expected call of RemoveMaintenanceWindow.
+func (mr *MockMaintenanceUsecaseMockRecorder) RemoveMaintenanceWindow(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMaintenanceWindow", reflect.TypeOf((*MockMaintenanceUsecase)(nil).RemoveMaintenanceWindow), ctx, req)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: client.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	response "Ev-Charge-Hub/Server/internal/dto/response"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// GetVersionDetails mocks base method.
+func (m *MockClient) GetVersionDetails(ctx context.Context, url, token string) (*response.OCPIVersionDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersionDetails", ctx, url, token)
+	ret0, _ := ret[0].(*response.OCPIVersionDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersionDetails indicates an expected call of GetVersionDetails.
+func (mr *MockClientMockRecorder) GetVersionDetails(ctx, url, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersionDetails", reflect.TypeOf((*MockClient)(nil).GetVersionDetails), ctx, url, token)
+}
+
+// GetVersions mocks base method.
+func (m *MockClient) GetVersions(ctx context.Context, url, token string) ([]response.OCPIVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", ctx, url, token)
+	ret0, _ := ret[0].([]response.OCPIVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersions indicates an expected call of GetVersions.
+func (mr *MockClientMockRecorder) GetVersions(ctx, url, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*MockClient)(nil).GetVersions), ctx, url, token)
+}
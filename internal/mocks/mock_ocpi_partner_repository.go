@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ocpi_partner_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "Ev-Charge-Hub/Server/internal/domain/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOCPIPartnerRepository is a mock of OCPIPartnerRepository interface.
+type MockOCPIPartnerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOCPIPartnerRepositoryMockRecorder
+}
+
+// MockOCPIPartnerRepositoryMockRecorder is the mock recorder for MockOCPIPartnerRepository.
+type MockOCPIPartnerRepositoryMockRecorder struct {
+	mock *MockOCPIPartnerRepository
+}
+
+// NewMockOCPIPartnerRepository creates a new mock instance.
+func NewMockOCPIPartnerRepository(ctrl *gomock.Controller) *MockOCPIPartnerRepository {
+	mock := &MockOCPIPartnerRepository{ctrl: ctrl}
+	mock.recorder = &MockOCPIPartnerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOCPIPartnerRepository) EXPECT() *MockOCPIPartnerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePartner mocks base method.
+func (m *MockOCPIPartnerRepository) CreatePartner(ctx context.Context, partner *models.OCPIPartner) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePartner", ctx, partner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePartner indicates an expected call of CreatePartner.
+func (mr *MockOCPIPartnerRepositoryMockRecorder) CreatePartner(ctx, partner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePartner", reflect.TypeOf((*MockOCPIPartnerRepository)(nil).CreatePartner), ctx, partner)
+}
+
+// FindPartnerByID mocks base method.
+func (m *MockOCPIPartnerRepository) FindPartnerByID(ctx context.Context, id string) (*models.OCPIPartner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPartnerByID", ctx, id)
+	ret0, _ := ret[0].(*models.OCPIPartner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPartnerByID indicates an expected call of FindPartnerByID.
+func (mr *MockOCPIPartnerRepositoryMockRecorder) FindPartnerByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPartnerByID", reflect.TypeOf((*MockOCPIPartnerRepository)(nil).FindPartnerByID), ctx, id)
+}
+
+// FindPartnerByTokenHash mocks base method.
+func (m *MockOCPIPartnerRepository) FindPartnerByTokenHash(ctx context.Context, tokenHash string) (*models.OCPIPartner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPartnerByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.OCPIPartner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPartnerByTokenHash indicates an expected call of FindPartnerByTokenHash.
+func (mr *MockOCPIPartnerRepositoryMockRecorder) FindPartnerByTokenHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPartnerByTokenHash", reflect.TypeOf((*MockOCPIPartnerRepository)(nil).FindPartnerByTokenHash), ctx, tokenHash)
+}
+
+// FindPartners mocks base method.
+func (m *MockOCPIPartnerRepository) FindPartners(ctx context.Context) ([]models.OCPIPartner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPartners", ctx)
+	ret0, _ := ret[0].([]models.OCPIPartner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPartners indicates an expected call of FindPartners.
+func (mr *MockOCPIPartnerRepositoryMockRecorder) FindPartners(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPartners", reflect.TypeOf((*MockOCPIPartnerRepository)(nil).FindPartners), ctx)
+}
+
+// UpdatePartner mocks base method.
+func (m *MockOCPIPartnerRepository) UpdatePartner(ctx context.Context, partner *models.OCPIPartner) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePartner", ctx, partner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePartner indicates an expected call of UpdatePartner.
+func (mr *MockOCPIPartnerRepositoryMockRecorder) UpdatePartner(ctx, partner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePartner", reflect.TypeOf((*MockOCPIPartnerRepository)(nil).UpdatePartner), ctx, partner)
+}
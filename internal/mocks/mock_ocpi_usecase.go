@@ -0,0 +1,229 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ocpi_usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "Ev-Charge-Hub/Server/internal/domain/models"
+	request "Ev-Charge-Hub/Server/internal/dto/request"
+	response "Ev-Charge-Hub/Server/internal/dto/response"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOCPIUsecase is a mock of OCPIUsecase interface.
+type MockOCPIUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOCPIUsecaseMockRecorder
+}
+
+// MockOCPIUsecaseMockRecorder is the mock recorder for MockOCPIUsecase.
+type MockOCPIUsecaseMockRecorder struct {
+	mock *MockOCPIUsecase
+}
+
+// NewMockOCPIUsecase creates a new mock instance.
+func NewMockOCPIUsecase(ctrl *gomock.Controller) *MockOCPIUsecase {
+	mock := &MockOCPIUsecase{ctrl: ctrl}
+	mock.recorder = &MockOCPIUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOCPIUsecase) EXPECT() *MockOCPIUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AuthenticateToken mocks base method.
+func (m *MockOCPIUsecase) AuthenticateToken(ctx context.Context, token string) (*models.OCPIPartner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateToken", ctx, token)
+	ret0, _ := ret[0].(*models.OCPIPartner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateToken indicates an expected call of AuthenticateToken.
+func (mr *MockOCPIUsecaseMockRecorder) AuthenticateToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateToken", reflect.TypeOf((*MockOCPIUsecase)(nil).AuthenticateToken), ctx, token)
+}
+
+// CreatePartner mocks base method.
+func (m *MockOCPIUsecase) CreatePartner(ctx context.Context, req request.CreateOCPIPartnerRequest) (*response.OCPIPartnerCreatedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePartner", ctx, req)
+	ret0, _ := ret[0].(*response.OCPIPartnerCreatedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePartner indicates an expected call of CreatePartner.
+func (mr *MockOCPIUsecaseMockRecorder) CreatePartner(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePartner", reflect.TypeOf((*MockOCPIUsecase)(nil).CreatePartner), ctx, req)
+}
+
+// DeleteCredentials mocks base method.
+func (m *MockOCPIUsecase) DeleteCredentials(ctx context.Context, partnerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCredentials", ctx, partnerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCredentials indicates an expected call of DeleteCredentials.
+func (mr *MockOCPIUsecaseMockRecorder) DeleteCredentials(ctx, partnerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCredentials", reflect.TypeOf((*MockOCPIUsecase)(nil).DeleteCredentials), ctx, partnerID)
+}
+
+// GetConnector mocks base method.
+func (m *MockOCPIUsecase) GetConnector(ctx context.Context, req request.OCPILocationRequest) (*response.OCPIConnector, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnector", ctx, req)
+	ret0, _ := ret[0].(*response.OCPIConnector)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConnector indicates an expected call of GetConnector.
+func (mr *MockOCPIUsecaseMockRecorder) GetConnector(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnector", reflect.TypeOf((*MockOCPIUsecase)(nil).GetConnector), ctx, req)
+}
+
+// GetCredentials mocks base method.
+func (m *MockOCPIUsecase) GetCredentials(ctx context.Context, token string) response.OCPICredentials {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredentials", ctx, token)
+	ret0, _ := ret[0].(response.OCPICredentials)
+	return ret0
+}
+
+// GetCredentials indicates an expected call of GetCredentials.
+func (mr *MockOCPIUsecaseMockRecorder) GetCredentials(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentials", reflect.TypeOf((*MockOCPIUsecase)(nil).GetCredentials), ctx, token)
+}
+
+// GetEVSE mocks base method.
+func (m *MockOCPIUsecase) GetEVSE(ctx context.Context, req request.OCPILocationRequest) (*response.OCPIEVSE, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEVSE", ctx, req)
+	ret0, _ := ret[0].(*response.OCPIEVSE)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEVSE indicates an expected call of GetEVSE.
+func (mr *MockOCPIUsecaseMockRecorder) GetEVSE(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEVSE", reflect.TypeOf((*MockOCPIUsecase)(nil).GetEVSE), ctx, req)
+}
+
+// GetLocation mocks base method.
+func (m *MockOCPIUsecase) GetLocation(ctx context.Context, req request.OCPILocationRequest) (*response.OCPILocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocation", ctx, req)
+	ret0, _ := ret[0].(*response.OCPILocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocation indicates an expected call of GetLocation.
+func (mr *MockOCPIUsecaseMockRecorder) GetLocation(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocation", reflect.TypeOf((*MockOCPIUsecase)(nil).GetLocation), ctx, req)
+}
+
+// GetLocations mocks base method.
+func (m *MockOCPIUsecase) GetLocations(ctx context.Context, req request.OCPILocationsRequest) (*response.OCPILocationPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocations", ctx, req)
+	ret0, _ := ret[0].(*response.OCPILocationPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocations indicates an expected call of GetLocations.
+func (mr *MockOCPIUsecaseMockRecorder) GetLocations(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocations", reflect.TypeOf((*MockOCPIUsecase)(nil).GetLocations), ctx, req)
+}
+
+// GetPartners mocks base method.
+func (m *MockOCPIUsecase) GetPartners(ctx context.Context) ([]response.OCPIPartnerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPartners", ctx)
+	ret0, _ := ret[0].([]response.OCPIPartnerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPartners indicates an expected call of GetPartners.
+func (mr *MockOCPIUsecaseMockRecorder) GetPartners(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartners", reflect.TypeOf((*MockOCPIUsecase)(nil).GetPartners), ctx)
+}
+
+// GetVersionDetails mocks base method.
+func (m *MockOCPIUsecase) GetVersionDetails(ctx context.Context) response.OCPIVersionDetails {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersionDetails", ctx)
+	ret0, _ := ret[0].(response.OCPIVersionDetails)
+	return ret0
+}
+
+// GetVersionDetails indicates an expected call of GetVersionDetails.
+func (mr *MockOCPIUsecaseMockRecorder) GetVersionDetails(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersionDetails", reflect.TypeOf((*MockOCPIUsecase)(nil).GetVersionDetails), ctx)
+}
+
+// GetVersions mocks base method.
+func (m *MockOCPIUsecase) GetVersions(ctx context.Context) []response.OCPIVersion {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", ctx)
+	ret0, _ := ret[0].([]response.OCPIVersion)
+	return ret0
+}
+
+// GetVersions indicates an expected call of GetVersions.
+func (mr *MockOCPIUsecaseMockRecorder) GetVersions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*MockOCPIUsecase)(nil).GetVersions), ctx)
+}
+
+// RegisterCredentials mocks base method.
+func (m *MockOCPIUsecase) RegisterCredentials(ctx context.Context, partnerID string, req request.OCPICredentialsRequest) (*response.OCPICredentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterCredentials", ctx, partnerID, req)
+	ret0, _ := ret[0].(*response.OCPICredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterCredentials indicates an expected call of RegisterCredentials.
+func (mr *MockOCPIUsecaseMockRecorder) RegisterCredentials(ctx, partnerID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterCredentials", reflect.TypeOf((*MockOCPIUsecase)(nil).RegisterCredentials), ctx, partnerID, req)
+}
+
+// UpdateCredentials mocks base method.
+func (m *MockOCPIUsecase) UpdateCredentials(ctx context.Context, partnerID string, req request.OCPICredentialsRequest) (*response.OCPICredentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCredentials", ctx, partnerID, req)
+	ret0, _ := ret[0].(*response.OCPICredentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCredentials indicates an expected call of UpdateCredentials.
+func (mr *MockOCPIUsecaseMockRecorder) UpdateCredentials(ctx, partnerID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCredentials", reflect.TypeOf((*MockOCPIUsecase)(nil).UpdateCredentials), ctx, partnerID, req)
+}
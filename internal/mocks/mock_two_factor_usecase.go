@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: two_factor_usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "Ev-Charge-Hub/Server/internal/domain/models"
+	request "Ev-Charge-Hub/Server/internal/dto/request"
+	response "Ev-Charge-Hub/Server/internal/dto/response"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTwoFactorUsecase is a mock of TwoFactorUsecase interface.
+type MockTwoFactorUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorUsecaseMockRecorder
+}
+
+// MockTwoFactorUsecaseMockRecorder is the mock recorder for MockTwoFactorUsecase.
+type MockTwoFactorUsecaseMockRecorder struct {
+	mock *MockTwoFactorUsecase
+}
+
+// NewMockTwoFactorUsecase creates a new mock instance.
+func NewMockTwoFactorUsecase(ctrl *gomock.Controller) *MockTwoFactorUsecase {
+	mock := &MockTwoFactorUsecase{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorUsecase) EXPECT() *MockTwoFactorUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ActivateTOTP mocks base method.
+func (m *MockTwoFactorUsecase) ActivateTOTP(ctx context.Context, req request.TOTPCodeRequest) (*response.RecoveryCodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateTOTP", ctx, req)
+	ret0, _ := ret[0].(*response.RecoveryCodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateTOTP indicates an expected call of ActivateTOTP.
+func (mr *MockTwoFactorUsecaseMockRecorder) ActivateTOTP(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateTOTP", reflect.TypeOf((*MockTwoFactorUsecase)(nil).ActivateTOTP), ctx, req)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockTwoFactorUsecase) DisableTOTP(ctx context.Context, req request.TOTPCodeRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockTwoFactorUsecaseMockRecorder) DisableTOTP(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockTwoFactorUsecase)(nil).DisableTOTP), ctx, req)
+}
+
+// LoginChallenge mocks base method.
+func (m *MockTwoFactorUsecase) LoginChallenge(ctx context.Context, user *models.UserModel) (*response.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginChallenge", ctx, user)
+	ret0, _ := ret[0].(*response.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginChallenge indicates an expected call of LoginChallenge.
+func (mr *MockTwoFactorUsecaseMockRecorder) LoginChallenge(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginChallenge", reflect.TypeOf((*MockTwoFactorUsecase)(nil).LoginChallenge), ctx, user)
+}
+
+// RegenerateRecoveryCodes mocks base method.
+func (m *MockTwoFactorUsecase) RegenerateRecoveryCodes(ctx context.Context, req request.TOTPCodeRequest) (*response.RecoveryCodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRecoveryCodes", ctx, req)
+	ret0, _ := ret[0].(*response.RecoveryCodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateRecoveryCodes indicates an expected call of RegenerateRecoveryCodes.
+func (mr *MockTwoFactorUsecaseMockRecorder) RegenerateRecoveryCodes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRecoveryCodes", reflect.TypeOf((*MockTwoFactorUsecase)(nil).RegenerateRecoveryCodes), ctx, req)
+}
+
+// ResetTwoFactor mocks base method.
+func (m *MockTwoFactorUsecase) ResetTwoFactor(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetTwoFactor", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetTwoFactor indicates an expected call of ResetTwoFactor.
+func (mr *MockTwoFactorUsecaseMockRecorder) ResetTwoFactor(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetTwoFactor", reflect.TypeOf((*MockTwoFactorUsecase)(nil).ResetTwoFactor), ctx, userID)
+}
+
+// SetupFromChallenge mocks base method.
+func (m *MockTwoFactorUsecase) SetupFromChallenge(ctx context.Context, req request.TwoFactorChallengeSetupRequest) (*response.TOTPSetupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetupFromChallenge", ctx, req)
+	ret0, _ := ret[0].(*response.TOTPSetupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetupFromChallenge indicates an expected call of SetupFromChallenge.
+func (mr *MockTwoFactorUsecaseMockRecorder) SetupFromChallenge(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupFromChallenge", reflect.TypeOf((*MockTwoFactorUsecase)(nil).SetupFromChallenge), ctx, req)
+}
+
+// SetupTOTP mocks base method.
+func (m *MockTwoFactorUsecase) SetupTOTP(ctx context.Context, userID string) (*response.TOTPSetupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetupTOTP", ctx, userID)
+	ret0, _ := ret[0].(*response.TOTPSetupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetupTOTP indicates an expected call of SetupTOTP.
+func (mr *MockTwoFactorUsecaseMockRecorder) SetupTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupTOTP", reflect.TypeOf((*MockTwoFactorUsecase)(nil).SetupTOTP), ctx, userID)
+}
+
+// VerifyLoginChallenge mocks base method.
+func (m *MockTwoFactorUsecase) VerifyLoginChallenge(ctx context.Context, req request.TwoFactorLoginRequest) (*response.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyLoginChallenge", ctx, req)
+	ret0, _ := ret[0].(*response.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyLoginChallenge indicates an expected call of VerifyLoginChallenge.
+func (mr *MockTwoFactorUsecaseMockRecorder) VerifyLoginChallenge(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLoginChallenge", reflect.TypeOf((*MockTwoFactorUsecase)(nil).VerifyLoginChallenge), ctx, req)
+}
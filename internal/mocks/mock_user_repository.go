@@ -8,6 +8,7 @@ import (
 	models "Ev-Charge-Hub/Server/internal/domain/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,6 +36,20 @@ func (m *MockUserRepositoryInterface) EXPECT() *MockUserRepositoryInterfaceMockR
 	return m.recorder
 }
 
+// ClearTwoFactorChallenge mocks base method.
+func (m *MockUserRepositoryInterface) ClearTwoFactorChallenge(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearTwoFactorChallenge", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearTwoFactorChallenge indicates an expected call of ClearTwoFactorChallenge.
+func (mr *MockUserRepositoryInterfaceMockRecorder) ClearTwoFactorChallenge(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearTwoFactorChallenge", reflect.TypeOf((*MockUserRepositoryInterface)(nil).ClearTwoFactorChallenge), ctx, id)
+}
+
 // CreateUser mocks base method.
 func (m *MockUserRepositoryInterface) CreateUser(ctx context.Context, user *models.UserModel) error {
 	m.ctrl.T.Helper()
@@ -49,6 +64,48 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) CreateUser(ctx, user interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepositoryInterface)(nil).CreateUser), ctx, user)
 }
 
+// DisableTOTP mocks base method.
+func (m *MockUserRepositoryInterface) DisableTOTP(ctx context.Context, id string, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, id, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockUserRepositoryInterfaceMockRecorder) DisableTOTP(ctx, id, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockUserRepositoryInterface)(nil).DisableTOTP), ctx, id, updatedAt)
+}
+
+// EnableTOTP mocks base method.
+func (m *MockUserRepositoryInterface) EnableTOTP(ctx context.Context, id, secret string, step int64, recoveryCodeHashes []string, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTOTP", ctx, id, secret, step, recoveryCodeHashes, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTOTP indicates an expected call of EnableTOTP.
+func (mr *MockUserRepositoryInterfaceMockRecorder) EnableTOTP(ctx, id, secret, step, recoveryCodeHashes, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTOTP", reflect.TypeOf((*MockUserRepositoryInterface)(nil).EnableTOTP), ctx, id, secret, step, recoveryCodeHashes, updatedAt)
+}
+
+// EnsureUserIndexes mocks base method.
+func (m *MockUserRepositoryInterface) EnsureUserIndexes(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureUserIndexes", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureUserIndexes indicates an expected call of EnsureUserIndexes.
+func (mr *MockUserRepositoryInterfaceMockRecorder) EnsureUserIndexes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureUserIndexes", reflect.TypeOf((*MockUserRepositoryInterface)(nil).EnsureUserIndexes), ctx)
+}
+
 // FindByUsernameOrEmail mocks base method.
 func (m *MockUserRepositoryInterface) FindByUsernameOrEmail(ctx context.Context, usernameOrEmail string) (*models.UserModel, error) {
 	m.ctrl.T.Helper()
@@ -63,3 +120,251 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) FindByUsernameOrEmail(ctx, us
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsernameOrEmail", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByUsernameOrEmail), ctx, usernameOrEmail)
 }
+
+// FindDuplicateUsers mocks base method.
+func (m *MockUserRepositoryInterface) FindDuplicateUsers(ctx context.Context, field string) ([]models.DuplicateUserGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicateUsers", ctx, field)
+	ret0, _ := ret[0].([]models.DuplicateUserGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicateUsers indicates an expected call of FindDuplicateUsers.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindDuplicateUsers(ctx, field interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicateUsers", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindDuplicateUsers), ctx, field)
+}
+
+// FindUserByEmail mocks base method.
+func (m *MockUserRepositoryInterface) FindUserByEmail(ctx context.Context, email string) (*models.UserModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByEmail", ctx, email)
+	ret0, _ := ret[0].(*models.UserModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByEmail indicates an expected call of FindUserByEmail.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindUserByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByEmail", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindUserByEmail), ctx, email)
+}
+
+// FindUserByID mocks base method.
+func (m *MockUserRepositoryInterface) FindUserByID(ctx context.Context, id string) (*models.UserModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByID", ctx, id)
+	ret0, _ := ret[0].(*models.UserModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByID indicates an expected call of FindUserByID.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByID", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindUserByID), ctx, id)
+}
+
+// FindUserByTwoFactorChallenge mocks base method.
+func (m *MockUserRepositoryInterface) FindUserByTwoFactorChallenge(ctx context.Context, challengeHash string, now time.Time) (*models.UserModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByTwoFactorChallenge", ctx, challengeHash, now)
+	ret0, _ := ret[0].(*models.UserModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByTwoFactorChallenge indicates an expected call of FindUserByTwoFactorChallenge.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindUserByTwoFactorChallenge(ctx, challengeHash, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByTwoFactorChallenge", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindUserByTwoFactorChallenge), ctx, challengeHash, now)
+}
+
+// FindUserByUsername mocks base method.
+func (m *MockUserRepositoryInterface) FindUserByUsername(ctx context.Context, username string) (*models.UserModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByUsername", ctx, username)
+	ret0, _ := ret[0].(*models.UserModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByUsername indicates an expected call of FindUserByUsername.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindUserByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByUsername", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindUserByUsername), ctx, username)
+}
+
+// FindUsersByRole mocks base method.
+func (m *MockUserRepositoryInterface) FindUsersByRole(ctx context.Context, role string) ([]models.UserModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUsersByRole", ctx, role)
+	ret0, _ := ret[0].([]models.UserModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUsersByRole indicates an expected call of FindUsersByRole.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindUsersByRole(ctx, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUsersByRole", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindUsersByRole), ctx, role)
+}
+
+// MarkLegacyEmailsVerified mocks base method.
+func (m *MockUserRepositoryInterface) MarkLegacyEmailsVerified(ctx context.Context, verifiedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkLegacyEmailsVerified", ctx, verifiedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkLegacyEmailsVerified indicates an expected call of MarkLegacyEmailsVerified.
+func (mr *MockUserRepositoryInterfaceMockRecorder) MarkLegacyEmailsVerified(ctx, verifiedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkLegacyEmailsVerified", reflect.TypeOf((*MockUserRepositoryInterface)(nil).MarkLegacyEmailsVerified), ctx, verifiedAt)
+}
+
+// RenameUser mocks base method.
+func (m *MockUserRepositoryInterface) RenameUser(ctx context.Context, id, username string, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameUser", ctx, id, username, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameUser indicates an expected call of RenameUser.
+func (mr *MockUserRepositoryInterfaceMockRecorder) RenameUser(ctx, id, username, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameUser", reflect.TypeOf((*MockUserRepositoryInterface)(nil).RenameUser), ctx, id, username, updatedAt)
+}
+
+// SetEmailVerificationToken mocks base method.
+func (m *MockUserRepositoryInterface) SetEmailVerificationToken(ctx context.Context, id, tokenHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailVerificationToken", ctx, id, tokenHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailVerificationToken indicates an expected call of SetEmailVerificationToken.
+func (mr *MockUserRepositoryInterfaceMockRecorder) SetEmailVerificationToken(ctx, id, tokenHash, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailVerificationToken", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SetEmailVerificationToken), ctx, id, tokenHash, expiresAt)
+}
+
+// SetPendingTOTPSecret mocks base method.
+func (m *MockUserRepositoryInterface) SetPendingTOTPSecret(ctx context.Context, id, secret, enrollmentCodeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPendingTOTPSecret", ctx, id, secret, enrollmentCodeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPendingTOTPSecret indicates an expected call of SetPendingTOTPSecret.
+func (mr *MockUserRepositoryInterfaceMockRecorder) SetPendingTOTPSecret(ctx, id, secret, enrollmentCodeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingTOTPSecret", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SetPendingTOTPSecret), ctx, id, secret, enrollmentCodeHash)
+}
+
+// SetTOTPRecoveryCodes mocks base method.
+func (m *MockUserRepositoryInterface) SetTOTPRecoveryCodes(ctx context.Context, id string, recoveryCodeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTOTPRecoveryCodes", ctx, id, recoveryCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTOTPRecoveryCodes indicates an expected call of SetTOTPRecoveryCodes.
+func (mr *MockUserRepositoryInterfaceMockRecorder) SetTOTPRecoveryCodes(ctx, id, recoveryCodeHashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTOTPRecoveryCodes", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SetTOTPRecoveryCodes), ctx, id, recoveryCodeHashes)
+}
+
+// SetTwoFactorChallenge mocks base method.
+func (m *MockUserRepositoryInterface) SetTwoFactorChallenge(ctx context.Context, id, challengeHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTwoFactorChallenge", ctx, id, challengeHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTwoFactorChallenge indicates an expected call of SetTwoFactorChallenge.
+func (mr *MockUserRepositoryInterfaceMockRecorder) SetTwoFactorChallenge(ctx, id, challengeHash, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTwoFactorChallenge", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SetTwoFactorChallenge), ctx, id, challengeHash, expiresAt)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockUserRepositoryInterface) UpdateUserPassword(ctx context.Context, id, passwordHash string, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, id, passwordHash, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockUserRepositoryInterfaceMockRecorder) UpdateUserPassword(ctx, id, passwordHash, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockUserRepositoryInterface)(nil).UpdateUserPassword), ctx, id, passwordHash, updatedAt)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockUserRepositoryInterface) UpdateUserRole(ctx context.Context, id, role string, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, id, role, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockUserRepositoryInterfaceMockRecorder) UpdateUserRole(ctx, id, role, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUserRepositoryInterface)(nil).UpdateUserRole), ctx, id, role, updatedAt)
+}
+
+// UseTOTPRecoveryCode mocks base method.
+func (m *MockUserRepositoryInterface) UseTOTPRecoveryCode(ctx context.Context, id, codeHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPRecoveryCode", ctx, id, codeHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPRecoveryCode indicates an expected call of UseTOTPRecoveryCode.
+func (mr *MockUserRepositoryInterfaceMockRecorder) UseTOTPRecoveryCode(ctx, id, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPRecoveryCode", reflect.TypeOf((*MockUserRepositoryInterface)(nil).UseTOTPRecoveryCode), ctx, id, codeHash)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockUserRepositoryInterface) UseTOTPStep(ctx context.Context, id string, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", ctx, id, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockUserRepositoryInterfaceMockRecorder) UseTOTPStep(ctx, id, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockUserRepositoryInterface)(nil).UseTOTPStep), ctx, id, step)
+}
+
+// VerifyEmailByTokenHash mocks base method.
+func (m *MockUserRepositoryInterface) VerifyEmailByTokenHash(ctx context.Context, tokenHash string, verifiedAt time.Time) (*models.UserModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmailByTokenHash", ctx, tokenHash, verifiedAt)
+	ret0, _ := ret[0].(*models.UserModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmailByTokenHash indicates an expected call of VerifyEmailByTokenHash.
+func (mr *MockUserRepositoryInterfaceMockRecorder) VerifyEmailByTokenHash(ctx, tokenHash, verifiedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmailByTokenHash", reflect.TypeOf((*MockUserRepositoryInterface)(nil).VerifyEmailByTokenHash), ctx, tokenHash, verifiedAt)
+}
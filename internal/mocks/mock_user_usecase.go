@@ -36,6 +36,36 @@ func (m *MockUserUsecaseInterface) EXPECT() *MockUserUsecaseInterfaceMockRecorde
 	return m.recorder
 }
 
+// ChangeUserRole mocks base method.
+func (m *MockUserUsecaseInterface) ChangeUserRole(ctx context.Context, req request.ChangeUserRoleRequest) (*response.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeUserRole", ctx, req)
+	ret0, _ := ret[0].(*response.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeUserRole indicates an expected call of ChangeUserRole.
+func (mr *MockUserUsecaseInterfaceMockRecorder) ChangeUserRole(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUserRole", reflect.TypeOf((*MockUserUsecaseInterface)(nil).ChangeUserRole), ctx, req)
+}
+
+// GetAdminAccounts mocks base method.
+func (m *MockUserUsecaseInterface) GetAdminAccounts(ctx context.Context) ([]response.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminAccounts", ctx)
+	ret0, _ := ret[0].([]response.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminAccounts indicates an expected call of GetAdminAccounts.
+func (mr *MockUserUsecaseInterfaceMockRecorder) GetAdminAccounts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminAccounts", reflect.TypeOf((*MockUserUsecaseInterface)(nil).GetAdminAccounts), ctx)
+}
+
 // LoginUser mocks base method.
 func (m *MockUserUsecaseInterface) LoginUser(ctx context.Context, req request.LoginRequest) (*response.LoginResponse, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +81,21 @@ func (mr *MockUserUsecaseInterfaceMockRecorder) LoginUser(ctx, req interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockUserUsecaseInterface)(nil).LoginUser), ctx, req)
 }
 
+// ProvisionUser mocks base method.
+func (m *MockUserUsecaseInterface) ProvisionUser(ctx context.Context, req request.ProvisionUserRequest) (*response.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProvisionUser", ctx, req)
+	ret0, _ := ret[0].(*response.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProvisionUser indicates an expected call of ProvisionUser.
+func (mr *MockUserUsecaseInterfaceMockRecorder) ProvisionUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProvisionUser", reflect.TypeOf((*MockUserUsecaseInterface)(nil).ProvisionUser), ctx, req)
+}
+
 // RegisterUser mocks base method.
 func (m *MockUserUsecaseInterface) RegisterUser(ctx context.Context, req request.RegisterUserRequest) error {
 	m.ctrl.T.Helper()
@@ -64,3 +109,17 @@ func (mr *MockUserUsecaseInterfaceMockRecorder) RegisterUser(ctx, req interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserUsecaseInterface)(nil).RegisterUser), ctx, req)
 }
+
+// UnlockUser mocks base method.
+func (m *MockUserUsecaseInterface) UnlockUser(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockUserUsecaseInterfaceMockRecorder) UnlockUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockUserUsecaseInterface)(nil).UnlockUser), ctx, userID)
+}
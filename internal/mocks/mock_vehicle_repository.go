@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vehicle_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "Ev-Charge-Hub/Server/internal/domain/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVehicleRepository is a mock of VehicleRepository interface.
+type MockVehicleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleRepositoryMockRecorder
+}
+
+// MockVehicleRepositoryMockRecorder is the mock recorder for MockVehicleRepository.
+type MockVehicleRepositoryMockRecorder struct {
+	mock *MockVehicleRepository
+}
+
+// NewMockVehicleRepository creates a new mock instance.
+func NewMockVehicleRepository(ctrl *gomock.Controller) *MockVehicleRepository {
+	mock := &MockVehicleRepository{ctrl: ctrl}
+	mock.recorder = &MockVehicleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleRepository) EXPECT() *MockVehicleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateVehicle mocks base method.
+func (m *MockVehicleRepository) CreateVehicle(ctx context.Context, vehicle *models.Vehicle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVehicle", ctx, vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVehicle indicates an expected call of CreateVehicle.
+func (mr *MockVehicleRepositoryMockRecorder) CreateVehicle(ctx, vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVehicle", reflect.TypeOf((*MockVehicleRepository)(nil).CreateVehicle), ctx, vehicle)
+}
+
+// EditVehicle mocks base method.
+func (m *MockVehicleRepository) EditVehicle(ctx context.Context, vehicle *models.Vehicle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditVehicle", ctx, vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditVehicle indicates an expected call of EditVehicle.
+func (mr *MockVehicleRepositoryMockRecorder) EditVehicle(ctx, vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditVehicle", reflect.TypeOf((*MockVehicleRepository)(nil).EditVehicle), ctx, vehicle)
+}
+
+// FindVehicleByID mocks base method.
+func (m *MockVehicleRepository) FindVehicleByID(ctx context.Context, id string) (*models.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVehicleByID", ctx, id)
+	ret0, _ := ret[0].(*models.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVehicleByID indicates an expected call of FindVehicleByID.
+func (mr *MockVehicleRepositoryMockRecorder) FindVehicleByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVehicleByID", reflect.TypeOf((*MockVehicleRepository)(nil).FindVehicleByID), ctx, id)
+}
+
+// FindVehiclesByUserID mocks base method.
+func (m *MockVehicleRepository) FindVehiclesByUserID(ctx context.Context, userID string) ([]models.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVehiclesByUserID", ctx, userID)
+	ret0, _ := ret[0].([]models.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVehiclesByUserID indicates an expected call of FindVehiclesByUserID.
+func (mr *MockVehicleRepositoryMockRecorder) FindVehiclesByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVehiclesByUserID", reflect.TypeOf((*MockVehicleRepository)(nil).FindVehiclesByUserID), ctx, userID)
+}
+
+// RemoveVehicle mocks base method.
+func (m *MockVehicleRepository) RemoveVehicle(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveVehicle", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveVehicle indicates an expected call of RemoveVehicle.
+func (mr *MockVehicleRepositoryMockRecorder) RemoveVehicle(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVehicle", reflect.TypeOf((*MockVehicleRepository)(nil).RemoveVehicle), ctx, id)
+}
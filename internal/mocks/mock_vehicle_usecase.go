@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vehicle_usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	request "Ev-Charge-Hub/Server/internal/dto/request"
+	response "Ev-Charge-Hub/Server/internal/dto/response"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVehicleUsecase is a mock of VehicleUsecase interface.
+type MockVehicleUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleUsecaseMockRecorder
+}
+
+// MockVehicleUsecaseMockRecorder is the mock recorder for MockVehicleUsecase.
+type MockVehicleUsecaseMockRecorder struct {
+	mock *MockVehicleUsecase
+}
+
+// NewMockVehicleUsecase creates a new mock instance.
+func NewMockVehicleUsecase(ctrl *gomock.Controller) *MockVehicleUsecase {
+	mock := &MockVehicleUsecase{ctrl: ctrl}
+	mock.recorder = &MockVehicleUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleUsecase) EXPECT() *MockVehicleUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateVehicle mocks base method.
+func (m *MockVehicleUsecase) CreateVehicle(ctx context.Context, req request.VehicleRequest) (*response.VehicleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVehicle", ctx, req)
+	ret0, _ := ret[0].(*response.VehicleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVehicle indicates an expected call of CreateVehicle.
+func (mr *MockVehicleUsecaseMockRecorder) CreateVehicle(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVehicle", reflect.TypeOf((*MockVehicleUsecase)(nil).CreateVehicle), ctx, req)
+}
+
+// EditVehicle mocks base method.
+func (m *MockVehicleUsecase) EditVehicle(ctx context.Context, req request.VehicleRequest) (*response.VehicleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditVehicle", ctx, req)
+	ret0, _ := ret[0].(*response.VehicleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditVehicle indicates an expected call of EditVehicle.
+func (mr *MockVehicleUsecaseMockRecorder) EditVehicle(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditVehicle", reflect.TypeOf((*MockVehicleUsecase)(nil).EditVehicle), ctx, req)
+}
+
+// GetVehicleByID mocks base method.
+func (m *MockVehicleUsecase) GetVehicleByID(ctx context.Context, req request.GetVehicleByIDRequest) (*response.VehicleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleByID", ctx, req)
+	ret0, _ := ret[0].(*response.VehicleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVehicleByID indicates an expected call of GetVehicleByID.
+func (mr *MockVehicleUsecaseMockRecorder) GetVehicleByID(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleByID", reflect.TypeOf((*MockVehicleUsecase)(nil).GetVehicleByID), ctx, req)
+}
+
+// GetVehicleCatalog mocks base method.
+func (m *MockVehicleUsecase) GetVehicleCatalog(ctx context.Context) []response.VehicleCatalogResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleCatalog", ctx)
+	ret0, _ := ret[0].([]response.VehicleCatalogResponse)
+	return ret0
+}
+
+// GetVehicleCatalog indicates an expected call of GetVehicleCatalog.
+func (mr *MockVehicleUsecaseMockRecorder) GetVehicleCatalog(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleCatalog", reflect.TypeOf((*MockVehicleUsecase)(nil).GetVehicleCatalog), ctx)
+}
+
+// GetVehicles mocks base method.
+func (m *MockVehicleUsecase) GetVehicles(ctx context.Context, req request.GetVehiclesRequest) ([]response.VehicleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicles", ctx, req)
+	ret0, _ := ret[0].([]response.VehicleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVehicles indicates an expected call of GetVehicles.
+func (mr *MockVehicleUsecaseMockRecorder) GetVehicles(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicles", reflect.TypeOf((*MockVehicleUsecase)(nil).GetVehicles), ctx, req)
+}
+
+// RemoveVehicle mocks base method.
+func (m *MockVehicleUsecase) RemoveVehicle(ctx context.Context, req request.RemoveVehicleRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveVehicle", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveVehicle indicates an expected call of RemoveVehicle.
+func (mr *MockVehicleUsecaseMockRecorder) RemoveVehicle(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVehicle", reflect.TypeOf((*MockVehicleUsecase)(nil).RemoveVehicle), ctx, req)
+}
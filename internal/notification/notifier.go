@@ -0,0 +1,25 @@
+package notification
+
+import (
+	"context"
+	"log"
+)
+
+//go:generate mockgen -source=notifier.go -destination=../mocks/mock_notifier.go -package=mocks
+
+// Notifier delivers a short message to a user identified by username
+type Notifier interface {
+	Notify(ctx context.Context, username string, subject string, message string) error
+}
+
+type logNotifier struct{}
+
+// NewLogNotifier writes notifications to the application log
+func NewLogNotifier() Notifier {
+	return &logNotifier{}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, username string, subject string, message string) error {
+	log.Printf("[NOTIFY] to=%s | %s | %s", username, subject, message)
+	return nil
+}
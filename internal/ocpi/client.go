@@ -0,0 +1,104 @@
+// Package ocpi talks to roaming partners over OCPI 2.2.1: the token header format and the
+// calls the credentials handshake makes to the partner's own versions endpoints.
+package ocpi
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//go:generate mockgen -source=client.go -destination=../mocks/mock_ocpi_client.go -package=mocks
+
+// Client fetches a partner's version information during the credentials handshake
+type Client interface {
+	GetVersions(ctx context.Context, url string, token string) ([]response.OCPIVersion, error)
+	GetVersionDetails(ctx context.Context, url string, token string) (*response.OCPIVersionDetails, error)
+}
+
+type httpClient struct {
+	client *http.Client
+}
+
+// NewHTTPClient calls partners with the given client; nil uses one with a 15 second timeout
+func NewHTTPClient(client *http.Client) Client {
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+	return &httpClient{client: client}
+}
+
+func (c *httpClient) GetVersions(ctx context.Context, url string, token string) ([]response.OCPIVersion, error) {
+	var versions []response.OCPIVersion
+	if err := c.get(ctx, url, token, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (c *httpClient) GetVersionDetails(ctx context.Context, url string, token string) (*response.OCPIVersionDetails, error) {
+	var details response.OCPIVersionDetails
+	if err := c.get(ctx, url, token, &details); err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
+// get unwraps the OCPI envelope into data
+func (c *httpClient) get(ctx context.Context, url string, token string, data interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", AuthorizationHeader(token))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: HTTP %d", url, resp.StatusCode)
+	}
+
+	var envelope struct {
+		Data          json.RawMessage `json:"data"`
+		StatusCode    int             `json:"status_code"`
+		StatusMessage string          `json:"status_message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("GET %s: %v", url, err)
+	}
+	if envelope.StatusCode != constants.OCPIStatusSuccess {
+		return fmt.Errorf("GET %s: status %d %s", url, envelope.StatusCode, envelope.StatusMessage)
+	}
+	return json.Unmarshal(envelope.Data, data)
+}
+
+// AuthorizationHeader formats a token as OCPI 2.2.1 expects: "Token " + base64(token)
+func AuthorizationHeader(token string) string {
+	return "Token " + base64.StdEncoding.EncodeToString([]byte(token))
+}
+
+// TokensFromHeader returns the candidate tokens in an Authorization header: the base64-decoded
+// value first (2.2.1) and the raw value second, which partners on older versions still send
+func TokensFromHeader(header string) []string {
+	raw, ok := strings.CutPrefix(header, "Token ")
+	raw = strings.TrimSpace(raw)
+	if !ok || raw == "" {
+		return nil
+	}
+
+	tokens := []string{}
+	if decoded, err := base64.StdEncoding.DecodeString(raw); err == nil && len(decoded) > 0 {
+		tokens = append(tokens, string(decoded))
+	}
+	return append(tokens, raw)
+}
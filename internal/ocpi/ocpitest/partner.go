@@ -0,0 +1,108 @@
+// Package ocpitest provides a fake OCPI partner for tests of the credentials handshake
+package ocpitest
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/ocpi"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Partner is an OCPI platform serving its versions and version details on a local server.
+// It only answers requests carrying Token (the token B it hands to us).
+type Partner struct {
+	Server   *httptest.Server
+	Token    string
+	Versions []string
+
+	mu       sync.Mutex
+	requests []string
+}
+
+// NewPartner starts a partner offering the given versions (2.2.1 when none are given).
+// Close the partner when the test ends.
+func NewPartner(token string, versions ...string) *Partner {
+	if len(versions) == 0 {
+		versions = []string{constants.OCPIVersion}
+	}
+	p := &Partner{Token: token, Versions: versions}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ocpi/versions", func(w http.ResponseWriter, r *http.Request) {
+		list := make([]response.OCPIVersion, 0, len(p.Versions))
+		for _, v := range p.Versions {
+			list = append(list, response.OCPIVersion{Version: v, URL: p.Server.URL + "/ocpi/" + v})
+		}
+		p.respond(w, r, list)
+	})
+	mux.HandleFunc("/ocpi/", func(w http.ResponseWriter, r *http.Request) {
+		version := r.URL.Path[len("/ocpi/"):]
+		if !slices.Contains(p.Versions, version) {
+			http.NotFound(w, r)
+			return
+		}
+		p.respond(w, r, response.OCPIVersionDetails{
+			Version: version,
+			Endpoints: []response.OCPIEndpoint{
+				{Identifier: constants.OCPIModuleCredentials, Role: constants.OCPIInterfaceSender, URL: p.Server.URL + "/ocpi/" + version + "/credentials"},
+				{Identifier: constants.OCPIModuleLocations, Role: constants.OCPIInterfaceReceiver, URL: p.Server.URL + "/ocpi/" + version + "/locations"},
+			},
+		})
+	})
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *Partner) Close() {
+	p.Server.Close()
+}
+
+// VersionsURL is where we fetch the partner's versions during the handshake
+func (p *Partner) VersionsURL() string {
+	return p.Server.URL + "/ocpi/versions"
+}
+
+// Credentials is what the partner posts to our credentials endpoint to register
+func (p *Partner) Credentials() request.OCPICredentialsRequest {
+	return request.OCPICredentialsRequest{
+		Token: p.Token,
+		URL:   p.VersionsURL(),
+		Roles: []request.OCPICredentialsRoleRequest{{
+			Role:            "EMSP",
+			BusinessDetails: request.OCPIBusinessDetailsRequest{Name: "Fake Roaming"},
+			PartyID:         "FRM",
+			CountryCode:     "NL",
+		}},
+	}
+}
+
+// Requests lists the paths the partner has served, in order
+func (p *Partner) Requests() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.requests...)
+}
+
+func (p *Partner) respond(w http.ResponseWriter, r *http.Request, data interface{}) {
+	if !slices.Contains(ocpi.TokensFromHeader(r.Header.Get("Authorization")), p.Token) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	p.mu.Lock()
+	p.requests = append(p.requests, r.URL.Path)
+	p.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response.OCPIResponse{
+		Data:       data,
+		StatusCode: constants.OCPIStatusSuccess,
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+	})
+}
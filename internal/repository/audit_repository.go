@@ -0,0 +1,164 @@
+package repository
+
+import (
+	domainModels "Ev-Charge-Hub/Server/internal/domain/models"
+	repoModels "Ev-Charge-Hub/Server/internal/repository/models"
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//go:generate mockgen -source=audit_repository.go -destination=../mocks/mock_audit_repository.go -package=mocks
+
+// AuditRepository is append-only: entries can be inserted and read but never changed or removed
+type AuditRepository interface {
+	InsertEntry(ctx context.Context, entry domainModels.AuditEntry) error
+	FindEntries(ctx context.Context, filter domainModels.AuditFilter) ([]domainModels.AuditEntry, error)
+}
+
+type auditRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAuditRepository indexes the fields FindEntries filters and sorts on, the collection only ever grows
+func NewAuditRepository(db *mongo.Database) AuditRepository {
+	repo := &auditRepository{collection: db.Collection("audit_logs")}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	_, err := repo.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}, Options: options.Index().SetName("timestamp_desc")},
+		{Keys: bson.D{{Key: "target_id", Value: 1}, {Key: "timestamp", Value: -1}}, Options: options.Index().SetName("target_id_timestamp")},
+		{Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "timestamp", Value: -1}}, Options: options.Index().SetName("actor_id_timestamp")},
+		{Keys: bson.D{{Key: "request_id", Value: 1}}, Options: options.Index().SetName("request_id")},
+	})
+	if err != nil {
+		log.Printf("⚠️ error creating audit log indexes: %v", err)
+	}
+	return repo
+}
+
+func (repo *auditRepository) InsertEntry(ctx context.Context, entry domainModels.AuditEntry) error {
+	changes := make([]repoModels.AuditChangeDB, 0, len(entry.Changes))
+	for _, c := range entry.Changes {
+		changes = append(changes, repoModels.AuditChangeDB{Field: c.Field, Before: c.Before, After: c.After})
+	}
+
+	_, err := repo.collection.InsertOne(ctx, repoModels.AuditEntryDB{
+		ID:         primitive.NewObjectID(),
+		ActorID:    entry.ActorID,
+		ActorName:  entry.ActorName,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		RequestID:  entry.RequestID,
+		Timestamp:  entry.Timestamp,
+		Changes:    changes,
+	})
+	return err
+}
+
+// FindEntries returns matching entries, newest first
+func (repo *auditRepository) FindEntries(ctx context.Context, filter domainModels.AuditFilter) ([]domainModels.AuditEntry, error) {
+	query := bson.M{}
+	if filter.ActorID != "" {
+		query["actor_id"] = filter.ActorID
+	}
+	if filter.ActorName != "" {
+		query["actor_name"] = filter.ActorName
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+	if filter.TargetType != "" {
+		query["target_type"] = filter.TargetType
+	}
+	if filter.TargetID != "" {
+		query["target_id"] = filter.TargetID
+	}
+	if filter.RequestID != "" {
+		query["request_id"] = filter.RequestID
+	}
+	if filter.From != nil || filter.To != nil {
+		timestamp := bson.M{}
+		if filter.From != nil {
+			timestamp["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			timestamp["$lte"] = *filter.To
+		}
+		query["timestamp"] = timestamp
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+	if filter.Skip > 0 {
+		opts.SetSkip(filter.Skip)
+	}
+
+	cursor, err := repo.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var entriesDB []repoModels.AuditEntryDB
+	if err := cursor.All(ctx, &entriesDB); err != nil {
+		return nil, err
+	}
+
+	entries := make([]domainModels.AuditEntry, 0, len(entriesDB))
+	for _, e := range entriesDB {
+		changes := make([]domainModels.AuditChange, 0, len(e.Changes))
+		for _, c := range e.Changes {
+			changes = append(changes, domainModels.AuditChange{
+				Field:  c.Field,
+				Before: normalizeAuditValue(c.Before),
+				After:  normalizeAuditValue(c.After),
+			})
+		}
+		entries = append(entries, domainModels.AuditEntry{
+			ID:         e.ID.Hex(),
+			ActorID:    e.ActorID,
+			ActorName:  e.ActorName,
+			Action:     e.Action,
+			TargetType: e.TargetType,
+			TargetID:   e.TargetID,
+			RequestID:  e.RequestID,
+			Timestamp:  e.Timestamp,
+			Changes:    changes,
+		})
+	}
+	return entries, nil
+}
+
+// normalizeAuditValue turns decoded BSON documents and arrays back into plain maps and slices
+func normalizeAuditValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case primitive.D:
+		m := make(map[string]interface{}, len(v))
+		for _, e := range v {
+			m[e.Key] = normalizeAuditValue(e.Value)
+		}
+		return m
+	case primitive.M:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = normalizeAuditValue(item)
+		}
+		return m
+	case primitive.A:
+		items := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			items = append(items, normalizeAuditValue(item))
+		}
+		return items
+	default:
+		return value
+	}
+}
@@ -12,80 +12,194 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 //go:generate mockgen -source=ev_station_repository.go -destination=../../mocks/mock_ev_repository.go -package=mocks
 type EVStationRepository interface {
-	FindStations(ctx context.Context, company string, stationType string, search string, plugName string, isOpen *bool) ([]models.EVStationDB, error)
+	FindStations(ctx context.Context, company string, stationType string, search string, plugName string) ([]models.EVStationDB, error)
+	StreamStations(ctx context.Context, company string, stationType string, search string, plugName string, fn func(station models.EVStationDB) error) error
 	FindAllStations(ctx context.Context) ([]models.EVStationDB, error)
+	FindStationsUpdatedBetween(ctx context.Context, from *time.Time, to *time.Time, offset int64, limit int64) ([]models.EVStationDB, int64, error)
 	FindStationByID(ctx context.Context, id string) (*models.EVStationDB, error)
 	CreateStation(ctx context.Context, domainModel domainModel.EVStation) error
 	EditStation(ctx context.Context, domainModel domainModel.EVStation) error
-	RemoveStation(ctx context.Context, id string) error
+	UpdateStationFields(ctx context.Context, domainModel domainModel.EVStation, fields []string) error
+	SoftDeleteStation(ctx context.Context, id string, deletedBy string, deletedAt time.Time) error
+	FindDeletedStations(ctx context.Context) ([]models.EVStationDB, error)
+	FindDeletedStationByID(ctx context.Context, id string) (*models.EVStationDB, error)
+	RestoreStation(ctx context.Context, id string) error
+	PurgeDeletedStations(ctx context.Context, deletedBefore time.Time) (int64, error)
 	SetBooking(ctx context.Context, id string, booking models.BookingDB) error
 	FindStationByConnectorID(ctx context.Context, connectorID string) (*models.EVStationDB, error)
 	FindBookingByUserName(ctx context.Context, userName string) (*models.BookingDB, error)
 	FindBookingsByUserName(ctx context.Context, username string) ([]models.BookingDB, error)
 	FindStationByUserName(ctx context.Context, userName string) (*models.EVStationDB, error)
+	MigrateLegacyOpeningHours(ctx context.Context, timeZone string, includeClosed bool) (*OpeningHoursMigrationReport, error)
+	AddMaintenanceWindow(ctx context.Context, stationID string, window domainModel.MaintenanceWindow) error
+	EditMaintenanceWindow(ctx context.Context, stationID string, window domainModel.MaintenanceWindow) error
+	RemoveMaintenanceWindow(ctx context.Context, stationID string, windowID string) error
+	AddConnector(ctx context.Context, stationID string, version int64, connector domainModel.Connector) error
+	EditConnector(ctx context.Context, stationID string, version int64, connector domainModel.Connector) error
+	RemoveConnector(ctx context.Context, stationID string, version int64, connectorID string) error
+	FindStationByExternalRef(ctx context.Context, externalRef string) (*models.EVStationDB, error)
+	FindStationsInArea(ctx context.Context, minLatitude float64, maxLatitude float64, minLongitude float64, maxLongitude float64) ([]models.EVStationDB, error)
+	FindStationRevisions(ctx context.Context, stationID string) ([]models.StationRevisionDB, error)
+	FindStationRevision(ctx context.Context, stationID string, version int64) (*models.StationRevisionDB, error)
+	FindStationRevisionAsOf(ctx context.Context, stationID string, asOf time.Time) (*models.StationRevisionDB, error)
+}
+
+// ErrVersionConflict is returned when a conditional write finds the station at another version
+var ErrVersionConflict = errors.New("station was modified by another request")
+
+// ErrConnectorNotFound is returned by FindStationByConnectorID when no live station has the connector
+var ErrConnectorNotFound = errors.New("connector not found")
+
+// ErrStationNotFound is returned by conditional writes when the station is gone or in the trash
+var ErrStationNotFound = errors.New("station not found")
+
+// OpeningHoursMigrationReport summarises a legacy hours migration run
+type OpeningHoursMigrationReport struct {
+	Migrated int
+	Failed   map[string]string // station ID -> reason
+	Closed   []string          // IDs of stations marked is_open=false, left untouched unless included
 }
 
 type evStationRepository struct {
 	collection *mongo.Collection
+	revisions  *mongo.Collection
 }
 
 func NewEVStationRepository(db *mongo.Database) EVStationRepository {
-	return &evStationRepository{collection: db.Collection("ev_station")}
+	return &evStationRepository{
+		collection: db.Collection("ev_station"),
+		revisions:  db.Collection("station_revisions"),
+	}
 }
 
 func (repo *evStationRepository) FindStations(
+	ctx context.Context,
+	company string,
+	stationType string,
+	search string,
+	plugName string) ([]models.EVStationDB, error) {
+	// ดึงข้อมูล Ens ทั้งหมดที่ตรงกับ filterV Station
+	cursor, err := repo.collection.Find(ctx, stationSearchFilter(company, search))
+	if err != nil {
+		return nil, err
+	}
+
+	var stations []models.EVStationDB
+	if err := cursor.All(ctx, &stations); err != nil {
+		return nil, err
+	}
+
+	for i := range stations {
+		filterStationConnectors(&stations[i], stationType, plugName)
+	}
+
+	return stations, nil
+}
+
+// StreamStations runs the FindStations query but decodes one station at a time from the cursor,
+// so large result sets are never held in memory. It stops at the first error returned by fn.
+func (repo *evStationRepository) StreamStations(
 	ctx context.Context,
 	company string,
 	stationType string,
 	search string,
 	plugName string,
-	isOpen *bool) ([]models.EVStationDB, error) {
-	filter := bson.M{}
-	// กรอง Company และ Search ตามปกติ
-	if company != "" {
-		filter["company"] = company
+	fn func(station models.EVStationDB) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := repo.collection.Find(ctx, stationSearchFilter(company, search), opts)
+	if err != nil {
+		return err
 	}
-	if search != "" {
-		filter["name"] = bson.M{"$regex": search, "$options": "i"}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var station models.EVStationDB
+		if err := cursor.Decode(&station); err != nil {
+			return err
+		}
+		filterStationConnectors(&station, stationType, plugName)
+		if err := fn(station); err != nil {
+			return err
+		}
 	}
+	return cursor.Err()
+}
 
-	if isOpen != nil {
-		filter["status.is_open"] = *isOpen
+// FindStationsUpdatedBetween pages through stations by _id whose last update falls in [from, to);
+// nil bounds are open. Legacy stations without updated_at count as updated when created. Without
+// from only live stations are listed; with it, stations moved to the trash in the window are
+// included too, so a delta tells the reader what was removed.
+func (repo *evStationRepository) FindStationsUpdatedBetween(ctx context.Context, from *time.Time, to *time.Time, offset int64, limit int64) ([]models.EVStationDB, int64, error) {
+	filter := bson.M{}
+	if from == nil {
+		filter = notDeleted(filter)
+	}
+	if from != nil || to != nil {
+		updatedAt := bson.M{}
+		createdAt := bson.M{}
+		if from != nil {
+			updatedAt["$gte"] = *from
+			createdAt["$gte"] = primitive.NewObjectIDFromTimestamp(*from)
+		}
+		if to != nil {
+			updatedAt["$lt"] = *to
+			createdAt["$lt"] = primitive.NewObjectIDFromTimestamp(*to)
+		}
+		filter["$or"] = []bson.M{
+			{"updated_at": updatedAt},
+			{"updated_at": bson.M{"$exists": false}, "_id": createdAt},
+		}
 	}
 
-	// ดึงข้อมูล Ens ทั้งหมดที่ตรงกับ filterV Station
-	cursor, err := repo.collection.Find(ctx, filter)
+	total, err := repo.collection.CountDocuments(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetSkip(offset).SetLimit(limit)
+	cursor, err := repo.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	var stations []models.EVStationDB
 	if err := cursor.All(ctx, &stations); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
+	return stations, total, nil
+}
 
+func stationSearchFilter(company string, search string) bson.M {
+	filter := notDeleted(bson.M{})
+	// กรอง Company และ Search ตามปกติ
+	if company != "" {
+		filter["company"] = company
+	}
+	if search != "" {
+		filter["name"] = bson.M{"$regex": search, "$options": "i"}
+	}
+	return filter
+}
+
+// filterStationConnectors keeps the connectors of the requested type and plug
+func filterStationConnectors(station *models.EVStationDB, stationType string, plugName string) {
 	// map Connectors each Station (type)
 	if stationType != "" {
-		for i := range stations {
-			stations[i].Connectors = filterConnectorsByType(stations[i].Connectors, stationType)
-		}
+		station.Connectors = filterConnectorsByType(station.Connectors, stationType)
 	}
 
 	// map Connectors each Station (plugName)
 	if plugName != "" {
-		for i := range stations {
-			stations[i].Connectors = filterConnectorsByPlugName(stations[i].Connectors, plugName)
-		}
+		station.Connectors = filterConnectorsByPlugName(station.Connectors, plugName)
 	}
-
-	return stations, nil
 }
 
 func (repo *evStationRepository) FindAllStations(ctx context.Context) ([]models.EVStationDB, error) {
-	cursor, err := repo.collection.Find(ctx, bson.M{})
+	cursor, err := repo.collection.Find(ctx, notDeleted(bson.M{}))
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +218,7 @@ func (repo *evStationRepository) FindStationByID(ctx context.Context, id string)
 	}
 
 	var station models.EVStationDB
-	err = repo.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&station)
+	err = repo.collection.FindOne(ctx, notDeleted(bson.M{"_id": objectID})).Decode(&station)
 	if err != nil {
 		return nil, err
 	}
@@ -112,52 +226,236 @@ func (repo *evStationRepository) FindStationByID(ctx context.Context, id string)
 	return &station, nil
 }
 
+// FindStationByExternalRef returns nil without an error when no live station has the reference
+func (repo *evStationRepository) FindStationByExternalRef(ctx context.Context, externalRef string) (*models.EVStationDB, error) {
+	var station models.EVStationDB
+	err := repo.collection.FindOne(ctx, notDeleted(bson.M{"external_ref": externalRef})).Decode(&station)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding station: %v", err)
+	}
+	return &station, nil
+}
+
+// FindStationsInArea returns the live stations whose coordinates fall inside the bounding box
+func (repo *evStationRepository) FindStationsInArea(ctx context.Context, minLatitude float64, maxLatitude float64, minLongitude float64, maxLongitude float64) ([]models.EVStationDB, error) {
+	filter := notDeleted(bson.M{
+		"latitude":  bson.M{"$gte": minLatitude, "$lte": maxLatitude},
+		"longitude": bson.M{"$gte": minLongitude, "$lte": maxLongitude},
+	})
+	cursor, err := repo.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var stations []models.EVStationDB
+	if err := cursor.All(ctx, &stations); err != nil {
+		return nil, err
+	}
+	return stations, nil
+}
+
 func (repo *evStationRepository) CreateStation(ctx context.Context, station domainModel.EVStation) error {
 	dbModel := mapDomainToDBModel(station)
 
-	// ถ้าอยากใช้ Mongo สร้าง ID ก็ไม่ต้อง set เอง
-	// สามารถให้ dbModel.ID เป็นค่าว่าง แล้ว Mongo จะ generate ให้อัตโนมัติ
-	dbModel.ID = primitive.NewObjectID()
+	// ถ้า usecase ไม่ได้กำหนด ID มา ให้สร้างใหม่
+	dbModel.ID = station.ID
+	if dbModel.ID.IsZero() {
+		dbModel.ID = primitive.NewObjectID()
+	}
+	dbModel.Version = 1
+	now := time.Now().UTC()
+	dbModel.UpdatedAt = &now
 
-	_, err := repo.collection.InsertOne(ctx, dbModel)
-	return err
+	if _, err := repo.collection.InsertOne(ctx, dbModel); err != nil {
+		return err
+	}
+	repo.saveRevision(ctx, dbModel)
+	return nil
 }
 
 func (repo *evStationRepository) EditStation(ctx context.Context, station domainModel.EVStation) error {
 	dbModel := mapDomainToDBModel(station)
 	dbModel.ID = station.ID
+	dbModel.Version = station.Version + 1
+
+	update := bson.M{"$set": dbModel}
+	if station.ExternalRef == "" {
+		// omitempty leaves the old reference in place, so clearing it needs an $unset
+		update["$unset"] = bson.M{"external_ref": ""}
+	}
+	matched, err := repo.updateStation(ctx, versionFilter(station.ID, station.Version), update)
 
-	result, err := repo.collection.UpdateOne(
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return repo.conditionalWriteMiss(ctx, station.ID, station.Version, ErrStationNotFound)
+	}
+	return nil
+}
+
+// versionFilter matches the station only while it is still at the given version.
+// Documents written before versioning have no version field and count as version 0.
+func versionFilter(id primitive.ObjectID, version int64) bson.M {
+	if version == 0 {
+		return notDeleted(bson.M{"_id": id, "$or": bson.A{bson.M{"version": 0}, bson.M{"version": bson.M{"$exists": false}}}})
+	}
+	return notDeleted(bson.M{"_id": id, "version": version})
+}
+
+// notDeleted restricts a filter to stations that are not in the trash
+func notDeleted(filter bson.M) bson.M {
+	filter["deleted_at"] = bson.M{"$exists": false}
+	return filter
+}
+
+// conditionalWriteMiss explains why a versioned update matched nothing
+func (repo *evStationRepository) conditionalWriteMiss(ctx context.Context, id primitive.ObjectID, version int64, fallback error) error {
+	var current models.EVStationDB
+	if err := repo.collection.FindOne(ctx, notDeleted(bson.M{"_id": id})).Decode(&current); err != nil {
+		return ErrStationNotFound
+	}
+	if current.Version != version {
+		return ErrVersionConflict
+	}
+	return fallback
+}
+
+// UpdateStationFields writes only the given top-level fields (bson names) of the station
+func (repo *evStationRepository) UpdateStationFields(ctx context.Context, station domainModel.EVStation, fields []string) error {
+	raw, err := bson.Marshal(mapDomainToDBModel(station))
+	if err != nil {
+		return err
+	}
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+
+	set := bson.M{}
+	unset := bson.M{}
+	for _, field := range fields {
+		value, ok := doc[field]
+		switch {
+		case ok:
+			set[field] = value
+		case field == "external_ref":
+			// an empty reference is omitted from the document and removed from the station
+			unset[field] = ""
+		default:
+			return fmt.Errorf("unknown station field %s", field)
+		}
+	}
+	set["version"] = station.Version + 1
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	matched, err := repo.updateStation(ctx, versionFilter(station.ID, station.Version), update)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return repo.conditionalWriteMiss(ctx, station.ID, station.Version, ErrStationNotFound)
+	}
+	return nil
+}
+
+// SoftDeleteStation moves the station to the trash; it stays restorable until purged
+func (repo *evStationRepository) SoftDeleteStation(ctx context.Context, id string, deletedBy string, deletedAt time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	matched, err := repo.updateStation(
 		ctx,
-		bson.M{"_id": station.ID},
-		bson.M{"$set": dbModel},
+		notDeleted(bson.M{"_id": objectID}),
+		bson.M{
+			"$set": bson.M{"deleted_at": deletedAt, "deleted_by": deletedBy},
+			"$inc": bson.M{"version": 1},
+		},
 	)
-
 	if err != nil {
 		return err
 	}
-	if result.MatchedCount == 0 {
+	if !matched {
 		return errors.New("station not found")
 	}
 	return nil
 }
 
-func (repo *evStationRepository) RemoveStation(ctx context.Context, id string) error {
+func (repo *evStationRepository) FindDeletedStations(ctx context.Context) ([]models.EVStationDB, error) {
+	cursor, err := repo.collection.Find(
+		ctx,
+		bson.M{"deleted_at": bson.M{"$exists": true}},
+		options.Find().SetSort(bson.M{"deleted_at": -1}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deleted stations: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	var stations []models.EVStationDB
+	if err := cursor.All(ctx, &stations); err != nil {
+		return nil, fmt.Errorf("error decoding stations: %v", err)
+	}
+	return stations, nil
+}
+
+func (repo *evStationRepository) FindDeletedStationByID(ctx context.Context, id string) (*models.EVStationDB, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var station models.EVStationDB
+	err = repo.collection.FindOne(ctx, bson.M{"_id": objectID, "deleted_at": bson.M{"$exists": true}}).Decode(&station)
+	if err != nil {
+		return nil, err
+	}
+
+	return &station, nil
+}
+
+func (repo *evStationRepository) RestoreStation(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
 
-	result, err := repo.collection.DeleteOne(ctx, bson.M{"_id": objectID})
-	if result.DeletedCount == 0 {
-		return errors.New("station not found")
+	matched, err := repo.updateStation(
+		ctx,
+		bson.M{"_id": objectID, "deleted_at": bson.M{"$exists": true}},
+		bson.M{
+			"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+			"$inc":   bson.M{"version": 1},
+		},
+	)
+	if err != nil {
+		return err
 	}
+	if !matched {
+		return errors.New("station not found in trash")
+	}
+	return nil
+}
 
-	return err
+// PurgeDeletedStations hard-deletes stations that were trashed before the cutoff
+func (repo *evStationRepository) PurgeDeletedStations(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result, err := repo.collection.DeleteMany(ctx, bson.M{"deleted_at": bson.M{"$lt": deletedBefore}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
 }
 
 func (repo *evStationRepository) SetBooking(ctx context.Context, connector_id string, booking models.BookingDB) error {
-	cursor, err := repo.collection.Find(ctx, bson.M{})
+	cursor, err := repo.collection.Find(ctx, notDeleted(bson.M{}))
 	if err != nil {
 		return fmt.Errorf("failed to find stations: %v", err)
 	}
@@ -175,25 +473,26 @@ func (repo *evStationRepository) SetBooking(ctx context.Context, connector_id st
 				// Found the connector, update booking
 				station.Connectors[i].Booking = &booking
 
-				filter := bson.M{
+				filter := notDeleted(bson.M{
 					"_id":                     station.ID,
 					"connectors.connector_id": connector_id,
-				}
+				})
 
 				update := bson.M{
 					"$set": bson.M{
 						"connectors.$.booking": booking,
 					},
+					"$inc": bson.M{"version": 1},
 				}
 
-				// Update the station in the database
-				result, err := repo.collection.UpdateOne(ctx, filter, update)
+				// Update the station in the database; bookings are live state and get no revision
+				updated, err := repo.applyStationUpdate(ctx, filter, update)
 				if err != nil {
 					return fmt.Errorf("failed to update booking: %v", err)
 				}
 
 				// Check if the connector was matched and updated
-				if result.MatchedCount == 0 {
+				if updated == nil {
 					return fmt.Errorf("no matching connector found to update booking")
 				}
 
@@ -243,7 +542,7 @@ func (repo *evStationRepository) SetBooking(ctx context.Context, connector_id st
 // }
 
 func (repo *evStationRepository) FindBookingByUserName(ctx context.Context, userName string) (*models.BookingDB, error) {
-	filter := bson.M{"connectors.booking.username": userName}
+	filter := notDeleted(bson.M{"connectors.booking.username": userName})
 
 	var station models.EVStationDB
 	err := repo.collection.FindOne(ctx, filter).Decode(&station)
@@ -265,7 +564,7 @@ func (repo *evStationRepository) FindBookingByUserName(ctx context.Context, user
 
 func (repo *evStationRepository) FindBookingsByUserName(ctx context.Context, username string) ([]models.BookingDB, error) {
 	// Filter: Find all stations with any connector having a booking of this username
-	filter := bson.M{"connectors.booking.username": username}
+	filter := notDeleted(bson.M{"connectors.booking.username": username})
 
 	cursor, err := repo.collection.Find(ctx, filter)
 	if err != nil {
@@ -300,15 +599,15 @@ func (repo *evStationRepository) FindBookingsByUserName(ctx context.Context, use
 }
 
 func (repo *evStationRepository) FindStationByConnectorID(ctx context.Context, connectorID string) (*models.EVStationDB, error) {
-	filter := bson.M{
+	filter := notDeleted(bson.M{
 		"connectors.connector_id": connectorID,
-	}
+	})
 
 	var station models.EVStationDB
 	err := repo.collection.FindOne(ctx, filter).Decode(&station)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("no station found with connector id %s", connectorID)
+			return nil, fmt.Errorf("%w: no station found with connector id %s", ErrConnectorNotFound, connectorID)
 		}
 		return nil, fmt.Errorf("error finding station: %v", err)
 	}
@@ -335,7 +634,7 @@ func (repo *evStationRepository) FindStationByConnectorID(ctx context.Context, c
 // }
 
 func (repo *evStationRepository) FindStationByUserName(ctx context.Context, userName string) (*models.EVStationDB, error) {
-	filter := bson.M{"connectors.booking.username": userName}
+	filter := notDeleted(bson.M{"connectors.booking.username": userName})
 
 	var station models.EVStationDB
 	err := repo.collection.FindOne(ctx, filter).Decode(&station)
@@ -358,6 +657,201 @@ func (repo *evStationRepository) FindStationByUserName(ctx context.Context, user
 	return &station, nil
 }
 
+func (repo *evStationRepository) AddMaintenanceWindow(ctx context.Context, stationID string, window domainModel.MaintenanceWindow) error {
+	objectID, err := primitive.ObjectIDFromHex(stationID)
+	if err != nil {
+		return err
+	}
+
+	matched, err := repo.updateStation(
+		ctx,
+		notDeleted(bson.M{"_id": objectID}),
+		bson.M{
+			"$push": bson.M{"maintenance_windows": mapMaintenanceWindowDomainToDB(window)},
+			"$inc":  bson.M{"version": 1},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return errors.New("station not found")
+	}
+	return nil
+}
+
+func (repo *evStationRepository) EditMaintenanceWindow(ctx context.Context, stationID string, window domainModel.MaintenanceWindow) error {
+	objectID, err := primitive.ObjectIDFromHex(stationID)
+	if err != nil {
+		return err
+	}
+
+	matched, err := repo.updateStation(
+		ctx,
+		notDeleted(bson.M{"_id": objectID, "maintenance_windows.id": window.ID}),
+		bson.M{
+			"$set": bson.M{"maintenance_windows.$": mapMaintenanceWindowDomainToDB(window)},
+			"$inc": bson.M{"version": 1},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return errors.New("maintenance window not found")
+	}
+	return nil
+}
+
+func (repo *evStationRepository) RemoveMaintenanceWindow(ctx context.Context, stationID string, windowID string) error {
+	objectID, err := primitive.ObjectIDFromHex(stationID)
+	if err != nil {
+		return err
+	}
+
+	matched, err := repo.updateStation(
+		ctx,
+		notDeleted(bson.M{"_id": objectID, "maintenance_windows.id": windowID}),
+		bson.M{
+			"$pull": bson.M{"maintenance_windows": bson.M{"id": windowID}},
+			"$inc":  bson.M{"version": 1},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return errors.New("maintenance window not found")
+	}
+	return nil
+}
+
+func (repo *evStationRepository) AddConnector(ctx context.Context, stationID string, version int64, connector domainModel.Connector) error {
+	objectID, err := primitive.ObjectIDFromHex(stationID)
+	if err != nil {
+		return err
+	}
+
+	matched, err := repo.updateStation(
+		ctx,
+		versionFilter(objectID, version),
+		bson.M{
+			"$push": bson.M{"connectors": mapConnectorsDomainToDB([]domainModel.Connector{connector})[0]},
+			"$set":  bson.M{"version": version + 1},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return repo.conditionalWriteMiss(ctx, objectID, version, ErrStationNotFound)
+	}
+	return nil
+}
+
+// EditConnector updates the connector specification in place; its ID and booking are left untouched
+func (repo *evStationRepository) EditConnector(ctx context.Context, stationID string, version int64, connector domainModel.Connector) error {
+	objectID, err := primitive.ObjectIDFromHex(stationID)
+	if err != nil {
+		return err
+	}
+
+	filter := versionFilter(objectID, version)
+	filter["connectors.connector_id"] = connector.ConnectorID
+	matched, err := repo.updateStation(
+		ctx,
+		filter,
+		bson.M{"$set": bson.M{
+			"connectors.$.type":           connector.Type,
+			"connectors.$.plug_name":      connector.PlugName,
+			"connectors.$.price_per_unit": connector.PricePerUnit,
+			"connectors.$.power_output":   connector.PowerOutput,
+			"version":                     version + 1,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return repo.conditionalWriteMiss(ctx, objectID, version, errors.New("connector not found"))
+	}
+	return nil
+}
+
+func (repo *evStationRepository) RemoveConnector(ctx context.Context, stationID string, version int64, connectorID string) error {
+	objectID, err := primitive.ObjectIDFromHex(stationID)
+	if err != nil {
+		return err
+	}
+
+	filter := versionFilter(objectID, version)
+	filter["connectors.connector_id"] = connectorID
+	matched, err := repo.updateStation(
+		ctx,
+		filter,
+		bson.M{
+			"$pull": bson.M{"connectors": bson.M{"connector_id": connectorID}},
+			"$set":  bson.M{"version": version + 1},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return repo.conditionalWriteMiss(ctx, objectID, version, errors.New("connector not found"))
+	}
+	return nil
+}
+
+// MigrateLegacyOpeningHours converts free-text open/close hours into structured schedules
+// and drops the legacy fields. Stations whose hours cannot be parsed are left untouched. A
+// station an operator marked closed (is_open=false) would reopen on its schedule, so it is only
+// reported unless includeClosed is set.
+func (repo *evStationRepository) MigrateLegacyOpeningHours(ctx context.Context, timeZone string, includeClosed bool) (*OpeningHoursMigrationReport, error) {
+	cursor, err := repo.collection.Find(ctx, bson.M{"status.schedule": bson.M{"$exists": false}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stations: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	report := &OpeningHoursMigrationReport{Failed: map[string]string{}}
+	for cursor.Next(ctx) {
+		var station models.EVStationDB
+		if err := cursor.Decode(&station); err != nil {
+			return report, fmt.Errorf("error decoding station: %v", err)
+		}
+		// is_open is omitted when false on decode, so look at the stored document
+		if isOpen, ok := cursor.Current.Lookup("status", "is_open").BooleanOK(); ok && !isOpen {
+			report.Closed = append(report.Closed, station.ID.Hex())
+			if !includeClosed {
+				continue
+			}
+		}
+
+		hours, err := domainModel.LegacyOpeningHours(station.Status.OpenHours, station.Status.CloseHours, timeZone)
+		if err != nil {
+			report.Failed[station.ID.Hex()] = err.Error()
+			continue
+		}
+
+		update := bson.M{
+			"$set":   bson.M{"status.schedule": mapOpeningHoursDomainToDB(hours)},
+			"$unset": bson.M{"status.open_hours": "", "status.close_hours": "", "status.is_open": ""},
+			"$inc":   bson.M{"version": 1},
+		}
+		if _, err := repo.updateStation(ctx, bson.M{"_id": station.ID}, update); err != nil {
+			report.Failed[station.ID.Hex()] = err.Error()
+			continue
+		}
+		report.Migrated++
+	}
+
+	if err := cursor.Err(); err != nil {
+		return report, fmt.Errorf("cursor error: %v", err)
+	}
+	return report, nil
+}
+
 // 🔍 Utility Function - Filter Connectors by Type
 func filterConnectorsByType(connectors []models.ConnectorDB, stationType string) []models.ConnectorDB {
 	var filtered []models.ConnectorDB
@@ -408,17 +902,72 @@ func isBookingExpired(bookingEndTime string) bool {
 func mapDomainToDBModel(station domainModel.EVStation) models.EVStationDB {
 	return models.EVStationDB{
 		// ID:    primitive.ObjectID{}, // ถ้าใส่ว่างแล้วให้ Mongo gen
-		Name:      station.Name,
-		Latitude:  station.Latitude,
-		Longitude: station.Longitude,
-		Company:   station.Company,
+		ExternalRef: station.ExternalRef,
+		Name:        station.Name,
+		Latitude:    station.Latitude,
+		Longitude:   station.Longitude,
+		Company:     station.Company,
 		Status: models.StationStatusDB{
-			OpenHours:  station.Status.OpenHours,
-			CloseHours: station.Status.CloseHours,
-			IsOpen:     station.Status.IsOpen,
+			Schedule: mapOpeningHoursDomainToDB(station.Status.Schedule),
 		},
-		Connectors: mapConnectorsDomainToDB(station.Connectors),
+		Connectors:         mapConnectorsDomainToDB(station.Connectors),
+		MaintenanceWindows: mapMaintenanceWindowsDomainToDB(station.MaintenanceWindows),
+		Version:            station.Version,
+	}
+}
+
+func mapMaintenanceWindowsDomainToDB(windows []domainModel.MaintenanceWindow) []models.MaintenanceWindowDB {
+	if len(windows) == 0 {
+		return nil
+	}
+	result := make([]models.MaintenanceWindowDB, 0, len(windows))
+	for _, w := range windows {
+		result = append(result, mapMaintenanceWindowDomainToDB(w))
+	}
+	return result
+}
+
+func mapMaintenanceWindowDomainToDB(window domainModel.MaintenanceWindow) models.MaintenanceWindowDB {
+	return models.MaintenanceWindowDB{
+		ID:          window.ID,
+		Scope:       window.Scope,
+		ConnectorID: window.ConnectorID,
+		StartTime:   window.StartTime,
+		EndTime:     window.EndTime,
+		Reason:      window.Reason,
+		CreatedBy:   window.CreatedBy,
+	}
+}
+
+func mapOpeningHoursDomainToDB(hours domainModel.OpeningHours) *models.OpeningHoursDB {
+	schedule := &models.OpeningHoursDB{
+		TimeZone:   hours.TimeZone,
+		AlwaysOpen: hours.AlwaysOpen,
+		Weekly:     make([]models.DayScheduleDB, 0, len(hours.Weekly)),
+	}
+	for _, day := range hours.Weekly {
+		schedule.Weekly = append(schedule.Weekly, models.DayScheduleDB{
+			Day:       day.Day,
+			Intervals: mapIntervalsDomainToDB(day.Intervals),
+		})
+	}
+	for _, ex := range hours.Exceptions {
+		schedule.Exceptions = append(schedule.Exceptions, models.ScheduleExceptionDB{
+			Date:      ex.Date,
+			Closed:    ex.Closed,
+			Intervals: mapIntervalsDomainToDB(ex.Intervals),
+			Reason:    ex.Reason,
+		})
+	}
+	return schedule
+}
+
+func mapIntervalsDomainToDB(intervals []domainModel.TimeInterval) []models.TimeIntervalDB {
+	result := make([]models.TimeIntervalDB, 0, len(intervals))
+	for _, iv := range intervals {
+		result = append(result, models.TimeIntervalDB{Open: iv.Open, Close: iv.Close})
 	}
+	return result
 }
 
 func mapConnectorsDomainToDB(conns []domainModel.Connector) []models.ConnectorDB {
@@ -428,7 +977,7 @@ func mapConnectorsDomainToDB(conns []domainModel.Connector) []models.ConnectorDB
 		if c.Booking != nil {
 			booking = &models.BookingDB{
 				Username:       c.Booking.Username,
-				BookingEndTime: c.Booking.BookingEndTime.Format(time.RFC3339), // ✅ แปลงเป็น string
+				BookingEndTime: c.Booking.BookingEndTime.Format(constants.DateTimeLayout), // ✅ same layout as SetBooking
 			}
 		}
 
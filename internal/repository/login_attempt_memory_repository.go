@@ -0,0 +1,103 @@
+package repository
+
+import (
+	domainModels "Ev-Charge-Hub/Server/internal/domain/models"
+	"context"
+	"sync"
+	"time"
+)
+
+// memorySweepInterval is how often expired counters are dropped from memory
+const memorySweepInterval = time.Minute
+
+type memoryLoginAttempt struct {
+	attempt   domainModels.LoginAttempt
+	expiresAt time.Time
+}
+
+type memoryLoginAttemptRepository struct {
+	mu        sync.Mutex
+	attempts  map[string]*memoryLoginAttempt
+	lastSweep time.Time
+}
+
+// NewMemoryLoginAttemptRepository keeps the counters in process; each instance counts on its own
+func NewMemoryLoginAttemptRepository() LoginAttemptRepository {
+	return &memoryLoginAttemptRepository{attempts: map[string]*memoryLoginAttempt{}}
+}
+
+func (repo *memoryLoginAttemptRepository) FindLoginAttempt(ctx context.Context, key string) (*domainModels.LoginAttempt, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	stored, ok := repo.attempts[key]
+	if !ok || !time.Now().Before(stored.expiresAt) {
+		return nil, nil
+	}
+	attempt := stored.attempt
+	return &attempt, nil
+}
+
+func (repo *memoryLoginAttemptRepository) IncrementLoginFailures(ctx context.Context, key string, now time.Time, window time.Duration) (*domainModels.LoginAttempt, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	repo.sweep(now)
+
+	stored, ok := repo.attempts[key]
+	if !ok {
+		stored = &memoryLoginAttempt{attempt: domainModels.LoginAttempt{Key: key}}
+		repo.attempts[key] = stored
+	}
+	if stored.attempt.LastFailureAt.Before(now.Add(-window)) {
+		stored.attempt.Failures = 0
+	}
+	stored.attempt.Failures++
+	stored.attempt.LastFailureAt = now
+	stored.expiresAt = laterOf(stored.expiresAt, now.Add(window))
+
+	attempt := stored.attempt
+	return &attempt, nil
+}
+
+func (repo *memoryLoginAttemptRepository) LockLogin(ctx context.Context, key string, until time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	stored, ok := repo.attempts[key]
+	if !ok {
+		stored = &memoryLoginAttempt{attempt: domainModels.LoginAttempt{Key: key}}
+		repo.attempts[key] = stored
+	}
+	stored.attempt.LockedUntil = &until
+	stored.expiresAt = laterOf(stored.expiresAt, until)
+	return nil
+}
+
+func (repo *memoryLoginAttemptRepository) ClearLoginAttempts(ctx context.Context, key string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	delete(repo.attempts, key)
+	return nil
+}
+
+func (repo *memoryLoginAttemptRepository) EnsureLoginAttemptIndexes(ctx context.Context) error {
+	return nil
+}
+
+// sweep drops expired counters so addresses seen once do not stay in memory
+func (repo *memoryLoginAttemptRepository) sweep(now time.Time) {
+	if now.Sub(repo.lastSweep) < memorySweepInterval {
+		return
+	}
+	repo.lastSweep = now
+	for key, stored := range repo.attempts {
+		if !now.Before(stored.expiresAt) {
+			delete(repo.attempts, key)
+		}
+	}
+}
+
+func laterOf(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	domainModels "Ev-Charge-Hub/Server/internal/domain/models"
+	repoModels "Ev-Charge-Hub/Server/internal/repository/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//go:generate mockgen -source=login_attempt_repository.go -destination=../mocks/mock_login_attempt_repository.go -package=mocks
+
+// LoginAttemptRepository stores failed login counters; NewLoginAttemptRepository shares them
+// between instances through Mongo, NewMemoryLoginAttemptRepository keeps them in process
+type LoginAttemptRepository interface {
+	FindLoginAttempt(ctx context.Context, key string) (*domainModels.LoginAttempt, error)
+	// IncrementLoginFailures adds a failure, starting again from one when the last failure is older than window
+	IncrementLoginFailures(ctx context.Context, key string, now time.Time, window time.Duration) (*domainModels.LoginAttempt, error)
+	LockLogin(ctx context.Context, key string, until time.Time) error
+	ClearLoginAttempts(ctx context.Context, key string) error
+	EnsureLoginAttemptIndexes(ctx context.Context) error
+}
+
+type loginAttemptRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLoginAttemptRepository(db *mongo.Database) LoginAttemptRepository {
+	return &loginAttemptRepository{collection: db.Collection("login_attempts")}
+}
+
+// FindLoginAttempt returns nil without an error when the key has no recorded failures
+func (repo *loginAttemptRepository) FindLoginAttempt(ctx context.Context, key string) (*domainModels.LoginAttempt, error) {
+	var attemptDB repoModels.LoginAttemptDB
+	err := repo.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&attemptDB)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding login attempts: %v", err)
+	}
+	return mapLoginAttemptDBToDomain(attemptDB), nil
+}
+
+// IncrementLoginFailures counts in one update, so concurrent failures from several instances are all counted
+func (repo *loginAttemptRepository) IncrementLoginFailures(ctx context.Context, key string, now time.Time, window time.Duration) (*domainModels.LoginAttempt, error) {
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"failures": bson.M{"$cond": bson.A{
+			bson.M{"$lt": bson.A{"$last_failure_at", now.Add(-window)}},
+			1,
+			bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$failures", 0}}, 1}},
+		}},
+		"last_failure_at": now,
+		"expires_at":      bson.M{"$max": bson.A{"$locked_until", now.Add(window)}},
+	}}}}
+
+	var attemptDB repoModels.LoginAttemptDB
+	err := repo.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).Decode(&attemptDB)
+	if err != nil {
+		return nil, fmt.Errorf("error counting login failure: %v", err)
+	}
+	return mapLoginAttemptDBToDomain(attemptDB), nil
+}
+
+func (repo *loginAttemptRepository) LockLogin(ctx context.Context, key string, until time.Time) error {
+	_, err := repo.collection.UpdateOne(ctx, bson.M{"_id": key}, mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"locked_until": until,
+		"expires_at":   bson.M{"$max": bson.A{"$expires_at", until}},
+	}}}}, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("error locking login: %v", err)
+	}
+	return nil
+}
+
+func (repo *loginAttemptRepository) ClearLoginAttempts(ctx context.Context, key string) error {
+	if _, err := repo.collection.DeleteOne(ctx, bson.M{"_id": key}); err != nil {
+		return fmt.Errorf("error clearing login attempts: %v", err)
+	}
+	return nil
+}
+
+// EnsureLoginAttemptIndexes lets Mongo drop counters once they have expired
+func (repo *loginAttemptRepository) EnsureLoginAttemptIndexes(ctx context.Context) error {
+	_, err := repo.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating login attempt indexes: %v", err)
+	}
+	return nil
+}
+
+func mapLoginAttemptDBToDomain(attemptDB repoModels.LoginAttemptDB) *domainModels.LoginAttempt {
+	return &domainModels.LoginAttempt{
+		Key:           attemptDB.Key,
+		Failures:      attemptDB.Failures,
+		LastFailureAt: attemptDB.LastFailureAt,
+		LockedUntil:   attemptDB.LockedUntil,
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditEntryDB is a document of the append-only audit_logs collection
+type AuditEntryDB struct {
+	ID         primitive.ObjectID    `bson:"_id,omitempty"`
+	ActorID    string                `bson:"actor_id"`
+	ActorName  string                `bson:"actor_name"`
+	Action     constants.AuditAction `bson:"action"`
+	TargetType string                `bson:"target_type"`
+	TargetID   string                `bson:"target_id"`
+	RequestID  string                `bson:"request_id,omitempty"`
+	Timestamp  time.Time             `bson:"timestamp"`
+	Changes    []AuditChangeDB       `bson:"changes"`
+}
+
+// AuditChangeDB holds the before/after value of one changed field
+type AuditChangeDB struct {
+	Field  string      `bson:"field"`
+	Before interface{} `bson:"before"`
+	After  interface{} `bson:"after"`
+}
@@ -2,25 +2,64 @@ package models
 
 import (
 	"Ev-Charge-Hub/Server/internal/constants"
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // EVStationDB represents the core domain model for EV Stations
 type EVStationDB struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty"`
-	Name       string             `bson:"name"`
-	Latitude   float64            `bson:"latitude"`
-	Longitude  float64            `bson:"longitude"`
-	Company    string             `bson:"company"`
-	Status     StationStatusDB    `bson:"status"`
-	Connectors []ConnectorDB      `bson:"connectors"`
+	ID                 primitive.ObjectID    `bson:"_id,omitempty"`
+	ExternalRef        string                `bson:"external_ref,omitempty"` // operator's own ID, used to upsert imports
+	Name               string                `bson:"name"`
+	Latitude           float64               `bson:"latitude"`
+	Longitude          float64               `bson:"longitude"`
+	Company            string                `bson:"company"`
+	Status             StationStatusDB       `bson:"status"`
+	Connectors         []ConnectorDB         `bson:"connectors"`
+	MaintenanceWindows []MaintenanceWindowDB `bson:"maintenance_windows,omitempty"`
+	Version            int64                 `bson:"version"`              // bumped on every write; missing on legacy documents
+	UpdatedAt          *time.Time            `bson:"updated_at,omitempty"` // set on every write; missing on legacy documents
+	DeletedAt          *time.Time            `bson:"deleted_at,omitempty"`
+	DeletedBy          string                `bson:"deleted_by,omitempty"`
 }
 
 // StationStatusDB represents the status details of an EV Station
 type StationStatusDB struct {
-	OpenHours  string `bson:"open_hours"`
-	CloseHours string `bson:"close_hours"`
-	IsOpen     bool   `bson:"is_open"`
+	Schedule *OpeningHoursDB `bson:"schedule,omitempty"`
+
+	// Legacy free-text hours, only read from documents that have not been migrated yet
+	OpenHours  string `bson:"open_hours,omitempty"`
+	CloseHours string `bson:"close_hours,omitempty"`
+	IsOpen     bool   `bson:"is_open,omitempty"`
+}
+
+// OpeningHoursDB represents a weekly schedule in an IANA time zone
+type OpeningHoursDB struct {
+	TimeZone   string                `bson:"time_zone"`
+	AlwaysOpen bool                  `bson:"always_open"`
+	Weekly     []DayScheduleDB       `bson:"weekly"`
+	Exceptions []ScheduleExceptionDB `bson:"exceptions,omitempty"`
+}
+
+// DayScheduleDB represents the opening intervals of one weekday
+type DayScheduleDB struct {
+	Day       string           `bson:"day"`
+	Intervals []TimeIntervalDB `bson:"intervals"`
+}
+
+// TimeIntervalDB represents an "HH:MM" opening interval
+type TimeIntervalDB struct {
+	Open  string `bson:"open"`
+	Close string `bson:"close"`
+}
+
+// ScheduleExceptionDB overrides the weekly schedule on a specific date
+type ScheduleExceptionDB struct {
+	Date      string           `bson:"date"`
+	Closed    bool             `bson:"closed"`
+	Intervals []TimeIntervalDB `bson:"intervals,omitempty"`
+	Reason    string           `bson:"reason,omitempty"`
 }
 
 // ConnectorDB represents a charging connector within an EV Station
@@ -38,3 +77,14 @@ type BookingDB struct {
 	Username       string `bson:"username"`
 	BookingEndTime string `bson:"booking_end_time"`
 }
+
+// MaintenanceWindowDB represents a planned out-of-service period for a station or connector
+type MaintenanceWindowDB struct {
+	ID          string                     `bson:"id"`
+	Scope       constants.MaintenanceScope `bson:"scope"`
+	ConnectorID string                     `bson:"connector_id,omitempty"`
+	StartTime   time.Time                  `bson:"start_time"`
+	EndTime     time.Time                  `bson:"end_time"`
+	Reason      string                     `bson:"reason"`
+	CreatedBy   string                     `bson:"created_by"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type LoginAttemptDB struct {
+	Key           string     `bson:"_id"`
+	Failures      int        `bson:"failures"`
+	LastFailureAt time.Time  `bson:"last_failure_at"`
+	LockedUntil   *time.Time `bson:"locked_until,omitempty"`
+	ExpiresAt     time.Time  `bson:"expires_at"` // TTL index; the document is dropped once neither failures nor lock matter
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OCPIPartnerDB struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Name        string             `bson:"name"`
+	Status      string             `bson:"status"`
+	TokenAHash  string             `bson:"token_a_hash,omitempty"`
+	TokenCHash  string             `bson:"token_c_hash,omitempty"`
+	TokenB      string             `bson:"token_b,omitempty"`
+	VersionsURL string             `bson:"versions_url,omitempty"`
+	Version     string             `bson:"version,omitempty"`
+	Endpoints   []OCPIEndpointDB   `bson:"endpoints,omitempty"`
+	Roles       []OCPIRoleDB       `bson:"roles,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at"`
+}
+
+type OCPIEndpointDB struct {
+	Identifier string `bson:"identifier"`
+	Role       string `bson:"role"`
+	URL        string `bson:"url"`
+}
+
+type OCPIRoleDB struct {
+	Role         string `bson:"role"`
+	CountryCode  string `bson:"country_code"`
+	PartyID      string `bson:"party_id"`
+	BusinessName string `bson:"business_name"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StationRevisionDB is a full copy of a station as it was stored at one version
+type StationRevisionDB struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	StationID  primitive.ObjectID `bson:"station_id"`
+	Version    int64              `bson:"version"`
+	RecordedAt time.Time          `bson:"recorded_at"`
+	RecordedBy string             `bson:"recorded_by"`
+	RequestID  string             `bson:"request_id,omitempty"`
+	Station    EVStationDB        `bson:"station"`
+}
@@ -6,14 +6,32 @@ import (
 )
 
 type UserDB struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty"`
-	Username  string             `bson:"username"`
-	Email     string             `bson:"email"`
-	Password  string             `bson:"password"`
-	Role      string             `bson:"role"`
-	CreatedAt time.Time          `bson:"created_at"`
-	UpdatedAt time.Time          `bson:"updated_at"`
+	ID              primitive.ObjectID `bson:"_id,omitempty"`
+	Username        string             `bson:"username"`
+	Email           string             `bson:"email"`
+	Password        string             `bson:"password"`
+	Role            string             `bson:"role"`
+	CreatedBy       string             `bson:"created_by,omitempty"`
+	EmailVerified   bool               `bson:"email_verified"`
+	EmailVerifiedAt *time.Time         `bson:"email_verified_at,omitempty"`
+	// only the SHA-256 hash of the newest verification token; removed once the email is verified
+	EmailVerificationTokenHash string     `bson:"email_verification_token_hash,omitempty"`
+	EmailVerificationExpiresAt *time.Time `bson:"email_verification_expires_at,omitempty"`
+	TOTPEnabled                bool       `bson:"totp_enabled,omitempty"`
+	TOTPSecret                 string     `bson:"totp_secret,omitempty"`
+	TOTPPendingSecret          string     `bson:"totp_pending_secret,omitempty"`
+	// SHA-256 hashes of the unused recovery codes
+	TOTPRecoveryCodeHashes []string `bson:"totp_recovery_code_hashes,omitempty"`
+	// SHA-256 hash of the code mailed for an enrollment started at login
+	TOTPEnrollmentCodeHash string `bson:"totp_enrollment_code_hash,omitempty"`
+	// last time step a code was accepted for, so a code works once
+	TOTPLastStep int64 `bson:"totp_last_step,omitempty"`
+	// only the SHA-256 hash of the pending login challenge
+	TwoFactorChallengeHash      string     `bson:"two_factor_challenge_hash,omitempty"`
+	TwoFactorChallengeExpiresAt *time.Time `bson:"two_factor_challenge_expires_at,omitempty"`
+	CreatedAt                   time.Time  `bson:"created_at"`
+	UpdatedAt                   time.Time  `bson:"updated_at"`
 }
 
 // json: key name when Post to client(ถูกแปลงเป็น JSON)
-// bson: key name when Get from DB(ถูกใช้เก็บหรือดึงข้อมูลจาก MongoDB) bson:<key name of mongoDB>
\ No newline at end of file
+// bson: key name when Get from DB(ถูกใช้เก็บหรือดึงข้อมูลจาก MongoDB) bson:<key name of mongoDB>
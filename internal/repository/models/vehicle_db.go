@@ -0,0 +1,23 @@
+package models
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VehicleDB represents a vehicle profile owned by a user
+type VehicleDB struct {
+	ID             primitive.ObjectID   `bson:"_id,omitempty"`
+	UserID         string               `bson:"user_id"`
+	CatalogID      string               `bson:"catalog_id,omitempty"`
+	Make           string               `bson:"make"`
+	Model          string               `bson:"model"`
+	BatteryKWh     float64              `bson:"battery_kwh"`
+	SupportedPlugs []constants.PlugName `bson:"supported_plugs"`
+	MaxACPowerKW   float64              `bson:"max_ac_power_kw"`
+	MaxDCPowerKW   float64              `bson:"max_dc_power_kw"`
+	CreatedAt      time.Time            `bson:"created_at"`
+	UpdatedAt      time.Time            `bson:"updated_at"`
+}
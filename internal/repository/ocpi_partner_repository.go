@@ -0,0 +1,160 @@
+package repository
+
+import (
+	domainModels "Ev-Charge-Hub/Server/internal/domain/models"
+	repoModels "Ev-Charge-Hub/Server/internal/repository/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//go:generate mockgen -source=ocpi_partner_repository.go -destination=../mocks/mock_ocpi_partner_repository.go -package=mocks
+type OCPIPartnerRepository interface {
+	CreatePartner(ctx context.Context, partner *domainModels.OCPIPartner) error
+	FindPartners(ctx context.Context) ([]domainModels.OCPIPartner, error)
+	FindPartnerByID(ctx context.Context, id string) (*domainModels.OCPIPartner, error)
+	FindPartnerByTokenHash(ctx context.Context, tokenHash string) (*domainModels.OCPIPartner, error)
+	UpdatePartner(ctx context.Context, partner *domainModels.OCPIPartner) error
+}
+
+type ocpiPartnerRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOCPIPartnerRepository(db *mongo.Database) OCPIPartnerRepository {
+	return &ocpiPartnerRepository{collection: db.Collection("ocpi_partners")}
+}
+
+func (repo *ocpiPartnerRepository) CreatePartner(ctx context.Context, partner *domainModels.OCPIPartner) error {
+	partnerDB := mapOCPIPartnerDomainToDB(partner)
+	partnerDB.ID = primitive.NewObjectID()
+
+	if _, err := repo.collection.InsertOne(ctx, partnerDB); err != nil {
+		return err
+	}
+	partner.ID = partnerDB.ID.Hex()
+	return nil
+}
+
+func (repo *ocpiPartnerRepository) FindPartners(ctx context.Context) ([]domainModels.OCPIPartner, error) {
+	cursor, err := repo.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var partnersDB []repoModels.OCPIPartnerDB
+	if err := cursor.All(ctx, &partnersDB); err != nil {
+		return nil, err
+	}
+
+	partners := make([]domainModels.OCPIPartner, 0, len(partnersDB))
+	for _, p := range partnersDB {
+		partners = append(partners, mapOCPIPartnerDBToDomain(p))
+	}
+	return partners, nil
+}
+
+func (repo *ocpiPartnerRepository) FindPartnerByID(ctx context.Context, id string) (*domainModels.OCPIPartner, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid partner ID")
+	}
+	return repo.findPartner(ctx, bson.M{"_id": objectID})
+}
+
+// FindPartnerByTokenHash matches the hash of a token we issued (A or C); it returns nil without an error when none matches
+func (repo *ocpiPartnerRepository) FindPartnerByTokenHash(ctx context.Context, tokenHash string) (*domainModels.OCPIPartner, error) {
+	return repo.findPartner(ctx, bson.M{"$or": []bson.M{
+		{"token_a_hash": tokenHash},
+		{"token_c_hash": tokenHash},
+	}})
+}
+
+func (repo *ocpiPartnerRepository) findPartner(ctx context.Context, filter bson.M) (*domainModels.OCPIPartner, error) {
+	var partnerDB repoModels.OCPIPartnerDB
+	err := repo.collection.FindOne(ctx, filter).Decode(&partnerDB)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding OCPI partner: %v", err)
+	}
+
+	partner := mapOCPIPartnerDBToDomain(partnerDB)
+	return &partner, nil
+}
+
+// UpdatePartner replaces the stored partner, so cleared tokens are removed as well
+func (repo *ocpiPartnerRepository) UpdatePartner(ctx context.Context, partner *domainModels.OCPIPartner) error {
+	objectID, err := primitive.ObjectIDFromHex(partner.ID)
+	if err != nil {
+		return errors.New("invalid partner ID")
+	}
+
+	partnerDB := mapOCPIPartnerDomainToDB(partner)
+	partnerDB.ID = objectID
+	result, err := repo.collection.ReplaceOne(ctx, bson.M{"_id": objectID}, partnerDB)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("OCPI partner not found")
+	}
+	return nil
+}
+
+func mapOCPIPartnerDomainToDB(partner *domainModels.OCPIPartner) repoModels.OCPIPartnerDB {
+	endpoints := make([]repoModels.OCPIEndpointDB, 0, len(partner.Endpoints))
+	for _, e := range partner.Endpoints {
+		endpoints = append(endpoints, repoModels.OCPIEndpointDB{Identifier: e.Identifier, Role: e.Role, URL: e.URL})
+	}
+	roles := make([]repoModels.OCPIRoleDB, 0, len(partner.Roles))
+	for _, r := range partner.Roles {
+		roles = append(roles, repoModels.OCPIRoleDB{Role: r.Role, CountryCode: r.CountryCode, PartyID: r.PartyID, BusinessName: r.BusinessName})
+	}
+
+	return repoModels.OCPIPartnerDB{
+		Name:        partner.Name,
+		Status:      partner.Status,
+		TokenAHash:  partner.TokenAHash,
+		TokenCHash:  partner.TokenCHash,
+		TokenB:      partner.TokenB,
+		VersionsURL: partner.VersionsURL,
+		Version:     partner.Version,
+		Endpoints:   endpoints,
+		Roles:       roles,
+		CreatedAt:   partner.CreatedAt,
+		UpdatedAt:   partner.UpdatedAt,
+	}
+}
+
+func mapOCPIPartnerDBToDomain(partnerDB repoModels.OCPIPartnerDB) domainModels.OCPIPartner {
+	endpoints := make([]domainModels.OCPIEndpoint, 0, len(partnerDB.Endpoints))
+	for _, e := range partnerDB.Endpoints {
+		endpoints = append(endpoints, domainModels.OCPIEndpoint{Identifier: e.Identifier, Role: e.Role, URL: e.URL})
+	}
+	roles := make([]domainModels.OCPIRole, 0, len(partnerDB.Roles))
+	for _, r := range partnerDB.Roles {
+		roles = append(roles, domainModels.OCPIRole{Role: r.Role, CountryCode: r.CountryCode, PartyID: r.PartyID, BusinessName: r.BusinessName})
+	}
+
+	return domainModels.OCPIPartner{
+		ID:          partnerDB.ID.Hex(),
+		Name:        partnerDB.Name,
+		Status:      partnerDB.Status,
+		TokenAHash:  partnerDB.TokenAHash,
+		TokenCHash:  partnerDB.TokenCHash,
+		TokenB:      partnerDB.TokenB,
+		VersionsURL: partnerDB.VersionsURL,
+		Version:     partnerDB.Version,
+		Endpoints:   endpoints,
+		Roles:       roles,
+		CreatedAt:   partnerDB.CreatedAt,
+		UpdatedAt:   partnerDB.UpdatedAt,
+	}
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	domainModels "Ev-Charge-Hub/Server/internal/domain/models"
+	repoModels "Ev-Charge-Hub/Server/internal/repository/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//go:generate mockgen -source=password_reset_repository.go -destination=../mocks/mock_password_reset_repository.go -package=mocks
+type PasswordResetRepository interface {
+	CreatePasswordResetToken(ctx context.Context, token *domainModels.PasswordResetToken) error
+	FindPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*domainModels.PasswordResetToken, error)
+	MarkPasswordResetTokenUsed(ctx context.Context, id string, usedAt time.Time) (bool, error)
+	InvalidateUserPasswordResetTokens(ctx context.Context, userID string, usedAt time.Time) error
+}
+
+type passwordResetRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPasswordResetRepository(db *mongo.Database) PasswordResetRepository {
+	return &passwordResetRepository{collection: db.Collection("password_reset_tokens")}
+}
+
+func (repo *passwordResetRepository) CreatePasswordResetToken(ctx context.Context, token *domainModels.PasswordResetToken) error {
+	tokenDB := repoModels.PasswordResetTokenDB{
+		ID:        primitive.NewObjectID(),
+		TokenHash: token.TokenHash,
+		UserID:    token.UserID,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+	if _, err := repo.collection.InsertOne(ctx, tokenDB); err != nil {
+		return err
+	}
+	token.ID = tokenDB.ID.Hex()
+	return nil
+}
+
+// FindPasswordResetTokenByHash returns nil without an error when no token matches
+func (repo *passwordResetRepository) FindPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*domainModels.PasswordResetToken, error) {
+	var tokenDB repoModels.PasswordResetTokenDB
+	err := repo.collection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&tokenDB)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding password reset token: %v", err)
+	}
+
+	return &domainModels.PasswordResetToken{
+		ID:        tokenDB.ID.Hex(),
+		TokenHash: tokenDB.TokenHash,
+		UserID:    tokenDB.UserID,
+		ExpiresAt: tokenDB.ExpiresAt,
+		CreatedAt: tokenDB.CreatedAt,
+		UsedAt:    tokenDB.UsedAt,
+	}, nil
+}
+
+// MarkPasswordResetTokenUsed reports false when the token was already used, so a reset link
+// cannot be redeemed twice even by concurrent requests
+func (repo *passwordResetRepository) MarkPasswordResetTokenUsed(ctx context.Context, id string, usedAt time.Time) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, errors.New("invalid password reset token ID")
+	}
+	result, err := repo.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "used_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"used_at": usedAt}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// InvalidateUserPasswordResetTokens marks every unused token of the user as used
+func (repo *passwordResetRepository) InvalidateUserPasswordResetTokens(ctx context.Context, userID string, usedAt time.Time) error {
+	_, err := repo.collection.UpdateMany(ctx,
+		bson.M{"user_id": userID, "used_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"used_at": usedAt}})
+	return err
+}
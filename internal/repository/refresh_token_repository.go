@@ -0,0 +1,112 @@
+package repository
+
+import (
+	domainModels "Ev-Charge-Hub/Server/internal/domain/models"
+	repoModels "Ev-Charge-Hub/Server/internal/repository/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//go:generate mockgen -source=refresh_token_repository.go -destination=../mocks/mock_refresh_token_repository.go -package=mocks
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token *domainModels.RefreshToken) error
+	FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*domainModels.RefreshToken, error)
+	FindRefreshTokenByAccessJTI(ctx context.Context, jti string) (*domainModels.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, id string, usedAt time.Time) (bool, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+	RevokeUserRefreshTokens(ctx context.Context, userID string, revokedAt time.Time) error
+}
+
+type refreshTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRefreshTokenRepository(db *mongo.Database) RefreshTokenRepository {
+	return &refreshTokenRepository{collection: db.Collection("refresh_tokens")}
+}
+
+func (repo *refreshTokenRepository) CreateRefreshToken(ctx context.Context, token *domainModels.RefreshToken) error {
+	tokenDB := repoModels.RefreshTokenDB{
+		ID:        primitive.NewObjectID(),
+		TokenHash: token.TokenHash,
+		FamilyID:  token.FamilyID,
+		UserID:    token.UserID,
+		AccessJTI: token.AccessJTI,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+	if _, err := repo.collection.InsertOne(ctx, tokenDB); err != nil {
+		return err
+	}
+	token.ID = tokenDB.ID.Hex()
+	return nil
+}
+
+// FindRefreshTokenByHash returns nil without an error when no token matches
+func (repo *refreshTokenRepository) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*domainModels.RefreshToken, error) {
+	return repo.findRefreshToken(ctx, bson.M{"token_hash": tokenHash})
+}
+
+// FindRefreshTokenByAccessJTI returns nil without an error when no token matches
+func (repo *refreshTokenRepository) FindRefreshTokenByAccessJTI(ctx context.Context, jti string) (*domainModels.RefreshToken, error) {
+	return repo.findRefreshToken(ctx, bson.M{"access_jti": jti})
+}
+
+func (repo *refreshTokenRepository) findRefreshToken(ctx context.Context, filter bson.M) (*domainModels.RefreshToken, error) {
+	var tokenDB repoModels.RefreshTokenDB
+	err := repo.collection.FindOne(ctx, filter).Decode(&tokenDB)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding refresh token: %v", err)
+	}
+
+	return &domainModels.RefreshToken{
+		ID:        tokenDB.ID.Hex(),
+		TokenHash: tokenDB.TokenHash,
+		FamilyID:  tokenDB.FamilyID,
+		UserID:    tokenDB.UserID,
+		AccessJTI: tokenDB.AccessJTI,
+		ExpiresAt: tokenDB.ExpiresAt,
+		CreatedAt: tokenDB.CreatedAt,
+		UsedAt:    tokenDB.UsedAt,
+		RevokedAt: tokenDB.RevokedAt,
+	}, nil
+}
+
+// MarkRefreshTokenUsed reports false when the token was already used or revoked, so two
+// concurrent refreshes with the same token cannot both succeed
+func (repo *refreshTokenRepository) MarkRefreshTokenUsed(ctx context.Context, id string, usedAt time.Time) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, errors.New("invalid refresh token ID")
+	}
+	result, err := repo.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "used_at": bson.M{"$exists": false}, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"used_at": usedAt}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (repo *refreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	return repo.revoke(ctx, bson.M{"family_id": familyID}, revokedAt)
+}
+
+func (repo *refreshTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID string, revokedAt time.Time) error {
+	return repo.revoke(ctx, bson.M{"user_id": userID}, revokedAt)
+}
+
+func (repo *refreshTokenRepository) revoke(ctx context.Context, filter bson.M, revokedAt time.Time) error {
+	filter["revoked_at"] = bson.M{"$exists": false}
+	_, err := repo.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"revoked_at": revokedAt}})
+	return err
+}
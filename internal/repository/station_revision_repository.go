@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/repository/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrRevisionNotFound is returned when no stored revision matches
+var ErrRevisionNotFound = errors.New("station revision not found")
+
+// updateStation applies an update to one station, stamps updated_at and stores the resulting
+// document as a revision. It reports false when the filter matched nothing.
+func (repo *evStationRepository) updateStation(ctx context.Context, filter interface{}, update bson.M) (bool, error) {
+	updated, err := repo.applyStationUpdate(ctx, filter, update)
+	if err != nil || updated == nil {
+		return false, err
+	}
+
+	repo.saveRevision(ctx, *updated)
+	return true, nil
+}
+
+// applyStationUpdate is updateStation without the revision, for live state such as bookings that
+// would otherwise fill the history with a full copy per booking. It returns nil when nothing matched.
+func (repo *evStationRepository) applyStationUpdate(ctx context.Context, filter interface{}, update bson.M) (*models.EVStationDB, error) {
+	stamped := bson.M{"$currentDate": bson.M{"updated_at": true}}
+	for operator, fields := range update {
+		stamped[operator] = fields
+	}
+	update = stamped
+
+	var updated models.EVStationDB
+	err := repo.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// saveRevision keeps one revision per station version; a failure is logged and does not undo the write
+func (repo *evStationRepository) saveRevision(ctx context.Context, station models.EVStationDB) {
+	revision := models.StationRevisionDB{
+		StationID:  station.ID,
+		Version:    station.Version,
+		RecordedAt: time.Now().UTC(),
+		RecordedBy: constants.AuditSystemActor,
+		RequestID:  audit.RequestIDFromContext(ctx),
+		Station:    station,
+	}
+	if actor, ok := audit.ActorFromContext(ctx); ok {
+		revision.RecordedBy = actor.UserName
+	}
+
+	_, err := repo.revisions.UpdateOne(
+		ctx,
+		bson.M{"station_id": station.ID, "version": station.Version},
+		bson.M{"$setOnInsert": revision},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		log.Printf("failed to save revision %d of station %s: %v", station.Version, station.ID.Hex(), err)
+	}
+}
+
+// FindStationRevisions lists all stored revisions of a station, newest first
+func (repo *evStationRepository) FindStationRevisions(ctx context.Context, stationID string) ([]models.StationRevisionDB, error) {
+	objectID, err := primitive.ObjectIDFromHex(stationID)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := repo.revisions.Find(
+		ctx,
+		bson.M{"station_id": objectID},
+		options.Find().SetSort(bson.D{{Key: "version", Value: -1}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find revisions: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	var revisions []models.StationRevisionDB
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, fmt.Errorf("error decoding revisions: %v", err)
+	}
+	return revisions, nil
+}
+
+func (repo *evStationRepository) FindStationRevision(ctx context.Context, stationID string, version int64) (*models.StationRevisionDB, error) {
+	objectID, err := primitive.ObjectIDFromHex(stationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.findRevision(ctx, bson.M{"station_id": objectID, "version": version}, nil)
+}
+
+// FindStationRevisionAsOf returns the latest revision recorded at or before asOf
+func (repo *evStationRepository) FindStationRevisionAsOf(ctx context.Context, stationID string, asOf time.Time) (*models.StationRevisionDB, error) {
+	objectID, err := primitive.ObjectIDFromHex(stationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.findRevision(
+		ctx,
+		bson.M{"station_id": objectID, "recorded_at": bson.M{"$lte": asOf}},
+		options.FindOne().SetSort(bson.D{{Key: "recorded_at", Value: -1}, {Key: "version", Value: -1}}),
+	)
+}
+
+func (repo *evStationRepository) findRevision(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (*models.StationRevisionDB, error) {
+	if opts == nil {
+		opts = options.FindOne()
+	}
+
+	var revision models.StationRevisionDB
+	if err := repo.revisions.FindOne(ctx, filter, opts).Decode(&revision); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("error finding revision: %v", err)
+	}
+	return &revision, nil
+}
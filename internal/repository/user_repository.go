@@ -5,16 +5,57 @@ import (
 	repoModels "Ev-Charge-Hub/Server/internal/repository/models"
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 //go:generate mockery --name=UserRepositoryInterface --output=../../mocks --outpkg=mocks --with-expecter
 type UserRepositoryInterface interface {
 	FindByUsernameOrEmail(ctx context.Context, usernameOrEmail string) (*domainModels.UserModel, error)
 	CreateUser(ctx context.Context, user *domainModels.UserModel) error
+	FindUserByID(ctx context.Context, id string) (*domainModels.UserModel, error)
+	FindUsersByRole(ctx context.Context, role string) ([]domainModels.UserModel, error)
+	UpdateUserRole(ctx context.Context, id string, role string, updatedAt time.Time) error
+	FindUserByEmail(ctx context.Context, email string) (*domainModels.UserModel, error)
+	UpdateUserPassword(ctx context.Context, id string, passwordHash string, updatedAt time.Time) error
+	FindUserByUsername(ctx context.Context, username string) (*domainModels.UserModel, error)
+	SetEmailVerificationToken(ctx context.Context, id string, tokenHash string, expiresAt time.Time) error
+	VerifyEmailByTokenHash(ctx context.Context, tokenHash string, verifiedAt time.Time) (*domainModels.UserModel, error)
+	MarkLegacyEmailsVerified(ctx context.Context, verifiedAt time.Time) (int64, error)
+	EnsureUserIndexes(ctx context.Context) error
+	FindDuplicateUsers(ctx context.Context, field string) ([]domainModels.DuplicateUserGroup, error)
+	RenameUser(ctx context.Context, id string, username string, updatedAt time.Time) error
+	SetPendingTOTPSecret(ctx context.Context, id string, secret string, enrollmentCodeHash string) error
+	EnableTOTP(ctx context.Context, id string, secret string, step int64, recoveryCodeHashes []string, updatedAt time.Time) error
+	DisableTOTP(ctx context.Context, id string, updatedAt time.Time) error
+	SetTOTPRecoveryCodes(ctx context.Context, id string, recoveryCodeHashes []string) error
+	UseTOTPStep(ctx context.Context, id string, step int64) (bool, error)
+	UseTOTPRecoveryCode(ctx context.Context, id string, codeHash string) (bool, error)
+	SetTwoFactorChallenge(ctx context.Context, id string, challengeHash string, expiresAt time.Time) error
+	FindUserByTwoFactorChallenge(ctx context.Context, challengeHash string, now time.Time) (*domainModels.UserModel, error)
+	ClearTwoFactorChallenge(ctx context.Context, id string) error
 }
 
+var (
+	// ErrDuplicateUsername is returned by CreateUser and RenameUser when the unique username index rejects the write
+	ErrDuplicateUsername = errors.New("username already exists")
+	// ErrDuplicateEmail is returned by CreateUser when the unique email index rejects the write
+	ErrDuplicateEmail = errors.New("email already exists")
+)
+
+const (
+	usernameIndexName = "username_unique"
+	emailIndexName    = "email_unique"
+)
+
+// caseInsensitive compares strings ignoring letter case; the unique indexes and the lookups by
+// username or email use it, so "Alice" and "alice" are the same account
+var caseInsensitive = &options.Collation{Locale: "en", Strength: 2}
+
 // Define Class userRepository
 type userRepository struct {
 	collection *mongo.Collection
@@ -36,20 +77,12 @@ func (u *userRepository) FindByUsernameOrEmail(ctx context.Context, usernameOrEm
 		},
 	}
 
-	err := u.collection.FindOne(ctx, filter).Decode(&userDB)
+	err := u.collection.FindOne(ctx, filter, options.FindOne().SetCollation(caseInsensitive)).Decode(&userDB)
 	if err != nil {
 		return nil, err
 	}
 
-	return &domainModels.UserModel{
-		ID:        userDB.ID.Hex(),
-		Username:  userDB.Username,
-		Email:     userDB.Email,
-		Password:  userDB.Password,
-		Role:      userDB.Role,
-		CreatedAt: userDB.CreatedAt,
-		UpdatedAt: userDB.UpdatedAt,
-	}, nil
+	return mapUserDBToDomain(userDB), nil
 }
 
 // CreateUser implements UserRepository.
@@ -59,15 +92,395 @@ func (u *userRepository) CreateUser(ctx context.Context, user *domainModels.User
 		return errors.New("invalid object ID")
 	}
 	userDB := repoModels.UserDB{
-		ID:        objectID,
-		Username:  user.Username,
-		Email:     user.Email,
-		Password:  user.Password,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:              objectID,
+		Username:        user.Username,
+		Email:           user.Email,
+		Password:        user.Password,
+		Role:            user.Role,
+		CreatedBy:       user.CreatedBy,
+		EmailVerified:   user.EmailVerified,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
 	}
 
 	_, err = u.collection.InsertOne(ctx, userDB)
+	return mapDuplicateUserError(err)
+}
+
+// FindUserByID returns nil, nil when no user has the ID
+func (u *userRepository) FindUserByID(ctx context.Context, id string) (*domainModels.UserModel, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	var userDB repoModels.UserDB
+	err = u.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&userDB)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding user: %v", err)
+	}
+	return mapUserDBToDomain(userDB), nil
+}
+
+// FindUsersByRole returns the users with the role, oldest first
+func (u *userRepository) FindUsersByRole(ctx context.Context, role string) ([]domainModels.UserModel, error) {
+	cursor, err := u.collection.Find(ctx, bson.M{"role": role}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("error finding users: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	var usersDB []repoModels.UserDB
+	if err := cursor.All(ctx, &usersDB); err != nil {
+		return nil, fmt.Errorf("error decoding users: %v", err)
+	}
+	users := make([]domainModels.UserModel, 0, len(usersDB))
+	for _, userDB := range usersDB {
+		users = append(users, *mapUserDBToDomain(userDB))
+	}
+	return users, nil
+}
+
+func (u *userRepository) UpdateUserRole(ctx context.Context, id string, role string, updatedAt time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid object ID")
+	}
+	result, err := u.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": bson.M{"role": role, "updated_at": updatedAt}})
+	if err != nil {
+		return fmt.Errorf("error updating user role: %v", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// FindUserByEmail returns nil, nil when no user has the email, compared ignoring case
+func (u *userRepository) FindUserByEmail(ctx context.Context, email string) (*domainModels.UserModel, error) {
+	var userDB repoModels.UserDB
+	err := u.collection.FindOne(ctx, bson.M{"email": email}, options.FindOne().SetCollation(caseInsensitive)).Decode(&userDB)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding user: %v", err)
+	}
+	return mapUserDBToDomain(userDB), nil
+}
+
+func (u *userRepository) UpdateUserPassword(ctx context.Context, id string, passwordHash string, updatedAt time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid object ID")
+	}
+	result, err := u.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": bson.M{"password": passwordHash, "updated_at": updatedAt}})
+	if err != nil {
+		return fmt.Errorf("error updating user password: %v", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// FindUserByUsername returns nil, nil when no user has the username, compared ignoring case
+func (u *userRepository) FindUserByUsername(ctx context.Context, username string) (*domainModels.UserModel, error) {
+	var userDB repoModels.UserDB
+	err := u.collection.FindOne(ctx, bson.M{"username": username}, options.FindOne().SetCollation(caseInsensitive)).Decode(&userDB)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding user: %v", err)
+	}
+	return mapUserDBToDomain(userDB), nil
+}
+
+// SetEmailVerificationToken replaces any earlier token, so only the newest link works
+func (u *userRepository) SetEmailVerificationToken(ctx context.Context, id string, tokenHash string, expiresAt time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid object ID")
+	}
+	_, err = u.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": bson.M{
+		"email_verification_token_hash": tokenHash,
+		"email_verification_expires_at": expiresAt,
+	}})
+	if err != nil {
+		return fmt.Errorf("error storing email verification token: %v", err)
+	}
+	return nil
+}
+
+// VerifyEmailByTokenHash marks the email of the user holding the unexpired token as verified and
+// removes the token in one update. It returns nil, nil when no user holds such a token.
+func (u *userRepository) VerifyEmailByTokenHash(ctx context.Context, tokenHash string, verifiedAt time.Time) (*domainModels.UserModel, error) {
+	filter := bson.M{
+		"email_verification_token_hash": tokenHash,
+		"email_verification_expires_at": bson.M{"$gt": verifiedAt},
+	}
+	update := bson.M{
+		"$set":   bson.M{"email_verified": true, "email_verified_at": verifiedAt, "updated_at": verifiedAt},
+		"$unset": bson.M{"email_verification_token_hash": "", "email_verification_expires_at": ""},
+	}
+
+	var userDB repoModels.UserDB
+	err := u.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&userDB)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error verifying email: %v", err)
+	}
+	return mapUserDBToDomain(userDB), nil
+}
+
+// MarkLegacyEmailsVerified marks the accounts created before email verification existed, which
+// have no email_verified field at all, as verified. Accounts that are unverified stay so.
+func (u *userRepository) MarkLegacyEmailsVerified(ctx context.Context, verifiedAt time.Time) (int64, error) {
+	result, err := u.collection.UpdateMany(
+		ctx,
+		bson.M{"email_verified": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"email_verified": true, "email_verified_at": verifiedAt}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error marking legacy emails verified: %v", err)
+	}
+	return result.ModifiedCount, nil
+}
+
+// EnsureUserIndexes creates the case-insensitive unique indexes on username and email. It fails
+// while duplicates exist; cmd/dedupe-users reports and resolves them.
+func (u *userRepository) EnsureUserIndexes(ctx context.Context) error {
+	_, err := u.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "username", Value: 1}},
+			Options: options.Index().SetName(usernameIndexName).SetUnique(true).SetCollation(caseInsensitive),
+		},
+		{
+			Keys:    bson.D{{Key: "email", Value: 1}},
+			Options: options.Index().SetName(emailIndexName).SetUnique(true).SetCollation(caseInsensitive),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating user indexes: %v", err)
+	}
+	return nil
+}
+
+// FindDuplicateUsers groups the accounts sharing a username or email (field) regardless of case;
+// the accounts of a group are sorted oldest first
+func (u *userRepository) FindDuplicateUsers(ctx context.Context, field string) ([]domainModels.DuplicateUserGroup, error) {
+	if field != "username" && field != "email" {
+		return nil, fmt.Errorf("cannot look for duplicates of %q", field)
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"$toLower": "$" + field},
+			"users": bson.M{"$push": "$$ROOT"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+	cursor, err := u.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("error finding duplicate users: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	var groupsDB []struct {
+		Value string              `bson:"_id"`
+		Users []repoModels.UserDB `bson:"users"`
+	}
+	if err := cursor.All(ctx, &groupsDB); err != nil {
+		return nil, fmt.Errorf("error decoding duplicate users: %v", err)
+	}
+	groups := make([]domainModels.DuplicateUserGroup, 0, len(groupsDB))
+	for _, groupDB := range groupsDB {
+		group := domainModels.DuplicateUserGroup{Field: field, Value: groupDB.Value}
+		for _, userDB := range groupDB.Users {
+			group.Users = append(group.Users, *mapUserDBToDomain(userDB))
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func (u *userRepository) RenameUser(ctx context.Context, id string, username string, updatedAt time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid object ID")
+	}
+	result, err := u.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": bson.M{"username": username, "updated_at": updatedAt}})
+	if err != nil {
+		return mapDuplicateUserError(err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// SetPendingTOTPSecret starts an enrollment; TOTP stays off until EnableTOTP confirms it. An empty
+// enrollmentCodeHash removes the code of an earlier enrollment started at login.
+func (u *userRepository) SetPendingTOTPSecret(ctx context.Context, id string, secret string, enrollmentCodeHash string) error {
+	update := bson.M{"$set": bson.M{"totp_pending_secret": secret, "totp_enrollment_code_hash": enrollmentCodeHash}}
+	if enrollmentCodeHash == "" {
+		update = bson.M{"$set": bson.M{"totp_pending_secret": secret}, "$unset": bson.M{"totp_enrollment_code_hash": ""}}
+	}
+	return u.updateUser(ctx, id, update, "error storing TOTP secret")
+}
+
+// EnableTOTP turns TOTP on with the confirmed secret; step is the time step of the confirming code
+func (u *userRepository) EnableTOTP(ctx context.Context, id string, secret string, step int64, recoveryCodeHashes []string, updatedAt time.Time) error {
+	return u.updateUser(ctx, id, bson.M{
+		"$set": bson.M{
+			"totp_enabled":              true,
+			"totp_secret":               secret,
+			"totp_last_step":            step,
+			"totp_recovery_code_hashes": recoveryCodeHashes,
+			"updated_at":                updatedAt,
+		},
+		"$unset": bson.M{"totp_pending_secret": "", "totp_enrollment_code_hash": ""},
+	}, "error enabling TOTP")
+}
+
+func (u *userRepository) DisableTOTP(ctx context.Context, id string, updatedAt time.Time) error {
+	return u.updateUser(ctx, id, bson.M{
+		"$set": bson.M{"totp_enabled": false, "updated_at": updatedAt},
+		"$unset": bson.M{
+			"totp_secret":               "",
+			"totp_pending_secret":       "",
+			"totp_enrollment_code_hash": "",
+			"totp_last_step":            "",
+			"totp_recovery_code_hashes": "",
+		},
+	}, "error disabling TOTP")
+}
+
+// SetTOTPRecoveryCodes replaces every recovery code, used or not
+func (u *userRepository) SetTOTPRecoveryCodes(ctx context.Context, id string, recoveryCodeHashes []string) error {
+	return u.updateUser(ctx, id, bson.M{"$set": bson.M{"totp_recovery_code_hashes": recoveryCodeHashes}}, "error storing recovery codes")
+}
+
+// UseTOTPStep records that a code of step was accepted. It returns false when a code of this or
+// a later step was already accepted, so each code works once even with concurrent logins.
+func (u *userRepository) UseTOTPStep(ctx context.Context, id string, step int64) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, errors.New("invalid object ID")
+	}
+	filter := bson.M{"_id": objectID, "$or": bson.A{
+		bson.M{"totp_last_step": bson.M{"$lt": step}},
+		bson.M{"totp_last_step": bson.M{"$exists": false}},
+	}}
+	result, err := u.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"totp_last_step": step}})
+	if err != nil {
+		return false, fmt.Errorf("error storing TOTP step: %v", err)
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// UseTOTPRecoveryCode removes the recovery code in one update; false means the account does not hold it
+func (u *userRepository) UseTOTPRecoveryCode(ctx context.Context, id string, codeHash string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, errors.New("invalid object ID")
+	}
+	result, err := u.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "totp_recovery_code_hashes": codeHash},
+		bson.M{"$pull": bson.M{"totp_recovery_code_hashes": codeHash}})
+	if err != nil {
+		return false, fmt.Errorf("error using recovery code: %v", err)
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// SetTwoFactorChallenge replaces any earlier challenge, so only the newest login can be completed
+func (u *userRepository) SetTwoFactorChallenge(ctx context.Context, id string, challengeHash string, expiresAt time.Time) error {
+	return u.updateUser(ctx, id, bson.M{"$set": bson.M{
+		"two_factor_challenge_hash":       challengeHash,
+		"two_factor_challenge_expires_at": expiresAt,
+	}}, "error storing two-factor challenge")
+}
+
+// FindUserByTwoFactorChallenge returns nil, nil when no user holds the challenge or it has expired
+func (u *userRepository) FindUserByTwoFactorChallenge(ctx context.Context, challengeHash string, now time.Time) (*domainModels.UserModel, error) {
+	filter := bson.M{
+		"two_factor_challenge_hash":       challengeHash,
+		"two_factor_challenge_expires_at": bson.M{"$gt": now},
+	}
+	var userDB repoModels.UserDB
+	err := u.collection.FindOne(ctx, filter).Decode(&userDB)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding two-factor challenge: %v", err)
+	}
+	return mapUserDBToDomain(userDB), nil
+}
+
+func (u *userRepository) ClearTwoFactorChallenge(ctx context.Context, id string) error {
+	return u.updateUser(ctx, id, bson.M{"$unset": bson.M{
+		"two_factor_challenge_hash":       "",
+		"two_factor_challenge_expires_at": "",
+	}}, "error clearing two-factor challenge")
+}
+
+// updateUser applies update to the user with the hex id; action prefixes write errors
+func (u *userRepository) updateUser(ctx context.Context, id string, update bson.M, action string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid object ID")
+	}
+	result, err := u.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return fmt.Errorf("%s: %v", action, err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// mapDuplicateUserError tells which unique index rejected a write
+func mapDuplicateUserError(err error) error {
+	if err == nil || !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	switch {
+	case strings.Contains(err.Error(), emailIndexName):
+		return ErrDuplicateEmail
+	case strings.Contains(err.Error(), usernameIndexName):
+		return ErrDuplicateUsername
+	}
 	return err
 }
+
+func mapUserDBToDomain(userDB repoModels.UserDB) *domainModels.UserModel {
+	return &domainModels.UserModel{
+		ID:                     userDB.ID.Hex(),
+		Username:               userDB.Username,
+		Email:                  userDB.Email,
+		Password:               userDB.Password,
+		Role:                   userDB.Role,
+		CreatedBy:              userDB.CreatedBy,
+		EmailVerified:          userDB.EmailVerified,
+		EmailVerifiedAt:        userDB.EmailVerifiedAt,
+		TOTPEnabled:            userDB.TOTPEnabled,
+		TOTPSecret:             userDB.TOTPSecret,
+		TOTPPendingSecret:      userDB.TOTPPendingSecret,
+		TOTPRecoveryCodeHashes: userDB.TOTPRecoveryCodeHashes,
+		TOTPEnrollmentCodeHash: userDB.TOTPEnrollmentCodeHash,
+		CreatedAt:              userDB.CreatedAt,
+		UpdatedAt:              userDB.UpdatedAt,
+	}
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	domainModels "Ev-Charge-Hub/Server/internal/domain/models"
+	repoModels "Ev-Charge-Hub/Server/internal/repository/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//go:generate mockgen -source=vehicle_repository.go -destination=../mocks/mock_vehicle_repository.go -package=mocks
+type VehicleRepository interface {
+	CreateVehicle(ctx context.Context, vehicle *domainModels.Vehicle) error
+	FindVehicleByID(ctx context.Context, id string) (*domainModels.Vehicle, error)
+	FindVehiclesByUserID(ctx context.Context, userID string) ([]domainModels.Vehicle, error)
+	EditVehicle(ctx context.Context, vehicle *domainModels.Vehicle) error
+	RemoveVehicle(ctx context.Context, id string) error
+}
+
+// ErrVehicleNotFound is returned by FindVehicleByID for unknown and malformed IDs
+var ErrVehicleNotFound = errors.New("vehicle not found")
+
+type vehicleRepository struct {
+	collection *mongo.Collection
+}
+
+func NewVehicleRepository(db *mongo.Database) VehicleRepository {
+	return &vehicleRepository{collection: db.Collection("vehicles")}
+}
+
+func (repo *vehicleRepository) CreateVehicle(ctx context.Context, vehicle *domainModels.Vehicle) error {
+	vehicleDB, err := mapVehicleDomainToDB(vehicle)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.collection.InsertOne(ctx, vehicleDB)
+	return err
+}
+
+func (repo *vehicleRepository) FindVehicleByID(ctx context.Context, id string) (*domainModels.Vehicle, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid vehicle ID", ErrVehicleNotFound)
+	}
+
+	var vehicleDB repoModels.VehicleDB
+	err = repo.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&vehicleDB)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrVehicleNotFound
+		}
+		return nil, fmt.Errorf("error finding vehicle: %v", err)
+	}
+
+	vehicle := mapVehicleDBToDomain(vehicleDB)
+	return &vehicle, nil
+}
+
+func (repo *vehicleRepository) FindVehiclesByUserID(ctx context.Context, userID string) ([]domainModels.Vehicle, error) {
+	cursor, err := repo.collection.Find(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	var vehiclesDB []repoModels.VehicleDB
+	if err := cursor.All(ctx, &vehiclesDB); err != nil {
+		return nil, err
+	}
+
+	vehicles := make([]domainModels.Vehicle, 0, len(vehiclesDB))
+	for _, v := range vehiclesDB {
+		vehicles = append(vehicles, mapVehicleDBToDomain(v))
+	}
+	return vehicles, nil
+}
+
+func (repo *vehicleRepository) EditVehicle(ctx context.Context, vehicle *domainModels.Vehicle) error {
+	vehicleDB, err := mapVehicleDomainToDB(vehicle)
+	if err != nil {
+		return err
+	}
+
+	result, err := repo.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": vehicleDB.ID},
+		bson.M{"$set": vehicleDB},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("vehicle not found")
+	}
+	return nil
+}
+
+func (repo *vehicleRepository) RemoveVehicle(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid vehicle ID")
+	}
+
+	result, err := repo.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("vehicle not found")
+	}
+	return nil
+}
+
+func mapVehicleDomainToDB(vehicle *domainModels.Vehicle) (repoModels.VehicleDB, error) {
+	objectID, err := primitive.ObjectIDFromHex(vehicle.ID)
+	if err != nil {
+		return repoModels.VehicleDB{}, errors.New("invalid vehicle ID")
+	}
+
+	return repoModels.VehicleDB{
+		ID:             objectID,
+		UserID:         vehicle.UserID,
+		CatalogID:      vehicle.CatalogID,
+		Make:           vehicle.Make,
+		Model:          vehicle.Model,
+		BatteryKWh:     vehicle.BatteryKWh,
+		SupportedPlugs: vehicle.SupportedPlugs,
+		MaxACPowerKW:   vehicle.MaxACPowerKW,
+		MaxDCPowerKW:   vehicle.MaxDCPowerKW,
+		CreatedAt:      vehicle.CreatedAt,
+		UpdatedAt:      vehicle.UpdatedAt,
+	}, nil
+}
+
+func mapVehicleDBToDomain(vehicleDB repoModels.VehicleDB) domainModels.Vehicle {
+	return domainModels.Vehicle{
+		ID:             vehicleDB.ID.Hex(),
+		UserID:         vehicleDB.UserID,
+		CatalogID:      vehicleDB.CatalogID,
+		Make:           vehicleDB.Make,
+		Model:          vehicleDB.Model,
+		BatteryKWh:     vehicleDB.BatteryKWh,
+		SupportedPlugs: vehicleDB.SupportedPlugs,
+		MaxACPowerKW:   vehicleDB.MaxACPowerKW,
+		MaxDCPowerKW:   vehicleDB.MaxDCPowerKW,
+		CreatedAt:      vehicleDB.CreatedAt,
+		UpdatedAt:      vehicleDB.UpdatedAt,
+	}
+}
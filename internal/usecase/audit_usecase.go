@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/authz"
+	"Ev-Charge-Hub/Server/internal/constants"
+	domainModel "Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:generate mockgen -source=audit_usecase.go -destination=../mocks/mock_audit_usecase.go -package=mocks
+
+// AuditRecorder is what other usecases depend on to log their changes
+type AuditRecorder interface {
+	// Record stores who did what to which target, with the field-level diff of before and after.
+	// Either snapshot may be nil (creation, deletion). Failures are logged, not returned.
+	Record(ctx context.Context, action constants.AuditAction, targetType string, targetID string, before interface{}, after interface{})
+}
+
+type AuditUsecase interface {
+	AuditRecorder
+	GetAuditEntries(ctx context.Context, req request.AuditLogFilterRequest) ([]response.AuditEntryResponse, error)
+}
+
+const (
+	defaultAuditLimit = 100
+)
+
+type auditUsecase struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditUsecase(auditRepo repository.AuditRepository) AuditUsecase {
+	return &auditUsecase{auditRepo: auditRepo}
+}
+
+func (u *auditUsecase) Record(ctx context.Context, action constants.AuditAction, targetType string, targetID string, before interface{}, after interface{}) {
+	entry := domainModel.AuditEntry{
+		ActorName:  constants.AuditSystemActor,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		RequestID:  audit.RequestIDFromContext(ctx),
+		Timestamp:  time.Now().UTC(),
+		Changes:    diffAuditSnapshots(before, after),
+	}
+	if actor, ok := audit.ActorFromContext(ctx); ok {
+		entry.ActorID = actor.UserID
+		entry.ActorName = actor.UserName
+	}
+
+	if err := u.auditRepo.InsertEntry(ctx, entry); err != nil {
+		log.Printf("failed to write audit entry %s %s/%s: %v", action, targetType, targetID, err)
+	}
+}
+
+func (u *auditUsecase) GetAuditEntries(ctx context.Context, req request.AuditLogFilterRequest) ([]response.AuditEntryResponse, error) {
+	if err := authz.Check(ctx, constants.PermAuditRead); err != nil {
+		return nil, err
+	}
+
+	filter := domainModel.AuditFilter{
+		ActorID:    req.ActorID,
+		ActorName:  req.Actor,
+		Action:     constants.AuditAction(req.Action),
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		RequestID:  req.RequestID,
+		Limit:      req.Limit,
+		Skip:       req.Offset,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	if req.From != "" {
+		from, err := time.Parse(constants.DateTimeLayout, req.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from format")
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse(constants.DateTimeLayout, req.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to format")
+		}
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, fmt.Errorf("to must not be before from")
+	}
+
+	entries, err := u.auditRepo.FindEntries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.AuditEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, mapAuditEntryToResponse(e))
+	}
+	return result, nil
+}
+
+func mapAuditEntryToResponse(entry domainModel.AuditEntry) response.AuditEntryResponse {
+	changes := make([]response.AuditChangeResponse, 0, len(entry.Changes))
+	for _, c := range entry.Changes {
+		changes = append(changes, response.AuditChangeResponse{Field: c.Field, Before: c.Before, After: c.After})
+	}
+
+	return response.AuditEntryResponse{
+		ID:         entry.ID,
+		ActorID:    entry.ActorID,
+		ActorName:  entry.ActorName,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		RequestID:  entry.RequestID,
+		Timestamp:  entry.Timestamp.UTC().Format(constants.DateTimeLayout),
+		Changes:    changes,
+	}
+}
+
+// diffAuditSnapshots flattens both snapshots through their JSON form and returns
+// the leaf fields that differ, sorted by path
+func diffAuditSnapshots(before interface{}, after interface{}) []domainModel.AuditChange {
+	beforeFields := flattenAuditSnapshot(before)
+	afterFields := flattenAuditSnapshot(after)
+
+	paths := make([]string, 0, len(beforeFields)+len(afterFields))
+	for path := range beforeFields {
+		paths = append(paths, path)
+	}
+	for path := range afterFields {
+		if _, ok := beforeFields[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	changes := []domainModel.AuditChange{}
+	for _, path := range paths {
+		b, a := beforeFields[path], afterFields[path]
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		changes = append(changes, domainModel.AuditChange{Field: path, Before: b, After: a})
+	}
+	return changes
+}
+
+func flattenAuditSnapshot(snapshot interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if snapshot == nil {
+		return fields
+	}
+
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		log.Printf("failed to encode audit snapshot: %v", err)
+		return fields
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return fields
+	}
+
+	flattenAuditValue("", doc, fields)
+	return fields
+}
+
+func flattenAuditValue(path string, value interface{}, fields map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && path != "" {
+			fields[path] = v
+		}
+		for key, item := range v {
+			flattenAuditValue(joinAuditPath(path, key), item, fields)
+		}
+	case []interface{}:
+		if len(v) == 0 && path != "" {
+			fields[path] = v
+		}
+		for i, item := range v {
+			flattenAuditValue(joinAuditPath(path, strconv.Itoa(i)), item, fields)
+		}
+	default:
+		if path != "" {
+			fields[path] = v
+		}
+	}
+}
+
+func joinAuditPath(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// nopAuditRecorder is used when a usecase is built without an audit log
+type nopAuditRecorder struct{}
+
+func (nopAuditRecorder) Record(ctx context.Context, action constants.AuditAction, targetType string, targetID string, before interface{}, after interface{}) {
+}
@@ -0,0 +1,114 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/usecase"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// adminContext is the context of an authenticated ADMIN, as AuthMiddleware builds it
+func adminContext() context.Context {
+	return audit.WithActor(context.TODO(), audit.Actor{UserID: "a1", UserName: "root", Role: constants.RoleAdmin})
+}
+
+func TestRecord_StoresActorRequestIDAndDiff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAuditRepository(ctrl)
+	uc := usecase.NewAuditUsecase(mockRepo)
+
+	ctx := audit.WithActor(context.TODO(), audit.Actor{UserID: "u1", UserName: "admin", Role: constants.RoleAdmin})
+	ctx = audit.WithRequestID(ctx, "req-42")
+
+	before := map[string]interface{}{"name": "Old", "connectors": []interface{}{map[string]interface{}{"price_per_unit": 5.0, "type": "AC"}}}
+	after := map[string]interface{}{"name": "New", "connectors": []interface{}{map[string]interface{}{"price_per_unit": 7.5, "type": "AC"}}, "company": "EV CO"}
+
+	mockRepo.EXPECT().
+		InsertEntry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry models.AuditEntry) error {
+			assert.Equal(t, "u1", entry.ActorID)
+			assert.Equal(t, "admin", entry.ActorName)
+			assert.Equal(t, "req-42", entry.RequestID)
+			assert.Equal(t, constants.AuditStationEdit, entry.Action)
+			assert.Equal(t, constants.AuditTargetStation, entry.TargetType)
+			assert.Equal(t, "station1", entry.TargetID)
+			assert.WithinDuration(t, time.Now(), entry.Timestamp, time.Minute)
+			assert.Equal(t, []models.AuditChange{
+				{Field: "company", Before: nil, After: "EV CO"},
+				{Field: "connectors.0.price_per_unit", Before: 5.0, After: 7.5},
+				{Field: "name", Before: "Old", After: "New"},
+			}, entry.Changes)
+			return nil
+		})
+
+	uc.Record(ctx, constants.AuditStationEdit, constants.AuditTargetStation, "station1", before, after)
+}
+
+func TestRecord_WithoutActorIsSystem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAuditRepository(ctrl)
+	uc := usecase.NewAuditUsecase(mockRepo)
+
+	mockRepo.EXPECT().
+		InsertEntry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry models.AuditEntry) error {
+			assert.Equal(t, constants.AuditSystemActor, entry.ActorName)
+			assert.Empty(t, entry.ActorID)
+			assert.Len(t, entry.Changes, 1)
+			return nil
+		})
+
+	uc.Record(context.TODO(), constants.AuditStationPurge, constants.AuditTargetStation, "", nil, map[string]interface{}{"purged": 2})
+}
+
+func TestGetAuditEntries_BuildsFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockAuditRepository(ctrl)
+	uc := usecase.NewAuditUsecase(mockRepo)
+
+	timestamp := time.Date(2025, 5, 1, 10, 30, 0, 0, time.UTC)
+	mockRepo.EXPECT().
+		FindEntries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter models.AuditFilter) ([]models.AuditEntry, error) {
+			assert.Equal(t, "admin", filter.ActorName)
+			assert.Equal(t, constants.AuditStationDelete, filter.Action)
+			assert.Equal(t, int64(100), filter.Limit)
+			assert.Equal(t, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), *filter.From)
+			assert.Nil(t, filter.To)
+			return []models.AuditEntry{{ID: "a1", ActorName: "admin", Action: constants.AuditStationDelete, Timestamp: timestamp}}, nil
+		})
+
+	entries, err := uc.GetAuditEntries(adminContext(), request.AuditLogFilterRequest{
+		Actor:  "admin",
+		Action: string(constants.AuditStationDelete),
+		From:   "2025-05-01T00:00:00",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "2025-05-01T10:30:00", entries[0].Timestamp)
+	assert.NotNil(t, entries[0].Changes)
+}
+
+func TestGetAuditEntries_RejectsReversedRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uc := usecase.NewAuditUsecase(mocks.NewMockAuditRepository(ctrl))
+
+	_, err := uc.GetAuditEntries(adminContext(), request.AuditLogFilterRequest{
+		From: "2025-05-02T00:00:00",
+		To:   "2025-05-01T00:00:00",
+	})
+	assert.EqualError(t, err, "to must not be before from")
+}
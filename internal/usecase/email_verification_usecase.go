@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/mail"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/utils"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+//go:generate mockgen -source=email_verification_usecase.go -destination=../mocks/mock_email_verification_usecase.go -package=mocks
+type EmailVerificationUsecase interface {
+	SendVerification(ctx context.Context, user *models.UserModel) error
+	VerifyEmail(ctx context.Context, req request.VerifyEmailRequest) error
+	ResendVerification(ctx context.Context, req request.ResendVerificationRequest) error
+}
+
+// BookingPolicy decides whether the account a booking is made for may book
+type BookingPolicy interface {
+	CheckCanBook(ctx context.Context, username string) error
+}
+
+var (
+	// ErrInvalidVerificationToken is returned for unknown, expired or already used verification tokens
+	ErrInvalidVerificationToken = errors.New("invalid or expired email verification token")
+	// ErrEmailAlreadyVerified is returned when asking for a new link after verifying
+	ErrEmailAlreadyVerified = errors.New("email is already verified")
+	// ErrEmailNotVerified is returned when an account with an unverified email tries to book
+	ErrEmailNotVerified = errors.New("email address must be verified before booking")
+)
+
+type emailVerificationUsecase struct {
+	userRepo repository.UserRepositoryInterface
+	mailer   mail.Mailer
+	auditLog AuditRecorder
+	config   configs.EmailVerificationConfig
+}
+
+func NewEmailVerificationUsecase(userRepo repository.UserRepositoryInterface, mailer mail.Mailer, auditLog AuditRecorder, config configs.EmailVerificationConfig) EmailVerificationUsecase {
+	if auditLog == nil {
+		auditLog = nopAuditRecorder{}
+	}
+	return &emailVerificationUsecase{userRepo: userRepo, mailer: mailer, auditLog: auditLog, config: config}
+}
+
+// SendVerification mails a new verification link; earlier links of the user stop working
+func (u *emailVerificationUsecase) SendVerification(ctx context.Context, user *models.UserModel) error {
+	token, err := utils.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+	if err := u.userRepo.SetEmailVerificationToken(ctx, user.ID, utils.HashToken(token), time.Now().Add(u.config.TokenTTL)); err != nil {
+		return err
+	}
+
+	link := u.config.URL + "?token=" + url.QueryEscape(token)
+	return u.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Verify your EV Charge Hub email address",
+		Body: fmt.Sprintf("Hello %s,\n\nPlease confirm your email address by opening the link below. It expires in %s.\n\n%s\n\n"+
+			"If you did not create an account, you can ignore this email.\n",
+			user.Username, u.config.TokenTTL, link),
+	})
+}
+
+// VerifyEmail marks the email of the token's account as verified; the token works once
+func (u *emailVerificationUsecase) VerifyEmail(ctx context.Context, req request.VerifyEmailRequest) error {
+	user, err := u.userRepo.VerifyEmailByTokenHash(ctx, utils.HashToken(req.Token), time.Now())
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrInvalidVerificationToken
+	}
+	u.auditLog.Record(userActorContext(ctx, user), constants.AuditUserEmailVerified, constants.AuditTargetUser, user.ID, nil, map[string]interface{}{"email": user.Email})
+	return nil
+}
+
+// ResendVerification mails a new link to the user, e.g. when the first one expired
+func (u *emailVerificationUsecase) ResendVerification(ctx context.Context, req request.ResendVerificationRequest) error {
+	user, err := u.userRepo.FindUserByID(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+	return u.SendVerification(ctx, user)
+}
+
+type verifiedEmailBookingPolicy struct {
+	userRepo repository.UserRepositoryInterface
+}
+
+// NewVerifiedEmailBookingPolicy only lets accounts with a verified email book
+func NewVerifiedEmailBookingPolicy(userRepo repository.UserRepositoryInterface) BookingPolicy {
+	return &verifiedEmailBookingPolicy{userRepo: userRepo}
+}
+
+func (p *verifiedEmailBookingPolicy) CheckCanBook(ctx context.Context, username string) error {
+	user, err := p.userRepo.FindUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if !user.EmailVerified {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package usecase_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/mail"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/usecase"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEmailVerificationConfig = configs.EmailVerificationConfig{TokenTTL: 48 * time.Hour, URL: "https://api.example.com/users/verify", RequiredForBooking: true}
+
+func TestSendVerification_StoresHashAndMailsLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mailer := mail.NewMemoryMailer()
+	uc := usecase.NewEmailVerificationUsecase(mockUserRepo, mailer, nil, testEmailVerificationConfig)
+
+	var storedHash string
+	mockUserRepo.EXPECT().
+		SetEmailVerificationToken(gomock.Any(), "u1", gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, _ string, hash string, expiresAt time.Time) {
+			storedHash = hash
+			assert.WithinDuration(t, time.Now().Add(48*time.Hour), expiresAt, 5*time.Second)
+		}).
+		Return(nil)
+
+	err := uc.SendVerification(context.TODO(), &models.UserModel{ID: "u1", Username: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+
+	sent := mailer.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, "alice@example.com", sent[0].To)
+	start := strings.Index(sent[0].Body, testEmailVerificationConfig.URL)
+	require.NotEqual(t, -1, start)
+	link, _, _ := strings.Cut(sent[0].Body[start:], "\n")
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, sha256Hex(parsed.Query().Get("token")), storedHash)
+}
+
+func TestVerifyEmail_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewEmailVerificationUsecase(mockUserRepo, mail.NewMemoryMailer(), mockAudit, testEmailVerificationConfig)
+
+	mockUserRepo.EXPECT().VerifyEmailByTokenHash(gomock.Any(), sha256Hex("verify-token"), gomock.Any()).
+		Return(&models.UserModel{ID: "u1", Username: "alice", Email: "alice@example.com", EmailVerified: true}, nil)
+	mockAudit.EXPECT().Record(gomock.Any(), constants.AuditUserEmailVerified, constants.AuditTargetUser, "u1", nil, gomock.Any())
+
+	err := uc.VerifyEmail(context.TODO(), request.VerifyEmailRequest{Token: "verify-token"})
+	assert.NoError(t, err)
+}
+
+func TestVerifyEmail_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewEmailVerificationUsecase(mockUserRepo, mail.NewMemoryMailer(), nil, testEmailVerificationConfig)
+
+	mockUserRepo.EXPECT().VerifyEmailByTokenHash(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	err := uc.VerifyEmail(context.TODO(), request.VerifyEmailRequest{Token: "expired-or-used"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidVerificationToken)
+}
+
+func TestResendVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mailer := mail.NewMemoryMailer()
+	uc := usecase.NewEmailVerificationUsecase(mockUserRepo, mailer, nil, testEmailVerificationConfig)
+
+	mockUserRepo.EXPECT().FindUserByID(gomock.Any(), "verified").Return(&models.UserModel{ID: "verified", EmailVerified: true}, nil)
+	mockUserRepo.EXPECT().FindUserByID(gomock.Any(), "pending").Return(&models.UserModel{ID: "pending", Email: "bob@example.com"}, nil)
+	mockUserRepo.EXPECT().SetEmailVerificationToken(gomock.Any(), "pending", gomock.Any(), gomock.Any()).Return(nil)
+
+	err := uc.ResendVerification(context.TODO(), request.ResendVerificationRequest{UserID: "verified"})
+	assert.ErrorIs(t, err, usecase.ErrEmailAlreadyVerified)
+
+	err = uc.ResendVerification(context.TODO(), request.ResendVerificationRequest{UserID: "pending"})
+	assert.NoError(t, err)
+	require.Len(t, mailer.Sent(), 1)
+	assert.Equal(t, "bob@example.com", mailer.Sent()[0].To)
+}
+
+func TestVerifiedEmailBookingPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	policy := usecase.NewVerifiedEmailBookingPolicy(mockUserRepo)
+
+	mockUserRepo.EXPECT().FindUserByUsername(gomock.Any(), "alice").Return(&models.UserModel{Username: "alice", EmailVerified: true}, nil)
+	mockUserRepo.EXPECT().FindUserByUsername(gomock.Any(), "bob").Return(&models.UserModel{Username: "bob"}, nil)
+	mockUserRepo.EXPECT().FindUserByUsername(gomock.Any(), "ghost").Return(nil, nil)
+
+	assert.NoError(t, policy.CheckCanBook(context.TODO(), "alice"))
+	assert.ErrorIs(t, policy.CheckCanBook(context.TODO(), "bob"), usecase.ErrEmailNotVerified)
+	assert.ErrorIs(t, policy.CheckCanBook(context.TODO(), "ghost"), usecase.ErrUserNotFound)
+}
@@ -0,0 +1,405 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/internal/authz"
+	"Ev-Charge-Hub/Server/internal/constants"
+	domainModel "Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidImportFile is returned when an import file cannot be read as a whole
+var ErrInvalidImportFile = errors.New("invalid import file")
+
+// importRequiredColumns must be in a CSV import. A station spans one line per connector; station
+// columns are read from its first line.
+var importRequiredColumns = []string{"external_ref", "name", "latitude", "longitude", "company", "connector_type", "plug_name", "price_per_unit", "power_output"}
+
+// stationImportRow is one station read from an import file and the problems found while reading it
+type stationImportRow struct {
+	row     int
+	station request.EVStationRequest
+	errors  []response.FieldError
+}
+
+// ImportStations validates every station in the file and upserts the valid ones by external_ref,
+// so importing the same file twice leaves the stations unchanged. Nothing is written on a dry run.
+func (u *evStationUsecase) ImportStations(ctx context.Context, req request.ImportStationsRequest) (*response.StationImportReport, error) {
+	if err := authz.Check(ctx, constants.PermStationImport); err != nil {
+		return nil, err
+	}
+
+	format := req.Format
+	if format == "" {
+		format = detectImportFormat(req.Data)
+	}
+
+	var rows []stationImportRow
+	var err error
+	switch format {
+	case constants.ImportFormatJSON:
+		rows, err = readImportJSON(req.Data)
+	case constants.ImportFormatCSV:
+		rows, err = readImportCSV(req.Data)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidImportFile, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no stations found", ErrInvalidImportFile)
+	}
+
+	return u.importRows(ctx, rows, req.DryRun, nil)
+}
+
+// importRows validates and upserts the rows in order and reports the outcome of each. With a
+// deduper, rows that do not update a station of their own are skipped when they match a nearby one.
+func (u *evStationUsecase) importRows(ctx context.Context, rows []stationImportRow, dryRun bool, dedupe *stationDeduper) (*response.StationImportReport, error) {
+	report := &response.StationImportReport{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   make([]response.StationImportRowResult, 0, len(rows)),
+	}
+	firstRow := map[string]int{}
+	for _, row := range rows {
+		result := response.StationImportRowResult{Row: row.row, ExternalRef: row.station.ExternalRef}
+
+		fieldErrors := row.errors
+		if len(fieldErrors) == 0 {
+			fieldErrors = validateImportedStation(row.station)
+		}
+		if ref := row.station.ExternalRef; ref != "" {
+			if first, ok := firstRow[ref]; ok {
+				fieldErrors = append(fieldErrors, response.FieldError{Field: "external_ref", Message: fmt.Sprintf("already used by row %d", first)})
+			} else {
+				firstRow[ref] = row.row
+			}
+		}
+
+		if len(fieldErrors) == 0 && dedupe != nil {
+			duplicateOf, err := dedupe.find(ctx, row.station)
+			if err != nil {
+				return nil, err
+			}
+			if duplicateOf != "" {
+				result.Action = constants.ImportActionDuplicate
+				result.DuplicateOf = duplicateOf
+			}
+		}
+
+		if len(fieldErrors) == 0 && result.Action == "" {
+			var err error
+			result.Action, result.StationID, fieldErrors, err = u.importStation(ctx, row.station, dryRun)
+			if err != nil {
+				return nil, err
+			}
+			if dedupe != nil && len(fieldErrors) == 0 {
+				dedupe.add(row.station)
+			}
+		}
+		if len(fieldErrors) > 0 {
+			result.Action = constants.ImportActionError
+			result.Errors = fieldErrors
+		}
+
+		switch result.Action {
+		case constants.ImportActionCreate:
+			report.Created++
+		case constants.ImportActionUpdate:
+			report.Updated++
+		case constants.ImportActionUnchanged:
+			report.Unchanged++
+		case constants.ImportActionDuplicate:
+			report.Duplicates++
+		default:
+			report.Failed++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+	return report, nil
+}
+
+// importStation creates or updates the station with the row's external_ref. Problems that only
+// affect this row come back as field errors; the error is reserved for failures that stop the import.
+func (u *evStationUsecase) importStation(ctx context.Context, station request.EVStationRequest, dryRun bool) (string, string, []response.FieldError, error) {
+	existingDB, err := u.stationRepo.FindStationByExternalRef(ctx, station.ExternalRef)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	if existingDB == nil {
+		fieldErrors, err := u.checkConnectorIDs(ctx, connectorRequestIDs(station.Connectors))
+		if err != nil || len(fieldErrors) > 0 {
+			return "", "", fieldErrors, err
+		}
+		if dryRun {
+			return constants.ImportActionCreate, "", nil, nil
+		}
+		stationDomain, err := mapRequestToDomain(station)
+		if err != nil {
+			return "", "", []response.FieldError{{Field: "status", Message: err.Error()}}, nil
+		}
+		stationID, err := u.createStation(ctx, stationDomain, constants.AuditStationImport)
+		if err != nil {
+			return "", "", nil, err
+		}
+		return constants.ImportActionCreate, stationID, nil, nil
+	}
+
+	stationID := existingDB.ID.Hex()
+	existing := mapStationDBToDomain(*existingDB)
+	current := mapDomainToStationRequest(existing)
+
+	status, err := mapStatusRequestToDomain(station.Status)
+	if err != nil {
+		return "", stationID, []response.FieldError{{Field: "status", Message: err.Error()}}, nil
+	}
+	fieldErrors, err := u.checkConnectorIDs(ctx, newConnectorIDs(existing.Connectors, station.Connectors))
+	if err != nil || len(fieldErrors) > 0 {
+		return "", stationID, fieldErrors, err
+	}
+	connectors, err := mergeImportedConnectors(existing.Connectors, station.Connectors)
+	if err != nil {
+		return "", stationID, []response.FieldError{{Field: "connectors", Message: err.Error()}}, nil
+	}
+	existing.Name = station.Name
+	existing.Latitude = station.Latitude
+	existing.Longitude = station.Longitude
+	existing.Company = station.Company
+	existing.Status = status
+	existing.Connectors = connectors
+
+	if reflect.DeepEqual(mapDomainToStationRequest(existing), current) {
+		return constants.ImportActionUnchanged, stationID, nil, nil
+	}
+	if dryRun {
+		return constants.ImportActionUpdate, stationID, nil, nil
+	}
+
+	if err := u.stationRepo.EditStation(ctx, existing); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return "", stationID, []response.FieldError{{Message: "station was modified during the import, run it again"}}, nil
+		}
+		return "", "", nil, err
+	}
+	if _, err := u.reloadAudited(ctx, constants.AuditStationImport, stationID, existingDB); err != nil {
+		return "", "", nil, err
+	}
+	return constants.ImportActionUpdate, stationID, nil, nil
+}
+
+// newConnectorIDs lists, by position in the row, the supplied connector IDs the station does not
+// have yet; the other positions are left empty
+func newConnectorIDs(existing []domainModel.Connector, connReqs []request.ConnectorRequest) []string {
+	known := make(map[string]bool, len(existing))
+	for _, c := range existing {
+		known[c.ConnectorID] = true
+	}
+
+	ids := make([]string, len(connReqs))
+	for i, c := range connReqs {
+		if !known[c.ConnectorID] {
+			ids[i] = c.ConnectorID
+		}
+	}
+	return ids
+}
+
+// validateImportedStation adds the import-only rules to validateStationDocument
+func validateImportedStation(station request.EVStationRequest) []response.FieldError {
+	fieldErrors := validateStationDocument(station)
+	if strings.TrimSpace(station.ExternalRef) == "" {
+		fieldErrors = append(fieldErrors, response.FieldError{Field: "external_ref", Message: "is required for imports"})
+	}
+	for i, c := range station.Connectors {
+		if c.Booking != nil {
+			fieldErrors = append(fieldErrors, response.FieldError{Field: fmt.Sprintf("connectors[%d].booking", i), Message: "bookings cannot be imported"})
+		}
+	}
+	return fieldErrors
+}
+
+// detectImportFormat treats data starting with a JSON array as JSON and anything else as CSV
+func detectImportFormat(data []byte) string {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		return constants.ImportFormatJSON
+	}
+	return constants.ImportFormatCSV
+}
+
+// readImportJSON reads an array of EVStationRequest; Row is the position in the array, from 1
+func readImportJSON(data []byte) ([]stationImportRow, error) {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), &elements); err != nil {
+		return nil, fmt.Errorf("%w: expected a JSON array of stations: %v", ErrInvalidImportFile, err)
+	}
+
+	rows := make([]stationImportRow, 0, len(elements))
+	for i, element := range elements {
+		row := stationImportRow{row: i + 1}
+		decoder := json.NewDecoder(bytes.NewReader(element))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&row.station); err != nil {
+			row.errors = []response.FieldError{{Message: err.Error()}}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// readImportCSV reads a CSV file with a header line. Lines sharing an external_ref are one station;
+// a line without connector_type and plug_name adds no connector.
+func readImportCSV(data []byte) ([]stationImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidImportFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+
+	columns := map[string]int{}
+	known := map[string]bool{}
+	for _, name := range append(append([]string{}, constants.StationCSVStationColumns...), constants.StationCSVConnectorColumns...) {
+		known[name] = true
+	}
+	exportOnly := map[string]bool{}
+	for _, name := range constants.StationCSVExportOnlyColumns {
+		exportOnly[name] = true
+	}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if exportOnly[name] {
+			continue
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidImportFile, name)
+		}
+		columns[name] = i
+	}
+	for _, name := range importRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidImportFile, name)
+		}
+	}
+
+	var rows []*stationImportRow
+	byRef := map[string]*stationImportRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		ref := field("external_ref")
+		row, ok := byRef[ref]
+		if !ok || ref == "" {
+			row = &stationImportRow{row: line}
+			row.station, row.errors = readImportCSVStation(field, line)
+			rows = append(rows, row)
+			byRef[ref] = row
+		}
+		if err != nil {
+			row.errors = append(row.errors, response.FieldError{Message: fmt.Sprintf("line %d: %v", line, csv.ErrFieldCount)})
+			continue
+		}
+
+		connector, connectorErrors := readImportCSVConnector(field, line, len(row.station.Connectors))
+		row.errors = append(row.errors, connectorErrors...)
+		if connector != nil {
+			row.station.Connectors = append(row.station.Connectors, *connector)
+		}
+	}
+
+	result := make([]stationImportRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *row)
+	}
+	return result, nil
+}
+
+func readImportCSVStation(field func(string) string, line int) (request.EVStationRequest, []response.FieldError) {
+	var fieldErrors []response.FieldError
+	parseFloat := func(name string) float64 {
+		value, err := strconv.ParseFloat(field(name), 64)
+		if err != nil {
+			fieldErrors = append(fieldErrors, response.FieldError{Field: name, Message: fmt.Sprintf("line %d: %q is not a number", line, field(name))})
+		}
+		return value
+	}
+
+	station := request.EVStationRequest{
+		ExternalRef: field("external_ref"),
+		Name:        field("name"),
+		Latitude:    parseFloat("latitude"),
+		Longitude:   parseFloat("longitude"),
+		Company:     field("company"),
+		Status: request.StationStatusRequest{
+			TimeZone:   field("time_zone"),
+			OpenHours:  field("open_hours"),
+			CloseHours: field("close_hours"),
+		},
+		Connectors: []request.ConnectorRequest{},
+	}
+	if value := field("always_open"); value != "" {
+		alwaysOpen, err := strconv.ParseBool(value)
+		if err != nil {
+			fieldErrors = append(fieldErrors, response.FieldError{Field: "status.always_open", Message: fmt.Sprintf("line %d: %q is not a boolean", line, value)})
+		}
+		station.Status.AlwaysOpen = alwaysOpen
+	}
+	return station, fieldErrors
+}
+
+func readImportCSVConnector(field func(string) string, line int, index int) (*request.ConnectorRequest, []response.FieldError) {
+	if field("connector_type") == "" && field("plug_name") == "" {
+		return nil, nil
+	}
+
+	var fieldErrors []response.FieldError
+	path := fmt.Sprintf("connectors[%d]", index)
+	price, err := strconv.ParseFloat(field("price_per_unit"), 64)
+	if err != nil {
+		fieldErrors = append(fieldErrors, response.FieldError{Field: path + ".price_per_unit", Message: fmt.Sprintf("line %d: %q is not a number", line, field("price_per_unit"))})
+	}
+	power, err := strconv.Atoi(field("power_output"))
+	if err != nil {
+		fieldErrors = append(fieldErrors, response.FieldError{Field: path + ".power_output", Message: fmt.Sprintf("line %d: %q is not a whole number", line, field("power_output"))})
+	}
+
+	return &request.ConnectorRequest{
+		ConnectorID:  field("connector_id"),
+		Type:         constants.ConnectorType(strings.ToUpper(field("connector_type"))),
+		PlugName:     constants.PlugName(field("plug_name")),
+		PricePerUnit: price,
+		PowerOutput:  power,
+	}, fieldErrors
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/internal/authz"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// defaultOCMDedupeRadius is how close, in metres, a similarly named station must be to count as a duplicate
+const defaultOCMDedupeRadius = 50
+
+// ocmExternalRefPrefix namespaces Open Charge Map IDs so re-imports update the same stations
+const ocmExternalRefPrefix = "ocm:"
+
+// ocmSite is the part of an Open Charge Map POI that we import
+type ocmSite struct {
+	ID           int `json:"ID"`
+	OperatorInfo *struct {
+		Title string `json:"Title"`
+	} `json:"OperatorInfo"`
+	AddressInfo *struct {
+		Title     string  `json:"Title"`
+		Latitude  float64 `json:"Latitude"`
+		Longitude float64 `json:"Longitude"`
+	} `json:"AddressInfo"`
+	Connections []ocmConnection `json:"Connections"`
+}
+
+type ocmConnection struct {
+	ConnectionTypeID int `json:"ConnectionTypeID"`
+	ConnectionType   *struct {
+		Title string `json:"Title"`
+	} `json:"ConnectionType"`
+	PowerKW  *float64 `json:"PowerKW"`
+	Quantity *int     `json:"Quantity"`
+}
+
+// ImportOpenChargeMap imports the sites of an Open Charge Map JSON export as stations with the
+// external_ref "ocm:<ID>". Connections of unsupported types are left out and counted in the report.
+func (u *evStationUsecase) ImportOpenChargeMap(ctx context.Context, req request.ImportOpenChargeMapRequest) (*response.StationImportReport, error) {
+	if err := authz.Check(ctx, constants.PermStationImport); err != nil {
+		return nil, err
+	}
+
+	rows, unmapped, err := readOpenChargeMap(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no sites found", ErrInvalidImportFile)
+	}
+
+	radius := req.DedupeRadius
+	if radius <= 0 {
+		radius = defaultOCMDedupeRadius
+	}
+	report, err := u.importRows(ctx, rows, req.DryRun, &stationDeduper{stationRepo: u.stationRepo, radius: radius})
+	if err != nil {
+		return nil, err
+	}
+	report.UnmappedConnectionTypes = unmapped
+	return report, nil
+}
+
+// readOpenChargeMap maps every site of the export to a station; Row is the position in the array, from 1
+func readOpenChargeMap(req request.ImportOpenChargeMapRequest) ([]stationImportRow, []response.UnmappedConnectionType, error) {
+	var sites []ocmSite
+	if err := json.Unmarshal(bytes.TrimPrefix(req.Data, []byte("\xef\xbb\xbf")), &sites); err != nil {
+		return nil, nil, fmt.Errorf("%w: expected an Open Charge Map JSON export: %v", ErrInvalidImportFile, err)
+	}
+
+	unmapped := map[int]*response.UnmappedConnectionType{}
+	rows := make([]stationImportRow, 0, len(sites))
+	for i, site := range sites {
+		row := stationImportRow{row: i + 1}
+		row.station = request.EVStationRequest{
+			ExternalRef: ocmExternalRefPrefix + strconv.Itoa(site.ID),
+			Company:     constants.OCMUnknownOperator,
+			Status:      request.StationStatusRequest{TimeZone: req.TimeZone, AlwaysOpen: true},
+			Connectors:  []request.ConnectorRequest{},
+		}
+		if site.OperatorInfo != nil && strings.TrimSpace(site.OperatorInfo.Title) != "" {
+			row.station.Company = strings.TrimSpace(site.OperatorInfo.Title)
+		}
+		if site.AddressInfo == nil {
+			row.errors = append(row.errors, response.FieldError{Field: "AddressInfo", Message: "is missing"})
+		} else {
+			row.station.Name = strings.TrimSpace(site.AddressInfo.Title)
+			row.station.Latitude = site.AddressInfo.Latitude
+			row.station.Longitude = site.AddressInfo.Longitude
+		}
+
+		for j, connection := range site.Connections {
+			plug, ok := constants.OCMConnectionPlug(connection.ConnectionTypeID)
+			if !ok {
+				entry, seen := unmapped[connection.ConnectionTypeID]
+				if !seen {
+					entry = &response.UnmappedConnectionType{ID: connection.ConnectionTypeID}
+					if connection.ConnectionType != nil {
+						entry.Title = connection.ConnectionType.Title
+					}
+					unmapped[connection.ConnectionTypeID] = entry
+				}
+				entry.Connections++
+				continue
+			}
+			if connection.PowerKW == nil || *connection.PowerKW <= 0 {
+				row.errors = append(row.errors, response.FieldError{Field: fmt.Sprintf("Connections[%d].PowerKW", j), Message: "is missing"})
+				continue
+			}
+
+			connectorType, _ := plug.ConnectorType()
+			quantity := 1
+			if connection.Quantity != nil && *connection.Quantity > 1 {
+				quantity = *connection.Quantity
+			}
+			for k := 0; k < quantity; k++ {
+				row.station.Connectors = append(row.station.Connectors, request.ConnectorRequest{
+					Type:         connectorType,
+					PlugName:     plug,
+					PricePerUnit: req.PricePerUnit,
+					PowerOutput:  int(math.Round(*connection.PowerKW)),
+				})
+			}
+		}
+		if len(row.station.Connectors) == 0 && len(row.errors) == 0 {
+			row.errors = append(row.errors, response.FieldError{Field: "Connections", Message: "no connection of a supported type"})
+		}
+		rows = append(rows, row)
+	}
+
+	unmappedTypes := make([]response.UnmappedConnectionType, 0, len(unmapped))
+	for _, entry := range unmapped {
+		unmappedTypes = append(unmappedTypes, *entry)
+	}
+	sort.Slice(unmappedTypes, func(i, j int) bool {
+		if unmappedTypes[i].Connections != unmappedTypes[j].Connections {
+			return unmappedTypes[i].Connections > unmappedTypes[j].Connections
+		}
+		return unmappedTypes[i].ID < unmappedTypes[j].ID
+	})
+	return rows, unmappedTypes, nil
+}
+
+// stationDeduper finds stations that already describe an imported site: within radius metres
+// and with a similar name. Stations carrying the site's own external_ref are not duplicates,
+// so re-importing a dump updates the stations it created.
+type stationDeduper struct {
+	stationRepo repository.EVStationRepository
+	radius      float64
+	imported    []request.EVStationRequest
+}
+
+// find returns the ID of the matching station, or the external_ref of an earlier row of the file
+func (d *stationDeduper) find(ctx context.Context, station request.EVStationRequest) (string, error) {
+	for _, other := range d.imported {
+		if other.ExternalRef != station.ExternalRef && d.matches(station, other.Name, other.Latitude, other.Longitude) {
+			return other.ExternalRef, nil
+		}
+	}
+
+	latDelta := d.radius / metresPerDegree
+	lngDelta := 180.0
+	if cos := math.Cos(station.Latitude * math.Pi / 180); cos > 0.01 {
+		lngDelta = latDelta / cos
+	}
+	candidates, err := d.stationRepo.FindStationsInArea(ctx,
+		station.Latitude-latDelta, station.Latitude+latDelta,
+		station.Longitude-lngDelta, station.Longitude+lngDelta)
+	if err != nil {
+		return "", err
+	}
+	for _, candidate := range candidates {
+		if candidate.ExternalRef != station.ExternalRef && d.matches(station, candidate.Name, candidate.Latitude, candidate.Longitude) {
+			return candidate.ID.Hex(), nil
+		}
+	}
+	return "", nil
+}
+
+// add remembers an imported row so later rows of the same file are checked against it
+func (d *stationDeduper) add(station request.EVStationRequest) {
+	d.imported = append(d.imported, station)
+}
+
+func (d *stationDeduper) matches(station request.EVStationRequest, name string, latitude float64, longitude float64) bool {
+	return distanceMetres(station.Latitude, station.Longitude, latitude, longitude) <= d.radius &&
+		similarStationNames(station.Name, name)
+}
+
+// similarStationNames compares names by their letters and digits only; one containing the
+// other also matches, so "Central Plaza" and "Central Plaza Charging" are the same site
+func similarStationNames(a string, b string) bool {
+	a, b = normaliseStationName(a), normaliseStationName(b)
+	if a == "" || b == "" {
+		return false
+	}
+	return a == b || strings.Contains(a, b) || strings.Contains(b, a)
+}
+
+func normaliseStationName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// metresPerDegree is the length of one degree of latitude
+const metresPerDegree = 111320.0
+
+// distanceMetres is the great-circle (haversine) distance between two coordinates
+func distanceMetres(lat1 float64, lng1 float64, lat2 float64, lng2 float64) float64 {
+	const earthRadius = 6371000.0
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLng := (lng2 - lng1) * toRad
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
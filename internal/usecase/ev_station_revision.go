@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/internal/authz"
+	"Ev-Charge-Hub/Server/internal/constants"
+	domainModel "Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/internal/repository/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrRevisionNotFound is returned when a station has no revision for the requested version or time
+var ErrRevisionNotFound = errors.New("station revision not found")
+
+func (u *evStationUsecase) GetStationRevisions(ctx context.Context, request request.GetStationRevisionsRequest) ([]response.StationRevisionResponse, error) {
+	if err := authz.Check(ctx, constants.PermStationHistory); err != nil {
+		return nil, err
+	}
+
+	revisions, err := u.stationRepo.FindStationRevisions(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.StationRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		result = append(result, mapStationRevisionToResponse(revision))
+	}
+	return result, nil
+}
+
+// GetStationAsOf returns the last revision of the station recorded at or before the given time.
+// Stations not written since revisions were introduced have none; for them the current document
+// answers as long as it was last updated at or before that time.
+func (u *evStationUsecase) GetStationAsOf(ctx context.Context, request request.GetStationAsOfRequest) (*response.EVStationResponse, error) {
+	if err := authz.Check(ctx, constants.PermStationHistory); err != nil {
+		return nil, err
+	}
+
+	asOf, err := time.Parse(constants.DateTimeLayout, request.AsOf)
+	if err != nil {
+		asOf, err = time.Parse(time.RFC3339, request.AsOf)
+		if err != nil {
+			return nil, fmt.Errorf("invalid as_of format, use %s", constants.DateTimeLayout)
+		}
+	}
+
+	revision, err := u.stationRepo.FindStationRevisionAsOf(ctx, request.ID, asOf)
+	if errors.Is(err, repository.ErrRevisionNotFound) {
+		return u.currentStationAsOf(ctx, request.ID, asOf)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := mapStationDBToResponse(revision.Station)
+	return &resp, nil
+}
+
+func (u *evStationUsecase) currentStationAsOf(ctx context.Context, id string, asOf time.Time) (*response.EVStationResponse, error) {
+	station, err := u.stationRepo.FindStationByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stationLastUpdated(*station).After(asOf) {
+		return nil, ErrRevisionNotFound
+	}
+
+	resp := mapStationDBToResponse(*station)
+	return &resp, nil
+}
+
+// RevertStation writes the name, location, company, opening hours and connectors of an earlier
+// revision back as a new version. Live state (bookings, maintenance windows) is kept.
+func (u *evStationUsecase) RevertStation(ctx context.Context, request request.RevertStationRequest) (*response.EVStationResponse, error) {
+	if err := authz.Check(ctx, constants.PermStationHistory); err != nil {
+		return nil, err
+	}
+
+	current, err := u.stationRepo.FindStationByID(ctx, request.ID)
+	if err != nil {
+		return nil, fmt.Errorf("station not found")
+	}
+	if err := checkStationVersion(request.ExpectedVersion, current.Version); err != nil {
+		return nil, err
+	}
+
+	revision, err := u.stationRepo.FindStationRevision(ctx, request.ID, request.Version)
+	if err != nil {
+		return nil, mapRevisionError(err)
+	}
+
+	target := mapStationDBToDomain(revision.Station)
+	reverted := mapStationDBToDomain(*current)
+	connectors, err := revertConnectors(reverted.Connectors, target.Connectors)
+	if err != nil {
+		return nil, err
+	}
+	reverted.Name = target.Name
+	reverted.Latitude = target.Latitude
+	reverted.Longitude = target.Longitude
+	reverted.Company = target.Company
+	reverted.Status = target.Status
+	reverted.Connectors = connectors
+
+	// the revision may predate the current rules, and its connector IDs may have been given out
+	// again since the connectors were dropped
+	if err := stationValidationError(validateStationDocument(mapDomainToStationRequest(reverted))); err != nil {
+		return nil, err
+	}
+	fieldErrors, err := u.checkConnectorIDs(ctx, droppedConnectorIDs(current, connectors))
+	if err != nil {
+		return nil, err
+	}
+	if err := stationValidationError(fieldErrors); err != nil {
+		return nil, err
+	}
+
+	if err := u.stationRepo.EditStation(ctx, reverted); err != nil {
+		return nil, mapStationWriteError(err)
+	}
+
+	return u.reloadAudited(ctx, constants.AuditStationRevert, request.ID, current)
+}
+
+// revertConnectors takes the connector specs of the revision and keeps the bookings of connectors
+// that still exist. Dropping a connector that is booked right now is refused.
+func revertConnectors(current []domainModel.Connector, target []domainModel.Connector) ([]domainModel.Connector, error) {
+	bookings := map[string]*domainModel.Booking{}
+	for _, c := range current {
+		bookings[c.ConnectorID] = c.Booking
+	}
+
+	kept := map[string]bool{}
+	result := make([]domainModel.Connector, 0, len(target))
+	for _, c := range target {
+		c.Booking = bookings[c.ConnectorID]
+		kept[c.ConnectorID] = true
+		result = append(result, c)
+	}
+
+	now := time.Now()
+	for _, c := range current {
+		if !kept[c.ConnectorID] && c.Booking != nil && c.Booking.BookingEndTime.After(now) {
+			return nil, fmt.Errorf("connector %s has an active booking until %s and is not part of the revision",
+				c.ConnectorID, c.Booking.BookingEndTime.Format(constants.DateTimeLayout))
+		}
+	}
+	return result, nil
+}
+
+// droppedConnectorIDs lists, by position, the IDs of reverted connectors the station no longer has
+func droppedConnectorIDs(current *models.EVStationDB, connectors []domainModel.Connector) []string {
+	onStation := make(map[string]bool, len(current.Connectors))
+	for _, c := range current.Connectors {
+		onStation[c.ConnectorID] = true
+	}
+
+	ids := make([]string, len(connectors))
+	for i, c := range connectors {
+		if !onStation[c.ConnectorID] {
+			ids[i] = c.ConnectorID
+		}
+	}
+	return ids
+}
+
+func mapRevisionError(err error) error {
+	if errors.Is(err, repository.ErrRevisionNotFound) {
+		return ErrRevisionNotFound
+	}
+	return err
+}
+
+func mapStationRevisionToResponse(revision models.StationRevisionDB) response.StationRevisionResponse {
+	return response.StationRevisionResponse{
+		Version:    revision.Version,
+		RecordedAt: revision.RecordedAt.UTC().Format(constants.DateTimeLayout),
+		RecordedBy: revision.RecordedBy,
+		RequestID:  revision.RequestID,
+		Station:    mapStationDBToResponse(revision.Station),
+	}
+}
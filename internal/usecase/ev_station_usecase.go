@@ -1,69 +1,199 @@
 package usecase
 
 import (
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/authz"
+	"Ev-Charge-Hub/Server/internal/constants"
 	domainModel "Ev-Charge-Hub/Server/internal/domain/models"
 	"Ev-Charge-Hub/Server/internal/dto/request"
 	"Ev-Charge-Hub/Server/internal/dto/response"
 	"Ev-Charge-Hub/Server/internal/repository"
 	"Ev-Charge-Hub/Server/internal/repository/models"
+	"Ev-Charge-Hub/Server/utils"
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
+
 //go:generate mockgen -source=ev_station_usecase.go -destination=../mocks/mock_ev_station_usecase.go -package=mocks
 type EVStationUsecase interface {
 	FilterStations(ctx context.Context, request request.StationFilterRequest) ([]response.EVStationResponse, error)
+	ExportStations(ctx context.Context, request request.StationFilterRequest, emit func(station response.EVStationResponse) error) error
 	ShowAllStations(ctx context.Context) ([]response.EVStationResponse, error)
 	GetStationByID(ctx context.Context, request request.GetStationByIDRequest) (*response.EVStationResponse, error)
 	CreateStation(ctx context.Context, request request.EVStationRequest) error
 	EditStation(ctx context.Context, req request.EditStationRequest) (*response.EVStationResponse, error)
+	PatchStation(ctx context.Context, req request.PatchStationRequest) (*response.EVStationResponse, error)
 	RemoveStation(ctx context.Context, request request.RemoveStationRequest) error
+	GetDeletedStations(ctx context.Context) ([]response.EVStationResponse, error)
+	RestoreStation(ctx context.Context, request request.RestoreStationRequest) (*response.EVStationResponse, error)
+	PurgeDeletedStations(ctx context.Context, retention time.Duration) (int64, error)
 	SetBooking(ctx context.Context, request request.SetBookingRequest) error
 	GetBookingByUserName(ctx context.Context, request request.GetBookingRequest) (*response.BookingResponse, error)
 	GetBookingsByUserName(ctx context.Context, request request.GetBookingsRequest) ([]response.BookingResponse, error)
 	GetStationByConnectorID(ctx context.Context, request request.GetStationByConnectorIDRequest) (*response.EVStationResponse, error)
 	GetStationByUserName(ctx context.Context, request request.GetStationByUsernameRequest) (*response.EVStationResponse, error)
+	EstimateCharge(ctx context.Context, request request.ChargeEstimateRequest) (*response.ChargeEstimateResponse, error)
+	AddConnector(ctx context.Context, request request.StationConnectorRequest) (*response.EVStationResponse, error)
+	EditConnector(ctx context.Context, request request.StationConnectorRequest) (*response.EVStationResponse, error)
+	RemoveConnector(ctx context.Context, request request.RemoveConnectorRequest) (*response.EVStationResponse, error)
+	GetStationRevisions(ctx context.Context, request request.GetStationRevisionsRequest) ([]response.StationRevisionResponse, error)
+	GetStationAsOf(ctx context.Context, request request.GetStationAsOfRequest) (*response.EVStationResponse, error)
+	RevertStation(ctx context.Context, request request.RevertStationRequest) (*response.EVStationResponse, error)
+	ImportStations(ctx context.Context, request request.ImportStationsRequest) (*response.StationImportReport, error)
+	ImportOpenChargeMap(ctx context.Context, request request.ImportOpenChargeMapRequest) (*response.StationImportReport, error)
 }
 
+var (
+	// ErrInvalidStationDocument is returned when a patched station fails validation
+	ErrInvalidStationDocument = errors.New("invalid station document")
+	// ErrPreconditionFailed is returned when the caller's If-Match version is no longer current
+	ErrPreconditionFailed = errors.New("station has been modified since it was read")
+	// ErrStationHasActiveBookings is returned when deleting a booked station without force
+	ErrStationHasActiveBookings = errors.New("station has active bookings")
+	// ErrInvalidStationQuery is returned for filter and charge estimate parameters that are unknown
+	// or do not fit together
+	ErrInvalidStationQuery = errors.New("invalid query")
+	// ErrConnectorNotFound is returned when no live station has the requested connector
+	ErrConnectorNotFound = errors.New("connector not found")
+	// ErrExternalRefInUse is returned when another live station already has the external_ref
+	ErrExternalRefInUse = errors.New("external_ref already in use")
+	// ErrConnectorIDInUse is returned for a connector ID that another live station already has
+	ErrConnectorIDInUse = errors.New("connector_id already in use")
+	// ErrStationNotFound is returned when the station to change does not exist or is in the trash
+	ErrStationNotFound = errors.New("station not found")
+)
+
+// default state of charge window (%) used when a vehicle is given without one
+const (
+	defaultStartSoC  = 20.0
+	defaultTargetSoC = 80.0
+)
+
 // Create Class
 type evStationUsecase struct {
-	stationRepo repository.EVStationRepository
+	stationRepo   repository.EVStationRepository
+	vehicleRepo   repository.VehicleRepository
+	auditLog      AuditRecorder
+	bookingPolicy BookingPolicy
 }
 
-// Init class && imprement EVStationUsecase interface
-func NewEVStationUsecase(repo repository.EVStationRepository) EVStationUsecase {
-	return &evStationUsecase{stationRepo: repo}
+// Init class && imprement EVStationUsecase interface; without a booking policy every account may book
+func NewEVStationUsecase(repo repository.EVStationRepository, vehicleRepo repository.VehicleRepository, auditLog AuditRecorder, bookingPolicy BookingPolicy) EVStationUsecase {
+	if auditLog == nil {
+		auditLog = nopAuditRecorder{}
+	}
+	return &evStationUsecase{stationRepo: repo, vehicleRepo: vehicleRepo, auditLog: auditLog, bookingPolicy: bookingPolicy}
 }
 
 func (u *evStationUsecase) FilterStations(ctx context.Context, request request.StationFilterRequest) ([]response.EVStationResponse, error) {
-	var isOpen *bool
+	filter, err := u.newStationFilter(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	stations, err := u.stationRepo.FindStations(ctx, request.Company, request.Type, request.Search, request.PlugName)
+	if err != nil {
+		return nil, err
+	}
+
+	var stationResponses []response.EVStationResponse
+	for _, station := range stations {
+		if stationResponse, ok := filter.apply(station); ok {
+			stationResponses = append(stationResponses, stationResponse)
+		}
+	}
+	return stationResponses, nil
+}
+
+// ExportStations applies the FilterStations filters while streaming stations from the repository,
+// handing each match to emit as soon as it is read
+func (u *evStationUsecase) ExportStations(ctx context.Context, request request.StationFilterRequest, emit func(station response.EVStationResponse) error) error {
+	filter, err := u.newStationFilter(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return u.stationRepo.StreamStations(ctx, request.Company, request.Type, request.Search, request.PlugName, func(station models.EVStationDB) error {
+		if stationResponse, ok := filter.apply(station); ok {
+			return emit(stationResponse)
+		}
+		return nil
+	})
+}
+
+// stationFilter is the part of a StationFilterRequest applied after the stations are loaded
+type stationFilter struct {
+	isOpen    *bool
+	vehicle   *domainModel.Vehicle
+	startSoC  float64
+	targetSoC float64
+	now       time.Time
+}
+
+func (u *evStationUsecase) newStationFilter(ctx context.Context, request request.StationFilterRequest) (*stationFilter, error) {
+	filter := &stationFilter{startSoC: defaultStartSoC, targetSoC: defaultTargetSoC, now: time.Now()}
 
 	// Convert status string to boolean
 	if request.Status != "" {
 		switch request.Status {
 		case "open":
-			isOpen = new(bool)
-			*isOpen = true
+			filter.isOpen = new(bool)
+			*filter.isOpen = true
 		case "closed":
-			isOpen = new(bool)
-			*isOpen = false
+			filter.isOpen = new(bool)
+			*filter.isOpen = false
 		default:
-			return nil, fmt.Errorf("invalid status value: %s", request.Status)
+			return nil, fmt.Errorf("%w: invalid status value: %s", ErrInvalidStationQuery, request.Status)
 		}
 	}
 
-	stations, err := u.stationRepo.FindStations(ctx, request.Company, request.Type, request.Search, request.PlugName, isOpen)
-	if err != nil {
-		return nil, err
+	// Restrict to connectors the selected vehicle can use
+	if request.VehicleID != "" {
+		found, err := findOwnedVehicle(ctx, u.vehicleRepo, request.VehicleID, request.UserID)
+		if err != nil {
+			return nil, err
+		}
+		filter.vehicle = found
 	}
 
-	var stationResponses []response.EVStationResponse
-	for _, station := range stations {
-		stationResponses = append(stationResponses, mapStationDBToResponse(station))
+	if request.StartSoC != nil {
+		filter.startSoC = *request.StartSoC
 	}
-	return stationResponses, nil
+	if request.TargetSoC != nil {
+		filter.targetSoC = *request.TargetSoC
+	}
+	if filter.vehicle != nil && filter.startSoC >= filter.targetSoC {
+		return nil, fmt.Errorf("%w: start_soc must be lower than target_soc", ErrInvalidStationQuery)
+	}
+	return filter, nil
+}
+
+// apply maps a station to its response; ok is false when the filter excludes the station
+func (f *stationFilter) apply(station models.EVStationDB) (response.EVStationResponse, bool) {
+	// opening state is computed from the schedule at query time
+	if f.isOpen != nil && mapStatusDBToDomain(station.Status).Schedule.IsOpenAt(f.now) != *f.isOpen {
+		return response.EVStationResponse{}, false
+	}
+
+	if f.vehicle == nil {
+		return mapStationDBToResponse(station), true
+	}
+
+	station.Connectors = filterConnectorsByVehicle(station.Connectors, *f.vehicle)
+	if len(station.Connectors) == 0 {
+		return response.EVStationResponse{}, false
+	}
+	stationResponse := mapStationDBToResponse(station)
+	applyVehicleToConnectors(stationResponse.Connectors, *f.vehicle, f.startSoC, f.targetSoC)
+	return stationResponse, true
 }
 
 func (u *evStationUsecase) ShowAllStations(ctx context.Context) ([]response.EVStationResponse, error) {
@@ -89,21 +219,109 @@ func (u *evStationUsecase) GetStationByID(ctx context.Context, request request.G
 }
 
 func (u *evStationUsecase) CreateStation(ctx context.Context, req request.EVStationRequest) error {
+	if err := authz.Check(ctx, constants.PermStationWrite); err != nil {
+		return err
+	}
+
 	// map Request -> Domain
-	stationDomain := mapRequestToDomain(req)
+	stationDomain, err := mapRequestToDomain(req)
+	if err != nil {
+		return stationValidationError([]response.FieldError{{Field: "status", Message: err.Error()}})
+	}
+	if err := stationValidationError(mapDomainFieldErrors(stationDomain.Validate())); err != nil {
+		return err
+	}
+	fieldErrors, err := u.checkConnectorIDs(ctx, connectorRequestIDs(req.Connectors))
+	if err != nil {
+		return err
+	}
+	if err := stationValidationError(fieldErrors); err != nil {
+		return err
+	}
+
+	if err := u.checkExternalRef(ctx, stationDomain.ExternalRef, primitive.NilObjectID); err != nil {
+		return err
+	}
+
+	_, err = u.createStation(ctx, stationDomain, constants.AuditStationCreate)
+	return err
+}
 
-	// สร้าง ID (หรือจะให้ DB สร้างเองก็ได้)
-	// ตัวอย่าง: stationDomain.ID = primitive.NewObjectID().Hex()
+// createStation assigns the station its ID (so the audit log can refer to it) and stores it
+func (u *evStationUsecase) createStation(ctx context.Context, station domainModel.EVStation, action constants.AuditAction) (string, error) {
+	station.ID = primitive.NewObjectID()
 
 	// เรียก Repository
-	if err := u.stationRepo.CreateStation(ctx, stationDomain); err != nil {
-		return err
+	if err := u.stationRepo.CreateStation(ctx, station); err != nil {
+		return "", err
+	}
+
+	stationID := station.ID.Hex()
+	created, err := u.stationRepo.FindStationByID(ctx, stationID)
+	if err != nil {
+		created = nil
+	}
+	u.auditLog.Record(ctx, action, constants.AuditTargetStation, stationID, nil, stationAuditSnapshot(created))
+
+	return stationID, nil
+}
+
+// checkExternalRef refuses a reference that another live station already uses
+func (u *evStationUsecase) checkExternalRef(ctx context.Context, externalRef string, stationID primitive.ObjectID) error {
+	if externalRef == "" {
+		return nil
 	}
 
+	other, err := u.stationRepo.FindStationByExternalRef(ctx, externalRef)
+	if err != nil {
+		return err
+	}
+	if other != nil && other.ID != stationID {
+		return fmt.Errorf("%w: %s is used by station %s", ErrExternalRefInUse, externalRef, other.ID.Hex())
+	}
 	return nil
 }
 
+// checkConnectorIDs refuses connector IDs that are listed twice or that another live station
+// already uses; connectors are looked up by ID across all stations. IDs are given by position in
+// the station's connector list, and an empty ID is not checked.
+func (u *evStationUsecase) checkConnectorIDs(ctx context.Context, connectorIDs []string) ([]response.FieldError, error) {
+	var fieldErrors []response.FieldError
+	seen := map[string]bool{}
+	for i, connectorID := range connectorIDs {
+		if connectorID == "" {
+			continue
+		}
+		field := fmt.Sprintf("connectors[%d].connector_id", i)
+		if seen[connectorID] {
+			fieldErrors = append(fieldErrors, response.FieldError{Field: field, Message: "is listed more than once"})
+			continue
+		}
+		seen[connectorID] = true
+
+		_, err := u.stationRepo.FindStationByConnectorID(ctx, connectorID)
+		if err == nil {
+			fieldErrors = append(fieldErrors, response.FieldError{Field: field, Message: fmt.Sprintf("connector %s already exists", connectorID)})
+		} else if !errors.Is(err, repository.ErrConnectorNotFound) {
+			return nil, err
+		}
+	}
+	return fieldErrors, nil
+}
+
+func connectorRequestIDs(connectors []request.ConnectorRequest) []string {
+	ids := make([]string, 0, len(connectors))
+	for _, c := range connectors {
+		ids = append(ids, c.ConnectorID)
+	}
+	return ids
+}
+
 func (u *evStationUsecase) EditStation(ctx context.Context, req request.EditStationRequest) (*response.EVStationResponse, error) {
+	if err := authz.Check(ctx, constants.PermStationWrite); err != nil {
+		return nil, err
+	}
+
 	objectID, err := primitive.ObjectIDFromHex(req.ID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid ID")
@@ -116,7 +334,16 @@ func (u *evStationUsecase) EditStation(ctx context.Context, req request.EditStat
 
 	existing := mapStationDBToDomain(*existingDB)
 	existing.ID = objectID
+	if err := checkStationVersion(req.ExpectedVersion, existing.Version); err != nil {
+		return nil, err
+	}
 
+	if req.ExternalRef != nil {
+		if err := u.checkExternalRef(ctx, *req.ExternalRef, objectID); err != nil {
+			return nil, err
+		}
+		existing.ExternalRef = *req.ExternalRef
+	}
 	if req.Name != nil {
 		existing.Name = *req.Name
 	}
@@ -130,21 +357,375 @@ func (u *evStationUsecase) EditStation(ctx context.Context, req request.EditStat
 		existing.Company = *req.Company
 	}
 	if req.Status != nil {
-		existing.Status = domainModel.StationStatus{
-			OpenHours:  req.Status.OpenHours,
-			CloseHours: req.Status.CloseHours,
-			IsOpen:     req.Status.IsOpen,
+		status, err := mapStatusRequestToDomain(*req.Status)
+		if err != nil {
+			return nil, stationValidationError([]response.FieldError{{Field: "status", Message: err.Error()}})
 		}
+		existing.Status = status
 	}
 	if req.Connectors != nil {
-		existing.Connectors = mapConnectorsReqToDomain(*req.Connectors)
+		connectors, err := mergeConnectors(existing.Connectors, *req.Connectors)
+		if err != nil {
+			return nil, err
+		}
+		existing.Connectors = connectors
+	}
+	if err := stationValidationError(mapDomainFieldErrors(existing.Validate())); err != nil {
+		return nil, err
 	}
 
 	if err := u.stationRepo.EditStation(ctx, existing); err != nil {
+		return nil, mapStationWriteError(err)
+	}
+
+	return u.reloadAudited(ctx, constants.AuditStationEdit, req.ID, existingDB)
+}
+
+// PatchStation applies a JSON Merge Patch or JSON Patch to the editable station document
+// (the same shape as EVStationRequest), validates the result and writes only the fields that changed.
+// Only the fields and connectors the patch touched are validated, so a stored station that breaks
+// a newer rule can still be patched elsewhere.
+func (u *evStationUsecase) PatchStation(ctx context.Context, req request.PatchStationRequest) (*response.EVStationResponse, error) {
+	if err := authz.Check(ctx, constants.PermStationWrite); err != nil {
+		return nil, err
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID")
+	}
+
+	existingDB, err := u.stationRepo.FindStationByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("station not found")
+	}
+
+	existing := mapStationDBToDomain(*existingDB)
+	existing.ID = objectID
+	if err := checkStationVersion(req.ExpectedVersion, existing.Version); err != nil {
+		return nil, err
+	}
+
+	current := mapDomainToStationRequest(existing)
+	currentDoc, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	var patchedDoc []byte
+	switch req.PatchType {
+	case constants.MergePatchContentType:
+		patchedDoc, err = utils.ApplyMergePatch(currentDoc, req.Patch)
+	case constants.JSONPatchContentType:
+		patchedDoc, err = utils.ApplyJSONPatch(currentDoc, req.Patch)
+	default:
+		return nil, fmt.Errorf("unsupported patch type %q", req.PatchType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var patched request.EVStationRequest
+	decoder := json.NewDecoder(bytes.NewReader(patchedDoc))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patched); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStationDocument, err)
+	}
+	if err := stationValidationError(patchFieldErrors(validateStationDocument(patched), current, patched)); err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	if patched.ExternalRef != current.ExternalRef {
+		if err := u.checkExternalRef(ctx, patched.ExternalRef, objectID); err != nil {
+			return nil, err
+		}
+		existing.ExternalRef = patched.ExternalRef
+		changed = append(changed, "external_ref")
+	}
+	if patched.Name != current.Name {
+		existing.Name = patched.Name
+		changed = append(changed, "name")
+	}
+	if patched.Latitude != current.Latitude {
+		existing.Latitude = patched.Latitude
+		changed = append(changed, "latitude")
+	}
+	if patched.Longitude != current.Longitude {
+		existing.Longitude = patched.Longitude
+		changed = append(changed, "longitude")
+	}
+	if patched.Company != current.Company {
+		existing.Company = patched.Company
+		changed = append(changed, "company")
+	}
+	if !reflect.DeepEqual(patched.Status, current.Status) {
+		status, err := mapStatusRequestToDomain(patched.Status)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStationDocument, err)
+		}
+		existing.Status = status
+		changed = append(changed, "status")
+	}
+	if !reflect.DeepEqual(patched.Connectors, current.Connectors) {
+		connectors, err := mergeConnectors(existing.Connectors, patched.Connectors)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStationDocument, err)
+		}
+		existing.Connectors = connectors
+		changed = append(changed, "connectors")
+	}
+
+	if len(changed) == 0 {
+		return u.reloadStation(ctx, req.ID)
+	}
+	if err := u.stationRepo.UpdateStationFields(ctx, existing, changed); err != nil {
+		return nil, mapStationWriteError(err)
+	}
+
+	return u.reloadAudited(ctx, constants.AuditStationPatch, req.ID, existingDB)
+}
+
+// RemoveStation moves a station to the trash. Stations with active bookings are only
+// removed when Force is set.
+func (u *evStationUsecase) RemoveStation(ctx context.Context, request request.RemoveStationRequest) error {
+	if err := authz.Check(ctx, constants.PermStationWrite); err != nil {
+		return err
+	}
+
+	station, err := u.stationRepo.FindStationByID(ctx, request.ID)
+	if err != nil {
+		return fmt.Errorf("station not found")
+	}
+
+	if !request.Force {
+		now := time.Now()
+		active := 0
+		for _, c := range station.Connectors {
+			if c.Booking == nil {
+				continue
+			}
+			expiredAt, err := time.Parse(constants.DateTimeLayout, c.Booking.BookingEndTime)
+			if err == nil && now.Before(expiredAt) {
+				active++
+			}
+		}
+		if active > 0 {
+			return fmt.Errorf("%w: %d connector(s) are booked, pass force=true to delete anyway", ErrStationHasActiveBookings, active)
+		}
+	}
+
+	deletedAt := time.Now()
+	if err := u.stationRepo.SoftDeleteStation(ctx, request.ID, request.DeletedBy, deletedAt); err != nil {
+		return err
+	}
+
+	deleted := *station
+	deleted.DeletedAt = &deletedAt
+	deleted.DeletedBy = request.DeletedBy
+	u.auditLog.Record(ctx, constants.AuditStationDelete, constants.AuditTargetStation, request.ID, stationAuditSnapshot(station), stationAuditSnapshot(&deleted))
+	return nil
+}
+
+func (u *evStationUsecase) GetDeletedStations(ctx context.Context) ([]response.EVStationResponse, error) {
+	if err := authz.Check(ctx, constants.PermStationTrash); err != nil {
+		return nil, err
+	}
+
+	stations, err := u.stationRepo.FindDeletedStations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.EVStationResponse, 0, len(stations))
+	for _, station := range stations {
+		result = append(result, mapStationDBToResponse(station))
+	}
+	return result, nil
+}
+
+func (u *evStationUsecase) RestoreStation(ctx context.Context, request request.RestoreStationRequest) (*response.EVStationResponse, error) {
+	if err := authz.Check(ctx, constants.PermStationTrash); err != nil {
 		return nil, err
 	}
 
-	updated, err := u.stationRepo.FindStationByID(ctx, req.ID)
+	trashed, err := u.stationRepo.FindDeletedStationByID(ctx, request.ID)
+	if err != nil {
+		return nil, fmt.Errorf("station not found in trash")
+	}
+	// another live station may have taken the reference or connector IDs while this one was in the trash
+	if err := u.checkExternalRef(ctx, trashed.ExternalRef, trashed.ID); err != nil {
+		return nil, err
+	}
+	connectorIDs := make([]string, 0, len(trashed.Connectors))
+	for _, c := range trashed.Connectors {
+		connectorIDs = append(connectorIDs, c.ConnectorID)
+	}
+	fieldErrors, err := u.checkConnectorIDs(ctx, connectorIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(fieldErrors) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrConnectorIDInUse, fieldErrors[0].Message)
+	}
+
+	if err := u.stationRepo.RestoreStation(ctx, request.ID); err != nil {
+		return nil, err
+	}
+
+	return u.reloadAudited(ctx, constants.AuditStationRestore, request.ID, trashed)
+}
+
+// PurgeDeletedStations permanently removes stations that have been in the trash longer than retention
+func (u *evStationUsecase) PurgeDeletedStations(ctx context.Context, retention time.Duration) (int64, error) {
+	deletedBefore := time.Now().Add(-retention)
+	purged, err := u.stationRepo.PurgeDeletedStations(ctx, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	if purged > 0 {
+		u.auditLog.Record(ctx, constants.AuditStationPurge, constants.AuditTargetStation, "", nil, map[string]interface{}{
+			"purged":         purged,
+			"deleted_before": deletedBefore.UTC().Format(constants.DateTimeLayout),
+		})
+	}
+	return purged, nil
+}
+
+func (u *evStationUsecase) AddConnector(ctx context.Context, request request.StationConnectorRequest) (*response.EVStationResponse, error) {
+	if err := authz.Check(ctx, constants.PermStationWrite); err != nil {
+		return nil, err
+	}
+
+	station, err := u.stationRepo.FindStationByID(ctx, request.StationID)
+	if err != nil {
+		return nil, mapStationLookupError(request.StationID, err)
+	}
+	if err := checkStationVersion(request.ExpectedVersion, station.Version); err != nil {
+		return nil, err
+	}
+
+	connectorID := request.ConnectorID
+	if connectorID == "" {
+		connectorID = primitive.NewObjectID().Hex()
+	} else {
+		// connector IDs are looked up across all stations, so they must be globally unique
+		fieldErrors, err := u.checkConnectorIDs(ctx, []string{connectorID})
+		if err != nil {
+			return nil, err
+		}
+		if len(fieldErrors) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrConnectorIDInUse, connectorID)
+		}
+	}
+
+	connector := domainModel.Connector{
+		ConnectorID:  connectorID,
+		Type:         request.Type,
+		PlugName:     request.PlugName,
+		PricePerUnit: request.PricePerUnit,
+		PowerOutput:  request.PowerOutput,
+	}
+	if err := stationValidationError(mapDomainFieldErrors(connector.Validate())); err != nil {
+		return nil, err
+	}
+	if err := u.stationRepo.AddConnector(ctx, request.StationID, station.Version, connector); err != nil {
+		return nil, mapStationWriteError(err)
+	}
+
+	return u.reloadAudited(ctx, constants.AuditConnectorAdd, request.StationID, station)
+}
+
+func (u *evStationUsecase) EditConnector(ctx context.Context, request request.StationConnectorRequest) (*response.EVStationResponse, error) {
+	if err := authz.Check(ctx, constants.PermStationWrite); err != nil {
+		return nil, err
+	}
+
+	station, err := u.stationRepo.FindStationByID(ctx, request.StationID)
+	if err != nil {
+		return nil, fmt.Errorf("station not found")
+	}
+	if err := checkStationVersion(request.ExpectedVersion, station.Version); err != nil {
+		return nil, err
+	}
+
+	connector := domainModel.Connector{
+		ConnectorID:  request.ConnectorID,
+		Type:         request.Type,
+		PlugName:     request.PlugName,
+		PricePerUnit: request.PricePerUnit,
+		PowerOutput:  request.PowerOutput,
+	}
+	if err := stationValidationError(mapDomainFieldErrors(connector.Validate())); err != nil {
+		return nil, err
+	}
+	if err := u.stationRepo.EditConnector(ctx, request.StationID, station.Version, connector); err != nil {
+		return nil, mapStationWriteError(err)
+	}
+
+	return u.reloadAudited(ctx, constants.AuditConnectorEdit, request.StationID, station)
+}
+
+func (u *evStationUsecase) RemoveConnector(ctx context.Context, request request.RemoveConnectorRequest) (*response.EVStationResponse, error) {
+	if err := authz.Check(ctx, constants.PermStationWrite); err != nil {
+		return nil, err
+	}
+
+	station, err := u.stationRepo.FindStationByID(ctx, request.StationID)
+	if err != nil {
+		return nil, fmt.Errorf("station not found")
+	}
+	if err := checkStationVersion(request.ExpectedVersion, station.Version); err != nil {
+		return nil, err
+	}
+
+	for _, c := range station.Connectors {
+		if c.ConnectorID != request.ConnectorID || c.Booking == nil {
+			continue
+		}
+		expiredAt, err := time.Parse(constants.DateTimeLayout, c.Booking.BookingEndTime)
+		if err == nil && time.Now().Before(expiredAt) {
+			return nil, fmt.Errorf("connector has an active booking until %s", c.Booking.BookingEndTime)
+		}
+	}
+
+	if err := u.stationRepo.RemoveConnector(ctx, request.StationID, station.Version, request.ConnectorID); err != nil {
+		return nil, mapStationWriteError(err)
+	}
+
+	return u.reloadAudited(ctx, constants.AuditConnectorRemove, request.StationID, station)
+}
+
+// checkStationVersion compares the If-Match version with the stored one; nil accepts any version
+func checkStationVersion(expected *int64, current int64) error {
+	if expected != nil && *expected != current {
+		return ErrPreconditionFailed
+	}
+	return nil
+}
+
+// mapStationWriteError reports a lost race on a conditional write as a failed precondition
+func mapStationWriteError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrVersionConflict):
+		return ErrPreconditionFailed
+	case errors.Is(err, repository.ErrStationNotFound):
+		return ErrStationNotFound
+	}
+	return err
+}
+
+// mapStationLookupError tells a missing station or malformed ID apart from a failed lookup
+func mapStationLookupError(stationID string, err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) || !primitive.IsValidObjectID(stationID) {
+		return ErrStationNotFound
+	}
+	return err
+}
+
+func (u *evStationUsecase) reloadStation(ctx context.Context, stationID string) (*response.EVStationResponse, error) {
+	updated, err := u.stationRepo.FindStationByID(ctx, stationID)
 	if err != nil {
 		return nil, err
 	}
@@ -153,8 +734,30 @@ func (u *evStationUsecase) EditStation(ctx context.Context, req request.EditStat
 	return &resp, nil
 }
 
-func (u *evStationUsecase) RemoveStation(ctx context.Context, request request.RemoveStationRequest) error {
-	return u.stationRepo.RemoveStation(ctx, request.ID)
+// reloadAudited reloads a station after a write and records the change against its state before the write
+func (u *evStationUsecase) reloadAudited(ctx context.Context, action constants.AuditAction, stationID string, before *models.EVStationDB) (*response.EVStationResponse, error) {
+	updated, err := u.stationRepo.FindStationByID(ctx, stationID)
+	if err != nil {
+		return nil, err
+	}
+
+	u.auditLog.Record(ctx, action, constants.AuditTargetStation, stationID, stationAuditSnapshot(before), stationAuditSnapshot(updated))
+	resp := mapStationDBToResponse(*updated)
+	return &resp, nil
+}
+
+// stationAuditSnapshot is the station as clients see it, minus the fields computed at read time
+func stationAuditSnapshot(station *models.EVStationDB) interface{} {
+	if station == nil {
+		return nil
+	}
+
+	snapshot := mapStationDBToResponse(*station)
+	snapshot.Status.IsOpen = false
+	for i := range snapshot.Connectors {
+		snapshot.Connectors[i].UnderMaintenance = false
+	}
+	return snapshot
 }
 
 // func (u *evStationUsecase) SetBooking(ctx context.Context, booking request.SetBookingRequest) error {
@@ -193,6 +796,25 @@ func (u *evStationUsecase) GetStationByConnectorID(ctx context.Context, request
 }
 
 func (u *evStationUsecase) SetBooking(ctx context.Context, request request.SetBookingRequest) error {
+	if err := authz.Check(ctx, constants.PermBookingWrite); err != nil {
+		return err
+	}
+	if request.Username == "" {
+		actor, ok := audit.ActorFromContext(ctx)
+		if !ok {
+			return fmt.Errorf("username is required")
+		}
+		request.Username = actor.UserName
+	}
+	if err := authz.CheckOwner(ctx, request.Username, constants.PermBookingOnBehalf); err != nil {
+		return err
+	}
+	if u.bookingPolicy != nil {
+		if err := u.bookingPolicy.CheckCanBook(ctx, request.Username); err != nil {
+			return err
+		}
+	}
+
 	// 📥 Condition > (connector_id + username + booking_end_time)
 	// 1. Reject if booking_end_time is in the past or now.
 	// 2. Reject if user already has an active booking.
@@ -249,6 +871,14 @@ func (u *evStationUsecase) SetBooking(ctx context.Context, request request.SetBo
 		return fmt.Errorf("connector not found")
 	}
 
+	// 🔧 ห้ามจองทับช่วงปิดปรับปรุง (maintenance window)
+	for _, w := range mapMaintenanceWindowsDBToDomain(station.MaintenanceWindows) {
+		if w.Covers(request.ConnectorId) && w.Overlaps(time.Now(), endTime) {
+			return fmt.Errorf("connector is under maintenance from %s to %s: %s",
+				w.StartTime.Format(constants.DateTimeLayout), w.EndTime.Format(constants.DateTimeLayout), w.Reason)
+		}
+	}
+
 	// ✅ Create BookingDB object
 	bookingDB := models.BookingDB{
 		Username:       request.Username,
@@ -256,10 +886,27 @@ func (u *evStationUsecase) SetBooking(ctx context.Context, request request.SetBo
 	}
 
 	// ✅ Save to repository
-	return u.stationRepo.SetBooking(ctx, request.ConnectorId, bookingDB)
+	if err := u.stationRepo.SetBooking(ctx, request.ConnectorId, bookingDB); err != nil {
+		return err
+	}
+
+	booked := *station
+	booked.Connectors = make([]models.ConnectorDB, len(station.Connectors))
+	copy(booked.Connectors, station.Connectors)
+	for i := range booked.Connectors {
+		if booked.Connectors[i].ConnectorID == request.ConnectorId {
+			booked.Connectors[i].Booking = &bookingDB
+		}
+	}
+	u.auditLog.Record(ctx, constants.AuditBookingSet, constants.AuditTargetStation, station.ID.Hex(), stationAuditSnapshot(station), stationAuditSnapshot(&booked))
+	return nil
 }
 
 func (u *evStationUsecase) GetBookingByUserName(ctx context.Context, request request.GetBookingRequest) (*response.BookingResponse, error) {
+	if err := authz.CheckOwner(ctx, request.Username, constants.PermBookingOnBehalf); err != nil {
+		return nil, err
+	}
+
 	booking, err := u.stationRepo.FindBookingByUserName(ctx, request.Username)
 	if err != nil {
 		return nil, err
@@ -275,6 +922,10 @@ func (u *evStationUsecase) GetBookingByUserName(ctx context.Context, request req
 }
 
 func (u *evStationUsecase) GetBookingsByUserName(ctx context.Context, request request.GetBookingsRequest) ([]response.BookingResponse, error) {
+	if err := authz.CheckOwner(ctx, request.Username, constants.PermBookingOnBehalf); err != nil {
+		return nil, err
+	}
+
 	bookings, err := u.stationRepo.FindBookingsByUserName(ctx, request.Username)
 	if err != nil {
 		return nil, err
@@ -292,6 +943,10 @@ func (u *evStationUsecase) GetBookingsByUserName(ctx context.Context, request re
 }
 
 func (u *evStationUsecase) GetStationByUserName(ctx context.Context, request request.GetStationByUsernameRequest) (*response.EVStationResponse, error) {
+	if err := authz.CheckOwner(ctx, request.Username, constants.PermBookingOnBehalf); err != nil {
+		return nil, err
+	}
+
 	station, err := u.stationRepo.FindStationByUserName(ctx, request.Username)
 	if err != nil {
 		return nil, err
@@ -301,7 +956,60 @@ func (u *evStationUsecase) GetStationByUserName(ctx context.Context, request req
 	return &resp, nil
 }
 
+func (u *evStationUsecase) EstimateCharge(ctx context.Context, request request.ChargeEstimateRequest) (*response.ChargeEstimateResponse, error) {
+	if request.StartSoC >= request.TargetSoC {
+		return nil, fmt.Errorf("%w: start_soc must be lower than target_soc", ErrInvalidStationQuery)
+	}
+
+	station, err := u.stationRepo.FindStationByConnectorID(ctx, request.ConnectorID)
+	if errors.Is(err, repository.ErrConnectorNotFound) {
+		return nil, ErrConnectorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var connector *models.ConnectorDB
+	for i := range station.Connectors {
+		if station.Connectors[i].ConnectorID == request.ConnectorID {
+			connector = &station.Connectors[i]
+			break
+		}
+	}
+	if connector == nil {
+		return nil, ErrConnectorNotFound
+	}
+
+	batteryKWh := request.BatteryKWh
+	powerKW := float64(connector.PowerOutput)
+
+	// Vehicle profile supplies battery size and caps the charging power
+	if request.VehicleID != "" {
+		vehicle, err := findOwnedVehicle(ctx, u.vehicleRepo, request.VehicleID, request.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !vehicle.CanUse(connector.Type, connector.PlugName) {
+			return nil, fmt.Errorf("%w: vehicle is not compatible with connector %s", ErrInvalidStationQuery, connector.ConnectorID)
+		}
+		if batteryKWh == 0 {
+			batteryKWh = vehicle.BatteryKWh
+		}
+		powerKW = vehicle.EffectivePower(connector.Type, connector.PowerOutput)
+	}
+
+	if batteryKWh <= 0 {
+		return nil, fmt.Errorf("%w: battery_kwh or vehicle_id is required", ErrInvalidStationQuery)
+	}
+
+	estimate := domainModel.EstimateCharge(connector.Type, powerKW, batteryKWh, request.StartSoC, request.TargetSoC, connector.PricePerUnit)
+	return mapChargeEstimateToResponse(connector.ConnectorID, request.StartSoC, request.TargetSoC, estimate), nil
+}
+
 func mapStationDBToResponse(station models.EVStationDB) response.EVStationResponse {
+	now := time.Now()
+	windows := mapMaintenanceWindowsDBToDomain(station.MaintenanceWindows)
+
 	var connectors []response.ConnectorResponse
 	for _, c := range station.Connectors {
 		var booking *response.BookingResponse = nil
@@ -315,45 +1023,89 @@ func mapStationDBToResponse(station models.EVStationDB) response.EVStationRespon
 		}
 
 		connectors = append(connectors, response.ConnectorResponse{
-			ConnectorID:  c.ConnectorID,
-			Type:         c.Type,
-			PlugName:     c.PlugName,
-			PricePerUnit: c.PricePerUnit,
-			PowerOutput:  c.PowerOutput,
-			Booking:      booking,
+			ConnectorID:      c.ConnectorID,
+			Type:             c.Type,
+			PlugName:         c.PlugName,
+			PricePerUnit:     c.PricePerUnit,
+			PowerOutput:      c.PowerOutput,
+			Booking:          booking,
+			UnderMaintenance: isUnderMaintenance(windows, c.ConnectorID, now),
 		})
 	}
 
-	return response.EVStationResponse{
-		ID: station.ID.Hex(),
-		Name:      station.Name,
-		Latitude:  station.Latitude,
-		Longitude: station.Longitude,
-		Company:   station.Company,
-		Status: response.StationStatusResponse{
-			OpenHours:  station.Status.OpenHours,
-			CloseHours: station.Status.CloseHours,
-			IsOpen:     station.Status.IsOpen,
-		},
-		Connectors: connectors,
+	// only surface maintenance that has not finished yet
+	var upcoming []response.MaintenanceWindowResponse
+	for _, w := range windows {
+		if w.EndTime.After(now) {
+			upcoming = append(upcoming, mapMaintenanceWindowToResponse(w))
+		}
+	}
+
+	resp := response.EVStationResponse{
+		ID:                 station.ID.Hex(),
+		ExternalRef:        station.ExternalRef,
+		Name:               station.Name,
+		Latitude:           station.Latitude,
+		Longitude:          station.Longitude,
+		Company:            station.Company,
+		Status:             mapStatusToResponse(mapStatusDBToDomain(station.Status), now),
+		Connectors:         connectors,
+		MaintenanceWindows: upcoming,
+		Version:            station.Version,
 	}
+	if station.DeletedAt != nil {
+		resp.DeletedAt = station.DeletedAt.Format(constants.DateTimeLayout)
+		resp.DeletedBy = station.DeletedBy
+	}
+	return resp
 }
 
+// keep only connectors the vehicle can plug into and accept power from
+func filterConnectorsByVehicle(connectors []models.ConnectorDB, vehicle domainModel.Vehicle) []models.ConnectorDB {
+	var filtered []models.ConnectorDB
+	for _, c := range connectors {
+		if vehicle.CanUse(c.Type, c.PlugName) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// effective power is the lower of connector output and vehicle acceptance rate
+func applyVehicleToConnectors(connectors []response.ConnectorResponse, vehicle domainModel.Vehicle, startSoC float64, targetSoC float64) {
+	for i := range connectors {
+		c := &connectors[i]
+		c.EffectivePower = vehicle.EffectivePower(c.Type, c.PowerOutput)
+		estimate := domainModel.EstimateCharge(c.Type, c.EffectivePower, vehicle.BatteryKWh, startSoC, targetSoC, c.PricePerUnit)
+		c.Estimate = mapChargeEstimateToResponse(c.ConnectorID, startSoC, targetSoC, estimate)
+	}
+}
+
+func mapChargeEstimateToResponse(connectorID string, startSoC float64, targetSoC float64, estimate domainModel.ChargeEstimate) *response.ChargeEstimateResponse {
+	return &response.ChargeEstimateResponse{
+		ConnectorID:      connectorID,
+		StartSoC:         startSoC,
+		TargetSoC:        targetSoC,
+		EnergyKWh:        estimate.EnergyKWh,
+		EstimatedMinutes: estimate.Minutes,
+		EstimatedCost:    estimate.Cost,
+		AveragePowerKW:   estimate.AveragePowerKW,
+	}
+}
 
 // FOR CREATE STATION and EDIT STATION
 func mapStationDBToDomain(db models.EVStationDB) domainModel.EVStation {
 	return domainModel.EVStation{
-		ID:        db.ID,
-		Name:      db.Name,
-		Latitude:  db.Latitude,
-		Longitude: db.Longitude,
-		Company:   db.Company,
-		Status: domainModel.StationStatus{
-			OpenHours:  db.Status.OpenHours,
-			CloseHours: db.Status.CloseHours,
-			IsOpen:     db.Status.IsOpen,
-		},
-		Connectors: mapConnectorsDBToDomain(db.Connectors),
+		ID:                 db.ID,
+		ExternalRef:        db.ExternalRef,
+		Name:               db.Name,
+		Latitude:           db.Latitude,
+		Longitude:          db.Longitude,
+		Company:            db.Company,
+		Status:             mapStatusDBToDomain(db.Status),
+		Connectors:         mapConnectorsDBToDomain(db.Connectors),
+		MaintenanceWindows: mapMaintenanceWindowsDBToDomain(db.MaintenanceWindows),
+		Version:            db.Version,
 	}
 }
 
@@ -362,7 +1114,7 @@ func mapConnectorsDBToDomain(conns []models.ConnectorDB) []domainModel.Connector
 	for _, c := range conns {
 		var booking *domainModel.Booking
 		if c.Booking != nil {
-			if parsedTime, err := time.Parse(time.RFC3339, c.Booking.BookingEndTime); err == nil {
+			if parsedTime, err := parseBookingEndTime(c.Booking.BookingEndTime); err == nil {
 				booking = &domainModel.Booking{
 					Username:       c.Booking.Username,
 					BookingEndTime: parsedTime,
@@ -382,50 +1134,160 @@ func mapConnectorsDBToDomain(conns []models.ConnectorDB) []domainModel.Connector
 	return connectors
 }
 
-func mapRequestToDomain(req request.EVStationRequest) domainModel.EVStation {
+func mapRequestToDomain(req request.EVStationRequest) (domainModel.EVStation, error) {
+	status, err := mapStatusRequestToDomain(req.Status)
+	if err != nil {
+		return domainModel.EVStation{}, err
+	}
+
 	return domainModel.EVStation{
 		// ID: สร้างจากข้างบนหรือ DB
-		Name:      req.Name,
-		Latitude:  req.Latitude,
-		Longitude: req.Longitude,
-		Company:   req.Company,
-		Status: domainModel.StationStatus{
-			OpenHours:  req.Status.OpenHours,
-			CloseHours: req.Status.CloseHours,
-			IsOpen:     req.Status.IsOpen,
-		},
-		Connectors: mapConnectorsReqToDomain(req.Connectors),
+		ExternalRef: req.ExternalRef,
+		Name:        req.Name,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		Company:     req.Company,
+		Status:      status,
+		Connectors:  mapConnectorsReqToDomain(req.Connectors),
+	}, nil
+}
+
+// parseBookingEndTime accepts the booking layout and RFC3339, which older station edits wrote
+func parseBookingEndTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(constants.DateTimeLayout, value); err == nil {
+		return parsed, nil
 	}
+	return time.Parse(time.RFC3339, value)
 }
 
-func mapConnectorsReqToDomain(connReqs []request.ConnectorRequest) []domainModel.Connector {
-	connectors := make([]domainModel.Connector, 0, len(connReqs))
+// mergeConnectors applies a full connector list to an existing station. Connectors keep their
+// ID and booking when the request names them by connector_id, or when an unnamed entry is
+// identical to an existing connector; everything else gets a fresh ID.
+func mergeConnectors(existing []domainModel.Connector, connReqs []request.ConnectorRequest) ([]domainModel.Connector, error) {
+	return mergeConnectorList(existing, connReqs, false)
+}
+
+// mergeImportedConnectors is mergeConnectors for imports, where the file is the source of the
+// connector IDs: an ID the station does not have yet adds a connector that keeps it. The caller
+// checks such IDs with checkConnectorIDs first.
+func mergeImportedConnectors(existing []domainModel.Connector, connReqs []request.ConnectorRequest) ([]domainModel.Connector, error) {
+	return mergeConnectorList(existing, connReqs, true)
+}
+
+func mergeConnectorList(existing []domainModel.Connector, connReqs []request.ConnectorRequest, acceptNewIDs bool) ([]domainModel.Connector, error) {
+	byID := make(map[string]domainModel.Connector, len(existing))
+	for _, c := range existing {
+		byID[c.ConnectorID] = c
+	}
 
+	claimed := map[string]bool{}
 	for _, c := range connReqs {
-		var booking *domainModel.Booking
-		if c.Booking != nil {
-			layout := "2006-01-02T15:04:05" // หรือ RFC3339 ตามที่ใช้จริง
-			parsedTime, err := time.Parse(layout, c.Booking.BookingEndTime)
-			if err == nil {
-				booking = &domainModel.Booking{
-					Username:       c.Booking.Username,
-					BookingEndTime: parsedTime,
+		if c.ConnectorID == "" {
+			continue
+		}
+		if _, ok := byID[c.ConnectorID]; !ok && !acceptNewIDs {
+			return nil, fmt.Errorf("connector %s not found in station", c.ConnectorID)
+		}
+		if claimed[c.ConnectorID] {
+			return nil, fmt.Errorf("connector %s listed more than once", c.ConnectorID)
+		}
+		claimed[c.ConnectorID] = true
+	}
+
+	connectors := make([]domainModel.Connector, 0, len(connReqs))
+	for _, c := range connReqs {
+		connector, ok := mapConnectorReqToDomain(c)
+		if !ok {
+			continue
+		}
+
+		matchID := c.ConnectorID
+		if matchID == "" {
+			for _, e := range existing {
+				if !claimed[e.ConnectorID] && e.Type == c.Type && e.PlugName == c.PlugName &&
+					e.PricePerUnit == c.PricePerUnit && e.PowerOutput == c.PowerOutput {
+					matchID = e.ConnectorID
+					claimed[matchID] = true
+					break
 				}
-			} else {
-				fmt.Println("Invalid time format for booking:", c.Booking.BookingEndTime)
-				continue
 			}
 		}
 
-		connectors = append(connectors, domainModel.Connector{
-			ConnectorID:  primitive.NewObjectID().Hex(), // ✅ generate ID ที่นี่
+		if previous, ok := byID[matchID]; ok {
+			connector.ConnectorID = previous.ConnectorID
+			if c.Booking == nil {
+				connector.Booking = previous.Booking
+			}
+		}
+		connectors = append(connectors, connector)
+	}
+	return connectors, nil
+}
+
+// mapDomainToStationRequest renders a station as the document clients edit; bookings are left out
+func mapDomainToStationRequest(station domainModel.EVStation) request.EVStationRequest {
+	connectors := make([]request.ConnectorRequest, 0, len(station.Connectors))
+	for _, c := range station.Connectors {
+		connectors = append(connectors, request.ConnectorRequest{
+			ConnectorID:  c.ConnectorID,
 			Type:         c.Type,
 			PlugName:     c.PlugName,
 			PricePerUnit: c.PricePerUnit,
 			PowerOutput:  c.PowerOutput,
-			Booking:      booking,
 		})
 	}
 
+	return request.EVStationRequest{
+		ExternalRef: station.ExternalRef,
+		Name:        station.Name,
+		Latitude:    station.Latitude,
+		Longitude:   station.Longitude,
+		Company:     station.Company,
+		Status:      mapStatusDomainToRequest(station.Status),
+		Connectors:  connectors,
+	}
+}
+
+func mapConnectorsReqToDomain(connReqs []request.ConnectorRequest) []domainModel.Connector {
+	connectors := make([]domainModel.Connector, 0, len(connReqs))
+
+	for _, c := range connReqs {
+		connector, ok := mapConnectorReqToDomain(c)
+		if !ok {
+			continue
+		}
+		connectors = append(connectors, connector)
+	}
+
 	return connectors
 }
+
+// mapConnectorReqToDomain keeps a supplied connector_id and generates one otherwise
+func mapConnectorReqToDomain(c request.ConnectorRequest) (domainModel.Connector, bool) {
+	var booking *domainModel.Booking
+	if c.Booking != nil {
+		layout := "2006-01-02T15:04:05" // หรือ RFC3339 ตามที่ใช้จริง
+		parsedTime, err := time.Parse(layout, c.Booking.BookingEndTime)
+		if err != nil {
+			return domainModel.Connector{}, false
+		}
+		booking = &domainModel.Booking{
+			Username:       c.Booking.Username,
+			BookingEndTime: parsedTime,
+		}
+	}
+
+	connectorID := c.ConnectorID
+	if connectorID == "" {
+		connectorID = primitive.NewObjectID().Hex()
+	}
+
+	return domainModel.Connector{
+		ConnectorID:  connectorID,
+		Type:         c.Type,
+		PlugName:     c.PlugName,
+		PricePerUnit: c.PricePerUnit,
+		PowerOutput:  c.PowerOutput,
+		Booking:      booking,
+	}, true
+}
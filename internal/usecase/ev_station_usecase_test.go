@@ -2,18 +2,27 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/authz"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
 	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
 	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/repository"
 	repoModels "Ev-Charge-Hub/Server/internal/repository/models"
 	"Ev-Charge-Hub/Server/internal/usecase"
+	"Ev-Charge-Hub/Server/utils"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func TestShowAllStations_Success(t *testing.T) {
@@ -21,7 +30,7 @@ func TestShowAllStations_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockEVStationRepository(ctrl)
-	uc := usecase.NewEVStationUsecase(mockRepo)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
 
 	mockRepo.EXPECT().FindAllStations(gomock.Any()).Return([]repoModels.EVStationDB{
 		{
@@ -46,14 +55,14 @@ func TestFilterStations_StatusClosed(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockEVStationRepository(ctrl)
-	uc := usecase.NewEVStationUsecase(mockRepo)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
 
 	req := request.StationFilterRequest{
 		Status: "closed",
 	}
 
 	mockRepo.EXPECT().
-		FindStations(gomock.Any(), "", "", "", "", gomock.Not(nil)).
+		FindStations(gomock.Any(), "", "", "", "").
 		Return([]repoModels.EVStationDB{}, nil)
 
 	_, err := uc.FilterStations(context.TODO(), req)
@@ -65,7 +74,7 @@ func TestSetBooking_PastTime_ShouldFail(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockEVStationRepository(ctrl)
-	uc := usecase.NewEVStationUsecase(mockRepo)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
 
 	req := request.SetBookingRequest{
 		ConnectorId:    "CT01",
@@ -73,16 +82,34 @@ func TestSetBooking_PastTime_ShouldFail(t *testing.T) {
 		BookingEndTime: "2020-01-01T10:00:00",
 	}
 
-	err := uc.SetBooking(context.TODO(), req)
+	err := uc.SetBooking(adminContext(), req)
 	assert.EqualError(t, err, "booking_end_time must be in the future")
 }
 
+func TestSetBooking_BookingPolicyRejects(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	mockPolicy := mocks.NewMockBookingPolicy(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, mockPolicy)
+
+	mockPolicy.EXPECT().CheckCanBook(gomock.Any(), "user1").Return(usecase.ErrEmailNotVerified)
+
+	err := uc.SetBooking(adminContext(), request.SetBookingRequest{
+		ConnectorId:    "CT01",
+		Username:       "user1",
+		BookingEndTime: time.Now().Add(time.Hour).Format("2006-01-02T15:04:05"),
+	})
+	assert.ErrorIs(t, err, usecase.ErrEmailNotVerified)
+}
+
 func TestSetBooking_UserAlreadyBooked(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockEVStationRepository(ctrl)
-	uc := usecase.NewEVStationUsecase(mockRepo)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
 
 	endTime := time.Now().Add(1 * time.Hour).Format("2006-01-02T15:04:05")
 
@@ -96,7 +123,7 @@ func TestSetBooking_UserAlreadyBooked(t *testing.T) {
 		{Username: "user1", BookingEndTime: time.Now().Add(30 * time.Minute).Format("2006-01-02T15:04:05")},
 	}, nil)
 
-	err := uc.SetBooking(context.TODO(), req)
+	err := uc.SetBooking(adminContext(), req)
 	assert.Contains(t, err.Error(), "user already has an active booking")
 }
 
@@ -105,7 +132,7 @@ func TestSetBooking_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockEVStationRepository(ctrl)
-	uc := usecase.NewEVStationUsecase(mockRepo)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
 
 	endTime := time.Now().Add(2 * time.Hour).Format("2006-01-02T15:04:05")
 
@@ -133,8 +160,84 @@ func TestSetBooking_Success(t *testing.T) {
 		SetBooking(gomock.Any(), "CT03", gomock.Any()).
 		Return(nil)
 
-	err := uc.SetBooking(context.TODO(), req)
+	err := uc.SetBooking(adminContext(), req)
+	assert.NoError(t, err)
+}
+
+func TestSetBooking_DefaultsToCaller(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	ctx := audit.WithActor(context.TODO(), audit.Actor{UserID: "u1", UserName: "alice", Role: constants.RoleUser})
+	endTime := time.Now().Add(2 * time.Hour).Format("2006-01-02T15:04:05")
+
+	mockRepo.EXPECT().FindBookingsByUserName(gomock.Any(), "alice").Return([]repoModels.BookingDB{}, nil)
+	mockRepo.EXPECT().
+		FindStationByConnectorID(gomock.Any(), "CT03").
+		Return(&repoModels.EVStationDB{Connectors: []repoModels.ConnectorDB{{ConnectorID: "CT03"}}}, nil)
+	mockRepo.EXPECT().
+		SetBooking(gomock.Any(), "CT03", repoModels.BookingDB{Username: "alice", BookingEndTime: endTime}).
+		Return(nil)
+
+	err := uc.SetBooking(ctx, request.SetBookingRequest{ConnectorId: "CT03", BookingEndTime: endTime})
+	assert.NoError(t, err)
+}
+
+func TestSetBooking_UserCannotBookForSomeoneElse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	ctx := audit.WithActor(context.TODO(), audit.Actor{UserID: "u1", UserName: "alice", Role: constants.RoleUser})
+	endTime := time.Now().Add(2 * time.Hour).Format("2006-01-02T15:04:05")
+
+	err := uc.SetBooking(ctx, request.SetBookingRequest{ConnectorId: "CT03", Username: "bob", BookingEndTime: endTime})
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+}
+
+func TestGetBookingsByUserName_OwnerAndAdminOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().FindBookingsByUserName(gomock.Any(), "bob").Return([]repoModels.BookingDB{{Username: "bob"}}, nil).Times(2)
+
+	alice := audit.WithActor(context.TODO(), audit.Actor{UserID: "u1", UserName: "alice", Role: constants.RoleUser})
+	_, err := uc.GetBookingsByUserName(alice, request.GetBookingsRequest{Username: "bob"})
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+	_, err = uc.GetStationByUserName(alice, request.GetStationByUsernameRequest{Username: "bob"})
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+
+	bob := audit.WithActor(context.TODO(), audit.Actor{UserID: "u2", UserName: "bob", Role: constants.RoleUser})
+	bookings, err := uc.GetBookingsByUserName(bob, request.GetBookingsRequest{Username: "bob"})
+	assert.NoError(t, err)
+	assert.Len(t, bookings, 1)
+
+	admin := audit.WithActor(context.TODO(), audit.Actor{UserID: "a1", UserName: "root", Role: constants.RoleAdmin})
+	_, err = uc.GetBookingsByUserName(admin, request.GetBookingsRequest{Username: "bob"})
+	assert.NoError(t, err)
+}
+
+func TestGetBookingsByUserName_OwnerMatchesAnyCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().FindBookingsByUserName(gomock.Any(), "Alice").Return([]repoModels.BookingDB{{Username: "alice"}}, nil)
+
+	alice := audit.WithActor(context.TODO(), audit.Actor{UserID: "u1", UserName: "alice", Role: constants.RoleUser})
+	bookings, err := uc.GetBookingsByUserName(alice, request.GetBookingsRequest{Username: "Alice"})
 	assert.NoError(t, err)
+	assert.Len(t, bookings, 1)
 }
 
 func TestGetStationByID_Success(t *testing.T) {
@@ -142,7 +245,7 @@ func TestGetStationByID_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockEVStationRepository(ctrl)
-	uc := usecase.NewEVStationUsecase(mockRepo)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
 
 	mockRepo.EXPECT().
 		FindStationByID(gomock.Any(), "station123").
@@ -161,7 +264,7 @@ func TestGetStationByID_NotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockEVStationRepository(ctrl)
-	uc := usecase.NewEVStationUsecase(mockRepo)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
 
 	mockRepo.EXPECT().
 		FindStationByID(gomock.Any(), "badID").
@@ -177,21 +280,24 @@ func TestCreateStation_CallsRepository(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockEVStationRepository(ctrl)
-	uc := usecase.NewEVStationUsecase(mockRepo)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
 
 	req := request.EVStationRequest{
 		Name:      "New Station",
 		Latitude:  13.7,
 		Longitude: 100.5,
 		Company:   "EV CO",
-		Status:    request.StationStatusRequest{IsOpen: true},
+		Status:    request.StationStatusRequest{AlwaysOpen: true},
 	}
 
 	mockRepo.EXPECT().
 		CreateStation(gomock.Any(), gomock.Any()).
 		Return(nil)
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), gomock.Any()).
+		Return(&repoModels.EVStationDB{Name: "New Station"}, nil)
 
-	err := uc.CreateStation(context.TODO(), req)
+	err := uc.CreateStation(adminContext(), req)
 	assert.NoError(t, err)
 }
 
@@ -199,13 +305,13 @@ func TestEditStation_InvalidID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockEVStationRepository(ctrl)
-	uc := usecase.NewEVStationUsecase(mockRepo)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
 
 	req := request.EditStationRequest{
 		ID: "invalid_hex_id", // not a valid ObjectID
 	}
 
-	resp, err := uc.EditStation(context.TODO(), req)
+	resp, err := uc.EditStation(adminContext(), req)
 	assert.Nil(t, resp)
 	assert.EqualError(t, err, "invalid ID")
 }
@@ -214,23 +320,1392 @@ func TestRemoveStation_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockEVStationRepository(ctrl)
-	uc := usecase.NewEVStationUsecase(mockRepo)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), "stationXYZ").
+		Return(&repoModels.EVStationDB{}, nil)
+	mockRepo.EXPECT().
+		SoftDeleteStation(gomock.Any(), "stationXYZ", "admin", gomock.Any()).
+		Return(nil)
+
+	err := uc.RemoveStation(adminContext(), request.RemoveStationRequest{ID: "stationXYZ", DeletedBy: "admin"})
+	assert.NoError(t, err)
+}
+
+func TestRemoveStation_UserActorIsForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	ctx := audit.WithActor(context.TODO(), audit.Actor{UserID: "u1", UserName: "alice", Role: constants.RoleUser})
+	err := uc.RemoveStation(ctx, request.RemoveStationRequest{ID: "stationXYZ", DeletedBy: "alice"})
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+}
+
+func TestRemoveStation_AdminActorIsAllowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), "stationXYZ").
+		Return(&repoModels.EVStationDB{}, nil)
+	mockRepo.EXPECT().
+		SoftDeleteStation(gomock.Any(), "stationXYZ", "admin", gomock.Any()).
+		Return(nil)
+
+	ctx := audit.WithActor(context.TODO(), audit.Actor{UserID: "a1", UserName: "admin", Role: constants.RoleAdmin})
+	err := uc.RemoveStation(ctx, request.RemoveStationRequest{ID: "stationXYZ", DeletedBy: "admin"})
+	assert.NoError(t, err)
+}
+
+func TestRemoveStation_ActiveBookingRequiresForce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	booked := &repoModels.EVStationDB{Connectors: []repoModels.ConnectorDB{
+		{ConnectorID: "C1", Booking: &repoModels.BookingDB{Username: "alice", BookingEndTime: time.Now().Add(time.Hour).Format(constants.DateTimeLayout)}},
+	}}
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), "stationXYZ").Return(booked, nil).Times(2)
+
+	err := uc.RemoveStation(adminContext(), request.RemoveStationRequest{ID: "stationXYZ"})
+	assert.ErrorIs(t, err, usecase.ErrStationHasActiveBookings)
 
 	mockRepo.EXPECT().
-		RemoveStation(gomock.Any(), "stationXYZ").
+		SoftDeleteStation(gomock.Any(), "stationXYZ", "admin", gomock.Any()).
 		Return(nil)
 
-	err := uc.RemoveStation(context.TODO(), request.RemoveStationRequest{ID: "stationXYZ"})
+	err = uc.RemoveStation(adminContext(), request.RemoveStationRequest{ID: "stationXYZ", Force: true, DeletedBy: "admin"})
+	assert.NoError(t, err)
+}
+
+func TestRestoreStation_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	mockRepo.EXPECT().FindDeletedStationByID(gomock.Any(), id.Hex()).Return(&repoModels.EVStationDB{ID: id, Name: "Gone", ExternalRef: "ocm-1"}, nil)
+	mockRepo.EXPECT().FindStationByExternalRef(gomock.Any(), "ocm-1").Return(nil, nil)
+	mockRepo.EXPECT().RestoreStation(gomock.Any(), id.Hex()).Return(nil)
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(&repoModels.EVStationDB{ID: id, Name: "Back again"}, nil)
+
+	resp, err := uc.RestoreStation(adminContext(), request.RestoreStationRequest{ID: id.Hex()})
+	assert.NoError(t, err)
+	assert.Equal(t, "Back again", resp.Name)
+}
+
+func TestRestoreStation_RefusesTakenExternalRefAndUnknownStation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	mockRepo.EXPECT().FindDeletedStationByID(gomock.Any(), id.Hex()).Return(&repoModels.EVStationDB{ID: id, ExternalRef: "ocm-1"}, nil)
+	mockRepo.EXPECT().FindStationByExternalRef(gomock.Any(), "ocm-1").Return(&repoModels.EVStationDB{ID: primitive.NewObjectID()}, nil)
+
+	_, err := uc.RestoreStation(adminContext(), request.RestoreStationRequest{ID: id.Hex()})
+	assert.ErrorIs(t, err, usecase.ErrExternalRefInUse)
+
+	mockRepo.EXPECT().FindDeletedStationByID(gomock.Any(), "missing").Return(nil, mongo.ErrNoDocuments)
+	_, err = uc.RestoreStation(adminContext(), request.RestoreStationRequest{ID: "missing"})
+	assert.EqualError(t, err, "station not found in trash")
+}
+
+func TestRestoreStation_RefusesConnectorIDGivenOutAgain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	mockRepo.EXPECT().
+		FindDeletedStationByID(gomock.Any(), id.Hex()).
+		Return(&repoModels.EVStationDB{ID: id, Connectors: []repoModels.ConnectorDB{{ConnectorID: "C1"}, {ConnectorID: "C2"}}}, nil)
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "C1").Return(nil, repository.ErrConnectorNotFound)
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "C2").Return(&repoModels.EVStationDB{ID: primitive.NewObjectID()}, nil)
+
+	_, err := uc.RestoreStation(adminContext(), request.RestoreStationRequest{ID: id.Hex()})
+	assert.ErrorIs(t, err, usecase.ErrConnectorIDInUse)
+	assert.Contains(t, err.Error(), "C2")
+}
+
+func TestGetDeletedStations_IncludesDeletionInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	deletedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().
+		FindDeletedStations(gomock.Any()).
+		Return([]repoModels.EVStationDB{{Name: "Old", DeletedAt: &deletedAt, DeletedBy: "admin"}}, nil)
+
+	resp, err := uc.GetDeletedStations(adminContext())
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-05-01T10:00:00", resp[0].DeletedAt)
+	assert.Equal(t, "admin", resp[0].DeletedBy)
+}
+
+func TestPurgeDeletedStations_UsesRetention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().
+		PurgeDeletedStations(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-48*time.Hour), before, time.Minute)
+			return 3, nil
+		})
+
+	purged, err := uc.PurgeDeletedStations(context.TODO(), 48*time.Hour)
 	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
 }
 
 func TestFilterStations_InvalidStatus(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockEVStationRepository(ctrl)
-	uc := usecase.NewEVStationUsecase(mockRepo)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
 
 	_, err := uc.FilterStations(context.TODO(), request.StationFilterRequest{Status: "unknown-status"})
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, usecase.ErrInvalidStationQuery)
 	assert.Contains(t, err.Error(), "invalid status value")
 }
+
+func TestFilterStations_WithVehicle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	mockVehicleRepo := mocks.NewMockVehicleRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, mockVehicleRepo, nil, nil)
+
+	mockVehicleRepo.EXPECT().
+		FindVehicleByID(gomock.Any(), "v1").
+		Return(&models.Vehicle{
+			ID:             "v1",
+			UserID:         "user1",
+			SupportedPlugs: []constants.PlugName{constants.Type2, constants.CCSType2},
+			MaxACPowerKW:   7,
+			MaxDCPowerKW:   100,
+		}, nil)
+
+	mockRepo.EXPECT().
+		FindStations(gomock.Any(), "", "", "", "").
+		Return([]repoModels.EVStationDB{
+			{
+				Name: "Compatible",
+				Connectors: []repoModels.ConnectorDB{
+					{ConnectorID: "C1", Type: constants.AC, PlugName: constants.Type2, PowerOutput: 22},
+					{ConnectorID: "C2", Type: constants.DC, PlugName: constants.CHAdeMO, PowerOutput: 50},
+					{ConnectorID: "C3", Type: constants.DC, PlugName: constants.CCSType2, PowerOutput: 150},
+				},
+			},
+			{
+				Name: "Incompatible",
+				Connectors: []repoModels.ConnectorDB{
+					{ConnectorID: "C4", Type: constants.DC, PlugName: constants.CHAdeMO, PowerOutput: 50},
+				},
+			},
+		}, nil)
+
+	resp, err := uc.FilterStations(context.TODO(), request.StationFilterRequest{VehicleID: "v1", UserID: "user1"})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp, 1)
+	assert.Len(t, resp[0].Connectors, 2)
+	assert.Equal(t, 7.0, resp[0].Connectors[0].EffectivePower)
+	assert.Equal(t, 100.0, resp[0].Connectors[1].EffectivePower)
+	assert.NotNil(t, resp[0].Connectors[0].Estimate)
+	assert.Equal(t, 20.0, resp[0].Connectors[0].Estimate.StartSoC)
+}
+
+func TestEstimateCharge_ACConstantPower(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().
+		FindStationByConnectorID(gomock.Any(), "C1").
+		Return(&repoModels.EVStationDB{
+			Connectors: []repoModels.ConnectorDB{
+				{ConnectorID: "C1", Type: constants.AC, PlugName: constants.Type2, PowerOutput: 10, PricePerUnit: 5},
+			},
+		}, nil)
+
+	resp, err := uc.EstimateCharge(context.TODO(), request.ChargeEstimateRequest{
+		ConnectorID: "C1",
+		BatteryKWh:  50,
+		StartSoC:    20,
+		TargetSoC:   80,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, resp.EnergyKWh)
+	assert.Equal(t, 180.0, resp.EstimatedMinutes)
+	assert.Equal(t, 150.0, resp.EstimatedCost)
+}
+
+func TestEstimateCharge_DCTaperAbove80(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().
+		FindStationByConnectorID(gomock.Any(), "C2").
+		Return(&repoModels.EVStationDB{
+			Connectors: []repoModels.ConnectorDB{
+				{ConnectorID: "C2", Type: constants.DC, PlugName: constants.CCSType2, PowerOutput: 60, PricePerUnit: 7},
+			},
+		}, nil).Times(2)
+
+	below, err := uc.EstimateCharge(context.TODO(), request.ChargeEstimateRequest{ConnectorID: "C2", BatteryKWh: 60, StartSoC: 60, TargetSoC: 80})
+	assert.NoError(t, err)
+	above, err := uc.EstimateCharge(context.TODO(), request.ChargeEstimateRequest{ConnectorID: "C2", BatteryKWh: 60, StartSoC: 80, TargetSoC: 100})
+	assert.NoError(t, err)
+
+	// same energy, but the last 20% charges slower
+	assert.Equal(t, below.EnergyKWh, above.EnergyKWh)
+	assert.Equal(t, 12.0, below.EstimatedMinutes)
+	assert.Greater(t, above.EstimatedMinutes, below.EstimatedMinutes)
+}
+
+func TestEstimateCharge_InvalidSoCRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	_, err := uc.EstimateCharge(context.TODO(), request.ChargeEstimateRequest{ConnectorID: "C1", BatteryKWh: 50, StartSoC: 80, TargetSoC: 20})
+	assert.ErrorIs(t, err, usecase.ErrInvalidStationQuery)
+	assert.Contains(t, err.Error(), "start_soc must be lower than target_soc")
+}
+
+func TestEstimateCharge_UnknownConnector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "C9").
+		Return(nil, fmt.Errorf("%w: no station found with connector id C9", repository.ErrConnectorNotFound))
+	_, err := uc.EstimateCharge(context.TODO(), request.ChargeEstimateRequest{ConnectorID: "C9", BatteryKWh: 50, StartSoC: 20, TargetSoC: 80})
+	assert.ErrorIs(t, err, usecase.ErrConnectorNotFound)
+
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "C9").Return(nil, errors.New("error finding station: timeout"))
+	_, err = uc.EstimateCharge(context.TODO(), request.ChargeEstimateRequest{ConnectorID: "C9", BatteryKWh: 50, StartSoC: 20, TargetSoC: 80})
+	assert.NotErrorIs(t, err, usecase.ErrConnectorNotFound)
+}
+
+func TestFilterStations_StatusOpen_ComputedFromSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	today := time.Now().In(time.UTC).Format("2006-01-02")
+	mockRepo.EXPECT().
+		FindStations(gomock.Any(), "", "", "", "").
+		Return([]repoModels.EVStationDB{
+			{Name: "24/7", Status: repoModels.StationStatusDB{Schedule: &repoModels.OpeningHoursDB{TimeZone: "UTC", AlwaysOpen: true}}},
+			{Name: "Holiday", Status: repoModels.StationStatusDB{Schedule: &repoModels.OpeningHoursDB{
+				TimeZone:   "UTC",
+				AlwaysOpen: true,
+				Exceptions: []repoModels.ScheduleExceptionDB{{Date: today, Closed: true, Reason: "Holiday"}},
+			}}},
+		}, nil)
+
+	resp, err := uc.FilterStations(context.TODO(), request.StationFilterRequest{Status: "open"})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp, 1)
+	assert.Equal(t, "24/7", resp[0].Name)
+	assert.True(t, resp[0].Status.IsOpen)
+}
+
+func TestGetStationByID_LegacyHoursConverted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), "legacy").
+		Return(&repoModels.EVStationDB{
+			Name:   "Legacy Station",
+			Status: repoModels.StationStatusDB{OpenHours: "08:00", CloseHours: "20:00", IsOpen: true},
+		}, nil)
+
+	resp, err := uc.GetStationByID(context.TODO(), request.GetStationByIDRequest{ID: "legacy"})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Status.Weekly, 7)
+	assert.Equal(t, "08:00", resp.Status.Weekly[0].Intervals[0].Open)
+	assert.Equal(t, "20:00", resp.Status.Weekly[0].Intervals[0].Close)
+}
+
+func TestCreateStation_InvalidSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	req := request.EVStationRequest{
+		Name: "Bad Hours",
+		Status: request.StationStatusRequest{
+			TimeZone: "Mars/Olympus_Mons",
+			Weekly: []request.DayScheduleRequest{
+				{Day: "MON", Intervals: []request.TimeIntervalRequest{{Open: "08:00", Close: "20:00"}}},
+			},
+		},
+	}
+
+	err := uc.CreateStation(adminContext(), req)
+	var validationErr *usecase.StationValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []response.FieldError{{Field: "status", Message: "invalid time_zone: Mars/Olympus_Mons"}}, validationErr.Fields)
+}
+
+func TestCreateStation_OvernightSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	req := request.EVStationRequest{
+		Name: "Night Owl",
+		Status: request.StationStatusRequest{
+			TimeZone: "Asia/Bangkok",
+			Weekly: []request.DayScheduleRequest{
+				{Day: "FRI", Intervals: []request.TimeIntervalRequest{{Open: "08:00", Close: "12:00"}, {Open: "18:00", Close: "02:00"}}},
+			},
+		},
+	}
+
+	mockRepo.EXPECT().
+		CreateStation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, station models.EVStation) error {
+			bangkok, _ := time.LoadLocation("Asia/Bangkok")
+			schedule := station.Status.Schedule
+			// Friday 2025-01-03
+			assert.True(t, schedule.IsOpenAt(time.Date(2025, 1, 3, 9, 0, 0, 0, bangkok)))
+			assert.False(t, schedule.IsOpenAt(time.Date(2025, 1, 3, 13, 0, 0, 0, bangkok)))
+			// Saturday 01:30 is still inside Friday's overnight interval
+			assert.True(t, schedule.IsOpenAt(time.Date(2025, 1, 4, 1, 30, 0, 0, bangkok)))
+			assert.False(t, schedule.IsOpenAt(time.Date(2025, 1, 4, 2, 0, 0, 0, bangkok)))
+			return nil
+		})
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), gomock.Any()).Return(&repoModels.EVStationDB{Name: "Night Owl"}, nil)
+
+	assert.NoError(t, uc.CreateStation(adminContext(), req))
+}
+
+func TestSetBooking_BlockedByMaintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	now := time.Now()
+	req := request.SetBookingRequest{
+		ConnectorId:    "CT03",
+		Username:       "newuser",
+		BookingEndTime: now.Add(3 * time.Hour).Format(constants.DateTimeLayout),
+	}
+
+	mockRepo.EXPECT().
+		FindBookingsByUserName(gomock.Any(), "newuser").
+		Return([]repoModels.BookingDB{}, nil)
+
+	mockRepo.EXPECT().
+		FindStationByConnectorID(gomock.Any(), "CT03").
+		Return(&repoModels.EVStationDB{
+			Connectors: []repoModels.ConnectorDB{{ConnectorID: "CT03"}},
+			MaintenanceWindows: []repoModels.MaintenanceWindowDB{
+				{ID: "w1", Scope: constants.MaintenanceScopeStation, StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour), Reason: "Transformer upgrade"},
+			},
+		}, nil)
+
+	err := uc.SetBooking(adminContext(), req)
+	assert.ErrorContains(t, err, "connector is under maintenance")
+	assert.ErrorContains(t, err, "Transformer upgrade")
+}
+
+func TestGetStationByID_ConnectorUnderMaintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	now := time.Now()
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), "station123").
+		Return(&repoModels.EVStationDB{
+			Connectors: []repoModels.ConnectorDB{{ConnectorID: "C1"}, {ConnectorID: "C2"}},
+			MaintenanceWindows: []repoModels.MaintenanceWindowDB{
+				{ID: "past", Scope: constants.MaintenanceScopeStation, StartTime: now.Add(-3 * time.Hour), EndTime: now.Add(-2 * time.Hour)},
+				{ID: "now", Scope: constants.MaintenanceScopeConnector, ConnectorID: "C2", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)},
+			},
+		}, nil)
+
+	resp, err := uc.GetStationByID(context.TODO(), request.GetStationByIDRequest{ID: "station123"})
+
+	assert.NoError(t, err)
+	assert.False(t, resp.Connectors[0].UnderMaintenance)
+	assert.True(t, resp.Connectors[1].UnderMaintenance)
+	assert.Len(t, resp.MaintenanceWindows, 1)
+	assert.Equal(t, "now", resp.MaintenanceWindows[0].ID)
+}
+
+func TestEditStation_PreservesConnectorIDsAndBookings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	stationID := primitive.NewObjectID()
+	bookingEnd := time.Now().Add(time.Hour).Format(constants.DateTimeLayout)
+	existing := &repoModels.EVStationDB{
+		ID: stationID,
+		Connectors: []repoModels.ConnectorDB{
+			{ConnectorID: "C1", Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 5, PowerOutput: 22,
+				Booking: &repoModels.BookingDB{Username: "alice", BookingEndTime: bookingEnd}},
+			{ConnectorID: "C2", Type: constants.DC, PlugName: constants.CCSType2, PricePerUnit: 7, PowerOutput: 60},
+			{ConnectorID: "C3", Type: constants.DC, PlugName: constants.CHAdeMO, PricePerUnit: 7, PowerOutput: 50},
+		},
+	}
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), stationID.Hex()).Return(existing, nil).Times(2)
+
+	mockRepo.EXPECT().
+		EditStation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, station models.EVStation) error {
+			assert.Len(t, station.Connectors, 3)
+			// unchanged connector keeps its ID and booking
+			assert.Equal(t, "C1", station.Connectors[0].ConnectorID)
+			assert.NotNil(t, station.Connectors[0].Booking)
+			assert.Equal(t, "alice", station.Connectors[0].Booking.Username)
+			// connector named by ID keeps it despite the new price
+			assert.Equal(t, "C2", station.Connectors[1].ConnectorID)
+			assert.Equal(t, 8.0, station.Connectors[1].PricePerUnit)
+			// new connector gets a fresh ID; C3 is dropped
+			assert.NotContains(t, []string{"C1", "C2", "C3"}, station.Connectors[2].ConnectorID)
+			return nil
+		})
+
+	connectors := []request.ConnectorRequest{
+		{Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 5, PowerOutput: 22},
+		{ConnectorID: "C2", Type: constants.DC, PlugName: constants.CCSType2, PricePerUnit: 8, PowerOutput: 60},
+		{Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 6, PowerOutput: 7},
+	}
+	_, err := uc.EditStation(adminContext(), request.EditStationRequest{ID: stationID.Hex(), Connectors: &connectors})
+	assert.NoError(t, err)
+}
+
+func TestEditStation_UnknownConnectorID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	stationID := primitive.NewObjectID()
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), stationID.Hex()).
+		Return(&repoModels.EVStationDB{ID: stationID, Connectors: []repoModels.ConnectorDB{{ConnectorID: "C1"}}}, nil)
+
+	connectors := []request.ConnectorRequest{{ConnectorID: "C9", Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 5, PowerOutput: 22}}
+	_, err := uc.EditStation(adminContext(), request.EditStationRequest{ID: stationID.Hex(), Connectors: &connectors})
+	assert.EqualError(t, err, "connector C9 not found in station")
+}
+
+func TestAddConnector_DuplicateID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), "station123").Return(&repoModels.EVStationDB{}, nil)
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "C1").Return(&repoModels.EVStationDB{}, nil)
+
+	_, err := uc.AddConnector(adminContext(), request.StationConnectorRequest{StationID: "station123", ConnectorID: "C1", Type: constants.AC})
+	assert.ErrorIs(t, err, usecase.ErrConnectorIDInUse)
+}
+
+func TestAddConnector_LookupErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID().Hex()
+	req := request.StationConnectorRequest{StationID: id, ConnectorID: "C9", Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 5, PowerOutput: 22}
+
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id).Return(nil, mongo.ErrNoDocuments)
+	_, err := uc.AddConnector(adminContext(), req)
+	assert.ErrorIs(t, err, usecase.ErrStationNotFound)
+
+	// a failed uniqueness lookup must not be read as a free ID
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id).Return(patchTestStation(primitive.NewObjectID()), nil)
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "C9").Return(nil, errors.New("connection reset"))
+	_, err = uc.AddConnector(adminContext(), req)
+	assert.EqualError(t, err, "connection reset")
+}
+
+func TestAddConnector_GeneratesID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), "station123").Return(&repoModels.EVStationDB{}, nil).Times(2)
+	mockRepo.EXPECT().
+		AddConnector(gomock.Any(), "station123", int64(0), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ int64, c models.Connector) error {
+			assert.NotEmpty(t, c.ConnectorID)
+			assert.Nil(t, c.Booking)
+			return nil
+		})
+
+	_, err := uc.AddConnector(adminContext(), request.StationConnectorRequest{StationID: "station123", Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 5, PowerOutput: 22})
+	assert.NoError(t, err)
+}
+
+func TestCreateStation_RejectsInvalidConnectors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	req := request.EVStationRequest{
+		Name:      "Mixed Up",
+		Latitude:  13.7,
+		Longitude: 100.5,
+		Company:   "EV CO",
+		Status:    request.StationStatusRequest{AlwaysOpen: true},
+		Connectors: []request.ConnectorRequest{
+			{Type: constants.DC, PlugName: constants.Type2, PricePerUnit: 5, PowerOutput: 22},
+			{Type: constants.AC, PlugName: "Type 2", PricePerUnit: 5, PowerOutput: 22},
+			{Type: constants.AC, PlugName: constants.J1772, PricePerUnit: 0, PowerOutput: 60},
+			{Type: constants.DC, PlugName: constants.CHAdeMO, PricePerUnit: 9, PowerOutput: 50},
+		},
+	}
+
+	// nothing is written
+	err := uc.CreateStation(adminContext(), req)
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidStationDocument)
+	var validationErr *usecase.StationValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []response.FieldError{
+		{Field: "connectors[0].type", Message: "plug TYPE 2 is AC, not DC"},
+		{Field: "connectors[1].plug_name", Message: `unknown plug "Type 2"`},
+		{Field: "connectors[2].power_output", Message: "must be between 1 and 43 kW for AC"},
+		{Field: "connectors[2].price_per_unit", Message: "must be positive"},
+	}, validationErr.Fields)
+}
+
+func TestEditStation_RejectsMismatchedPlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	stationID := primitive.NewObjectID()
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), stationID.Hex()).Return(patchTestStation(stationID), nil)
+
+	connectors := []request.ConnectorRequest{
+		{ConnectorID: "C1", Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 5, PowerOutput: 22},
+		{Type: constants.AC, PlugName: constants.CCSType2, PricePerUnit: 9, PowerOutput: 120},
+	}
+	_, err := uc.EditStation(adminContext(), request.EditStationRequest{ID: stationID.Hex(), Connectors: &connectors})
+
+	var validationErr *usecase.StationValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []response.FieldError{{Field: "connectors[1].type", Message: "plug CCS TYPE 2 is DC, not AC"}}, validationErr.Fields)
+}
+
+func TestAddConnector_RejectsPowerOutOfRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), "station123").Return(&repoModels.EVStationDB{}, nil)
+
+	_, err := uc.AddConnector(adminContext(), request.StationConnectorRequest{StationID: "station123", Type: constants.DC, PlugName: constants.CCSType2, PricePerUnit: 9, PowerOutput: 2000})
+
+	var validationErr *usecase.StationValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []response.FieldError{{Field: "power_output", Message: "must be between 5 and 500 kW for DC"}}, validationErr.Fields)
+}
+
+func TestRemoveConnector_ActiveBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	bookingEnd := time.Now().Add(time.Hour).Format(constants.DateTimeLayout)
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), "station123").
+		Return(&repoModels.EVStationDB{Connectors: []repoModels.ConnectorDB{
+			{ConnectorID: "C1", Booking: &repoModels.BookingDB{Username: "alice", BookingEndTime: bookingEnd}},
+		}}, nil)
+
+	_, err := uc.RemoveConnector(adminContext(), request.RemoveConnectorRequest{StationID: "station123", ConnectorID: "C1"})
+	assert.EqualError(t, err, "connector has an active booking until "+bookingEnd)
+}
+
+func patchTestStation(id primitive.ObjectID) *repoModels.EVStationDB {
+	return &repoModels.EVStationDB{
+		ID:        id,
+		Name:      "Central",
+		Latitude:  13.75,
+		Longitude: 100.5,
+		Company:   "EV Co",
+		Status:    repoModels.StationStatusDB{Schedule: &repoModels.OpeningHoursDB{TimeZone: "Asia/Bangkok", AlwaysOpen: true}},
+		Connectors: []repoModels.ConnectorDB{
+			{ConnectorID: "C1", Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 5, PowerOutput: 22,
+				Booking: &repoModels.BookingDB{Username: "alice", BookingEndTime: time.Now().Add(time.Hour).Format(constants.DateTimeLayout)}},
+		},
+	}
+}
+
+func TestPatchStation_MergePatchTouchesOnlyChangedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(patchTestStation(id), nil).Times(2)
+	mockRepo.EXPECT().
+		UpdateStationFields(gomock.Any(), gomock.Any(), []string{"name"}).
+		DoAndReturn(func(_ context.Context, station models.EVStation, _ []string) error {
+			assert.Equal(t, "Central Plaza", station.Name)
+			return nil
+		})
+
+	_, err := uc.PatchStation(adminContext(), request.PatchStationRequest{
+		ID:        id.Hex(),
+		PatchType: constants.MergePatchContentType,
+		Patch:     []byte(`{"name": "Central Plaza"}`),
+	})
+	assert.NoError(t, err)
+}
+
+func TestPatchStation_JSONPatchKeepsConnectorIDAndBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(patchTestStation(id), nil).Times(2)
+	mockRepo.EXPECT().
+		UpdateStationFields(gomock.Any(), gomock.Any(), []string{"connectors"}).
+		DoAndReturn(func(_ context.Context, station models.EVStation, _ []string) error {
+			assert.Equal(t, "C1", station.Connectors[0].ConnectorID)
+			assert.Equal(t, 6.5, station.Connectors[0].PricePerUnit)
+			assert.NotNil(t, station.Connectors[0].Booking)
+			return nil
+		})
+
+	_, err := uc.PatchStation(adminContext(), request.PatchStationRequest{
+		ID:        id.Hex(),
+		PatchType: constants.JSONPatchContentType,
+		Patch: []byte(`[
+			{"op": "test", "path": "/connectors/0/connector_id", "value": "C1"},
+			{"op": "replace", "path": "/connectors/0/price_per_unit", "value": 6.5}
+		]`),
+	})
+	assert.NoError(t, err)
+}
+
+func TestPatchStation_NoChangesSkipsWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(patchTestStation(id), nil).Times(2)
+
+	_, err := uc.PatchStation(adminContext(), request.PatchStationRequest{
+		ID:        id.Hex(),
+		PatchType: constants.MergePatchContentType,
+		Patch:     []byte(`{"company": "EV Co"}`),
+	})
+	assert.NoError(t, err)
+}
+
+func TestPatchStation_TestOperationFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(patchTestStation(id), nil)
+
+	_, err := uc.PatchStation(adminContext(), request.PatchStationRequest{
+		ID:        id.Hex(),
+		PatchType: constants.JSONPatchContentType,
+		Patch:     []byte(`[{"op": "test", "path": "/name", "value": "Elsewhere"}, {"op": "remove", "path": "/company"}]`),
+	})
+	assert.ErrorIs(t, err, utils.ErrPatchTestFailed)
+}
+
+func TestPatchStation_InvalidResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(patchTestStation(id), nil).Times(3)
+
+	// merge patch null removes a required member
+	_, err := uc.PatchStation(adminContext(), request.PatchStationRequest{
+		ID:        id.Hex(),
+		PatchType: constants.MergePatchContentType,
+		Patch:     []byte(`{"name": null}`),
+	})
+	assert.ErrorIs(t, err, usecase.ErrInvalidStationDocument)
+
+	// unknown members are rejected rather than silently dropped
+	_, err = uc.PatchStation(adminContext(), request.PatchStationRequest{
+		ID:        id.Hex(),
+		PatchType: constants.MergePatchContentType,
+		Patch:     []byte(`{"nmae": "typo"}`),
+	})
+	assert.ErrorIs(t, err, usecase.ErrInvalidStationDocument)
+
+	_, err = uc.PatchStation(adminContext(), request.PatchStationRequest{
+		ID:        id.Hex(),
+		PatchType: constants.JSONPatchContentType,
+		Patch:     []byte(`[{"op": "replace", "path": "/status/time_zone", "value": "Mars/Olympus_Mons"}]`),
+	})
+	assert.ErrorIs(t, err, usecase.ErrInvalidStationDocument)
+}
+
+func TestPatchStation_IgnoresInvalidUntouchedConnector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	// a legacy connector stored before the plug rules: Type2 is AC, not DC
+	id := primitive.NewObjectID()
+	legacy := patchTestStation(id)
+	legacy.Connectors = append(legacy.Connectors, repoModels.ConnectorDB{ConnectorID: "C2", Type: constants.DC, PlugName: constants.Type2, PricePerUnit: 7, PowerOutput: 22})
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(legacy, nil).Times(3)
+	mockRepo.EXPECT().UpdateStationFields(gomock.Any(), gomock.Any(), []string{"name"}).Return(nil)
+
+	_, err := uc.PatchStation(adminContext(), request.PatchStationRequest{
+		ID:        id.Hex(),
+		PatchType: constants.MergePatchContentType,
+		Patch:     []byte(`{"name": "Central Plaza"}`),
+	})
+	assert.NoError(t, err)
+
+	// touching the invalid connector reports it
+	_, err = uc.PatchStation(adminContext(), request.PatchStationRequest{
+		ID:        id.Hex(),
+		PatchType: constants.JSONPatchContentType,
+		Patch:     []byte(`[{"op": "replace", "path": "/connectors/1/price_per_unit", "value": 8}]`),
+	})
+	var validationErr *usecase.StationValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "connectors[1].type", validationErr.Fields[0].Field)
+}
+
+func TestEditStation_StaleIfMatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), id.Hex()).
+		Return(&repoModels.EVStationDB{ID: id, Version: 4}, nil)
+
+	stale := int64(3)
+	name := "Renamed"
+	_, err := uc.EditStation(adminContext(), request.EditStationRequest{ID: id.Hex(), Name: &name, ExpectedVersion: &stale})
+	assert.ErrorIs(t, err, usecase.ErrPreconditionFailed)
+}
+
+func TestEditStation_LostRaceIsPreconditionFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), id.Hex()).
+		Return(&repoModels.EVStationDB{ID: id, Version: 4}, nil)
+	mockRepo.EXPECT().
+		EditStation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, station models.EVStation) error {
+			// the write is conditional on the version that was read
+			assert.Equal(t, int64(4), station.Version)
+			return repository.ErrVersionConflict
+		})
+
+	current := int64(4)
+	name := "Renamed"
+	_, err := uc.EditStation(adminContext(), request.EditStationRequest{ID: id.Hex(), Name: &name, ExpectedVersion: &current})
+	assert.ErrorIs(t, err, usecase.ErrPreconditionFailed)
+}
+
+func TestEditConnector_RecordsAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, mockAudit, nil)
+
+	id := primitive.NewObjectID()
+	before := &repoModels.EVStationDB{ID: id, Version: 2, Connectors: []repoModels.ConnectorDB{
+		{ConnectorID: "C1", Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 5, PowerOutput: 22},
+	}}
+	after := &repoModels.EVStationDB{ID: id, Version: 3, Connectors: []repoModels.ConnectorDB{
+		{ConnectorID: "C1", Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 7, PowerOutput: 22},
+	}}
+	gomock.InOrder(
+		mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(before, nil),
+		mockRepo.EXPECT().EditConnector(gomock.Any(), id.Hex(), int64(2), gomock.Any()).Return(nil),
+		mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(after, nil),
+	)
+	mockAudit.EXPECT().
+		Record(gomock.Any(), constants.AuditConnectorEdit, constants.AuditTargetStation, id.Hex(), gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, _ constants.AuditAction, _ string, _ string, b interface{}, a interface{}) {
+			assert.Equal(t, float64(5), b.(response.EVStationResponse).Connectors[0].PricePerUnit)
+			assert.Equal(t, float64(7), a.(response.EVStationResponse).Connectors[0].PricePerUnit)
+		})
+
+	_, err := uc.EditConnector(adminContext(), request.StationConnectorRequest{
+		StationID: id.Hex(), ConnectorID: "C1", Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 7, PowerOutput: 22,
+	})
+	assert.NoError(t, err)
+}
+
+func TestRemoveStation_RecordsDeletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, mockAudit, nil)
+
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), "stationXYZ").Return(&repoModels.EVStationDB{Name: "Gone"}, nil)
+	mockRepo.EXPECT().SoftDeleteStation(gomock.Any(), "stationXYZ", "admin", gomock.Any()).Return(nil)
+	mockAudit.EXPECT().
+		Record(gomock.Any(), constants.AuditStationDelete, constants.AuditTargetStation, "stationXYZ", gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, _ constants.AuditAction, _ string, _ string, b interface{}, a interface{}) {
+			assert.Empty(t, b.(response.EVStationResponse).DeletedBy)
+			assert.Equal(t, "admin", a.(response.EVStationResponse).DeletedBy)
+		})
+
+	err := uc.RemoveStation(adminContext(), request.RemoveStationRequest{ID: "stationXYZ", DeletedBy: "admin"})
+	assert.NoError(t, err)
+}
+
+func TestGetStationAsOf_UsesLatestRevisionBefore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	old := patchTestStation(id)
+	old.Connectors[0].PricePerUnit = 4
+	mockRepo.EXPECT().
+		FindStationRevisionAsOf(gomock.Any(), id.Hex(), time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)).
+		Return(&repoModels.StationRevisionDB{StationID: id, Version: 2, Station: *old}, nil)
+
+	resp, err := uc.GetStationAsOf(adminContext(), request.GetStationAsOfRequest{ID: id.Hex(), AsOf: "2025-04-01T00:00:00"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(4), resp.Connectors[0].PricePerUnit)
+
+	_, err = uc.GetStationAsOf(adminContext(), request.GetStationAsOfRequest{ID: id.Hex(), AsOf: "last month"})
+	assert.Error(t, err)
+
+	alice := audit.WithActor(context.TODO(), audit.Actor{UserID: "u1", UserName: "alice", Role: constants.RoleUser})
+	_, err = uc.GetStationAsOf(alice, request.GetStationAsOfRequest{ID: id.Hex(), AsOf: "2025-04-01T00:00:00"})
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+}
+
+func TestGetStationAsOf_WithoutRevisionFallsBackToCurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	current := patchTestStation(id)
+	updatedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	current.UpdatedAt = &updatedAt
+	mockRepo.EXPECT().
+		FindStationRevisionAsOf(gomock.Any(), id.Hex(), gomock.Any()).
+		Return(nil, repository.ErrRevisionNotFound).
+		Times(3)
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(current, nil).Times(2)
+
+	resp, err := uc.GetStationAsOf(adminContext(), request.GetStationAsOfRequest{ID: id.Hex(), AsOf: "2025-04-01T00:00:00"})
+	assert.NoError(t, err)
+	assert.Equal(t, id.Hex(), resp.ID)
+
+	_, err = uc.GetStationAsOf(adminContext(), request.GetStationAsOfRequest{ID: id.Hex(), AsOf: "2025-02-01T00:00:00Z"})
+	assert.ErrorIs(t, err, usecase.ErrRevisionNotFound)
+
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(nil, mongo.ErrNoDocuments)
+	_, err = uc.GetStationAsOf(adminContext(), request.GetStationAsOfRequest{ID: id.Hex(), AsOf: "2025-04-01T00:00:00"})
+	assert.ErrorIs(t, err, usecase.ErrRevisionNotFound)
+}
+
+func TestRevertStation_RestoresFieldsAndKeepsBookings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	current := patchTestStation(id)
+	current.Version = 6
+	current.Name = "Renamed"
+	current.Connectors[0].PricePerUnit = 9
+
+	old := patchTestStation(id)
+	old.Version = 2
+	old.Connectors[0].Booking = nil
+	old.Connectors = append(old.Connectors, repoModels.ConnectorDB{ConnectorID: "C2", Type: constants.DC, PlugName: constants.CCSType2, PricePerUnit: 8, PowerOutput: 50})
+
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(current, nil)
+	mockRepo.EXPECT().FindStationRevision(gomock.Any(), id.Hex(), int64(2)).Return(&repoModels.StationRevisionDB{StationID: id, Version: 2, Station: *old}, nil)
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "C2").Return(nil, repository.ErrConnectorNotFound)
+	mockRepo.EXPECT().
+		EditStation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, station models.EVStation) error {
+			assert.Equal(t, int64(6), station.Version)
+			assert.Equal(t, "Central", station.Name)
+			assert.Len(t, station.Connectors, 2)
+			assert.Equal(t, float64(5), station.Connectors[0].PricePerUnit)
+			// the live booking on C1 survives the revert
+			assert.NotNil(t, station.Connectors[0].Booking)
+			assert.Equal(t, "alice", station.Connectors[0].Booking.Username)
+			return nil
+		})
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(old, nil)
+
+	expected := int64(6)
+	_, err := uc.RevertStation(adminContext(), request.RevertStationRequest{ID: id.Hex(), Version: 2, ExpectedVersion: &expected})
+	assert.NoError(t, err)
+}
+
+func TestRevertStation_RefusesToDropBookedConnector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	current := patchTestStation(id)
+	old := patchTestStation(id)
+	old.Connectors = []repoModels.ConnectorDB{{ConnectorID: "C0", Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 5, PowerOutput: 7}}
+
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(current, nil)
+	mockRepo.EXPECT().FindStationRevision(gomock.Any(), id.Hex(), int64(1)).Return(&repoModels.StationRevisionDB{Station: *old}, nil)
+
+	_, err := uc.RevertStation(adminContext(), request.RevertStationRequest{ID: id.Hex(), Version: 1})
+	assert.ErrorContains(t, err, "connector C1 has an active booking")
+}
+
+func TestRevertStation_RefusesInvalidOrTakenConnectors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	id := primitive.NewObjectID()
+	current := patchTestStation(id)
+
+	// recorded before a DC plug on an AC connector was refused
+	invalid := patchTestStation(id)
+	invalid.Connectors = append(invalid.Connectors, repoModels.ConnectorDB{ConnectorID: "C2", Type: constants.AC, PlugName: constants.CCSType2, PricePerUnit: 8, PowerOutput: 50})
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), id.Hex()).Return(current, nil).Times(2)
+	mockRepo.EXPECT().FindStationRevision(gomock.Any(), id.Hex(), int64(1)).Return(&repoModels.StationRevisionDB{Station: *invalid}, nil)
+
+	_, err := uc.RevertStation(adminContext(), request.RevertStationRequest{ID: id.Hex(), Version: 1})
+	var validationErr *usecase.StationValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "connectors[1].type", validationErr.Fields[0].Field)
+
+	// C2 was removed from this station and has been given to another one since
+	taken := patchTestStation(id)
+	taken.Connectors = append(taken.Connectors, repoModels.ConnectorDB{ConnectorID: "C2", Type: constants.DC, PlugName: constants.CCSType2, PricePerUnit: 8, PowerOutput: 50})
+	mockRepo.EXPECT().FindStationRevision(gomock.Any(), id.Hex(), int64(2)).Return(&repoModels.StationRevisionDB{Station: *taken}, nil)
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "C2").Return(patchTestStation(primitive.NewObjectID()), nil)
+
+	_, err = uc.RevertStation(adminContext(), request.RevertStationRequest{ID: id.Hex(), Version: 2})
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "connectors[1].connector_id", validationErr.Fields[0].Field)
+}
+
+func TestImportStations_DryRunReportsRowErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	data := []byte(`[
+		{"external_ref": "EXT-1", "name": "Central", "latitude": 13.75, "longitude": 100.5, "company": "EV Co",
+		 "status": {"always_open": true},
+		 "connectors": [{"type": "AC", "plug_name": "TYPE 2", "price_per_unit": 5, "power_output": 22}]},
+		{"external_ref": "EXT-2", "name": "Broken", "latitude": 100, "longitude": 100.5, "company": "EV Co",
+		 "status": {"always_open": true},
+		 "connectors": [{"type": "AC", "plug_name": "CHAdeMO", "price_per_unit": -1, "power_output": 50}]},
+		{"name": "No Ref", "latitude": 13.7, "longitude": 100.4, "company": "EV Co",
+		 "status": {"always_open": true}, "connectors": []}
+	]`)
+
+	// dry run: only the lookup for the valid row, nothing is written
+	mockRepo.EXPECT().FindStationByExternalRef(gomock.Any(), "EXT-1").Return(nil, nil)
+
+	report, err := uc.ImportStations(adminContext(), request.ImportStationsRequest{Data: data, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 2, report.Failed)
+
+	assert.Equal(t, constants.ImportActionCreate, report.Rows[0].Action)
+	assert.Equal(t, constants.ImportActionError, report.Rows[1].Action)
+	assert.ElementsMatch(t, []response.FieldError{
+		{Field: "latitude", Message: "must be between -90 and 90"},
+		{Field: "connectors[0].type", Message: "plug CHAdeMO is DC, not AC"},
+		{Field: "connectors[0].price_per_unit", Message: "must be positive"},
+	}, report.Rows[1].Errors)
+	assert.Equal(t, 3, report.Rows[2].Row)
+	assert.Equal(t, []response.FieldError{{Field: "external_ref", Message: "is required for imports"}}, report.Rows[2].Errors)
+}
+
+func TestImportStations_CSVUpsertsByExternalRef(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	existing := patchTestStation(primitive.NewObjectID())
+	existing.ExternalRef = "EXT-2"
+	existing.Connectors[0].Booking = nil
+
+	data := []byte("external_ref,name,latitude,longitude,company,time_zone,always_open,connector_type,plug_name,price_per_unit,power_output\n" +
+		"EXT-1,North,13.9,100.6,EV Co,Asia/Bangkok,true,AC,TYPE 2,5,22\n" +
+		"EXT-1,North,13.9,100.6,EV Co,Asia/Bangkok,true,DC,CCS TYPE 2,9,120\n" +
+		"EXT-2,Central,13.75,100.5,EV Co,Asia/Bangkok,true,AC,TYPE 2,5,22\n")
+
+	mockRepo.EXPECT().FindStationByExternalRef(gomock.Any(), "EXT-1").Return(nil, nil)
+	mockRepo.EXPECT().
+		CreateStation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, station models.EVStation) error {
+			assert.Equal(t, "EXT-1", station.ExternalRef)
+			assert.Len(t, station.Connectors, 2)
+			return nil
+		})
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), gomock.Any()).Return(&repoModels.EVStationDB{Name: "North"}, nil)
+	mockRepo.EXPECT().FindStationByExternalRef(gomock.Any(), "EXT-2").Return(existing, nil)
+
+	report, err := uc.ImportStations(adminContext(), request.ImportStationsRequest{Format: constants.ImportFormatCSV, Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 2, report.Rows[0].Row)
+	assert.Equal(t, constants.ImportActionCreate, report.Rows[0].Action)
+	assert.Equal(t, 4, report.Rows[1].Row)
+	assert.Equal(t, constants.ImportActionUnchanged, report.Rows[1].Action)
+	assert.Equal(t, existing.ID.Hex(), report.Rows[1].StationID)
+}
+
+func TestImportStations_AcceptsExportedCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	data := []byte("station_id,external_ref,name,latitude,longitude,company,time_zone,always_open,open_hours,close_hours,is_open,connector_id,connector_type,plug_name,price_per_unit,power_output\n" +
+		"6ad5f444aaaf96e5f4d8fc7d,EXT-1,North,13.9,100.6,EV Co,Asia/Bangkok,false,08:00,20:00,true,C7,AC,TYPE 2,5,22\n")
+
+	mockRepo.EXPECT().FindStationByExternalRef(gomock.Any(), "EXT-1").Return(nil, nil)
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "C7").Return(nil, repository.ErrConnectorNotFound)
+	mockRepo.EXPECT().
+		CreateStation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, station models.EVStation) error {
+			assert.Equal(t, "08:00", station.Status.Schedule.Weekly[0].Intervals[0].Open)
+			assert.Equal(t, "C7", station.Connectors[0].ConnectorID)
+			return nil
+		})
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), gomock.Any()).Return(&repoModels.EVStationDB{Name: "North"}, nil)
+
+	report, err := uc.ImportStations(adminContext(), request.ImportStationsRequest{Format: constants.ImportFormatCSV, Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.ImportActionCreate, report.Rows[0].Action)
+}
+
+func TestImportStations_CreateKeepsSuppliedConnectorIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	data := []byte(`[{"external_ref": "EXT-1", "name": "North", "latitude": 13.9, "longitude": 100.6, "company": "EV Co",
+		"status": {"time_zone": "Asia/Bangkok", "always_open": true},
+		"connectors": [{"connector_id": "EXT-1-A", "type": "AC", "plug_name": "TYPE 2", "price_per_unit": 5, "power_output": 22}]},
+		{"external_ref": "EXT-2", "name": "South", "latitude": 13.6, "longitude": 100.4, "company": "EV Co",
+		"status": {"time_zone": "Asia/Bangkok", "always_open": true},
+		"connectors": [{"connector_id": "C1", "type": "AC", "plug_name": "TYPE 2", "price_per_unit": 5, "power_output": 22}]}]`)
+
+	mockRepo.EXPECT().FindStationByExternalRef(gomock.Any(), "EXT-1").Return(nil, nil)
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "EXT-1-A").Return(nil, repository.ErrConnectorNotFound)
+	mockRepo.EXPECT().
+		CreateStation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, station models.EVStation) error {
+			assert.Equal(t, "EXT-1-A", station.Connectors[0].ConnectorID)
+			return nil
+		})
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), gomock.Any()).Return(&repoModels.EVStationDB{Name: "North"}, nil)
+
+	// C1 already belongs to another station
+	mockRepo.EXPECT().FindStationByExternalRef(gomock.Any(), "EXT-2").Return(nil, nil)
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "C1").Return(patchTestStation(primitive.NewObjectID()), nil)
+
+	report, err := uc.ImportStations(adminContext(), request.ImportStationsRequest{Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.ImportActionCreate, report.Rows[0].Action)
+	assert.Equal(t, constants.ImportActionError, report.Rows[1].Action)
+	assert.Equal(t, "connectors[0].connector_id", report.Rows[1].Errors[0].Field)
+}
+
+func TestImportStations_UpdateKeepsConnectorIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	existing := patchTestStation(primitive.NewObjectID())
+	existing.ExternalRef = "EXT-1"
+
+	data := []byte(`[{"external_ref": "EXT-1", "name": "Central Plaza", "latitude": 13.75, "longitude": 100.5, "company": "EV Co",
+		"status": {"time_zone": "Asia/Bangkok", "always_open": true},
+		"connectors": [{"type": "AC", "plug_name": "TYPE 2", "price_per_unit": 5, "power_output": 22}]}]`)
+
+	mockRepo.EXPECT().FindStationByExternalRef(gomock.Any(), "EXT-1").Return(existing, nil)
+	mockRepo.EXPECT().
+		EditStation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, station models.EVStation) error {
+			assert.Equal(t, "Central Plaza", station.Name)
+			assert.Equal(t, "C1", station.Connectors[0].ConnectorID)
+			assert.NotNil(t, station.Connectors[0].Booking)
+			return nil
+		})
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), existing.ID.Hex()).Return(existing, nil)
+
+	report, err := uc.ImportStations(adminContext(), request.ImportStationsRequest{Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, constants.ImportActionUpdate, report.Rows[0].Action)
+}
+
+func TestImportStations_UpdateAddsConnectorWithSuppliedID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	existing := patchTestStation(primitive.NewObjectID())
+	existing.ExternalRef = "EXT-1"
+
+	data := []byte(`[{"external_ref": "EXT-1", "name": "Central", "latitude": 13.75, "longitude": 100.5, "company": "EV Co",
+		"status": {"time_zone": "Asia/Bangkok", "always_open": true},
+		"connectors": [{"connector_id": "C1", "type": "AC", "plug_name": "TYPE 2", "price_per_unit": 5, "power_output": 22},
+			{"connector_id": "OP-1-3", "type": "DC", "plug_name": "CCS TYPE 2", "price_per_unit": 8, "power_output": 50}]}]`)
+
+	mockRepo.EXPECT().FindStationByExternalRef(gomock.Any(), "EXT-1").Return(existing, nil).Times(2)
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "OP-1-3").Return(nil, repository.ErrConnectorNotFound)
+	mockRepo.EXPECT().
+		EditStation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, station models.EVStation) error {
+			assert.Len(t, station.Connectors, 2)
+			assert.Equal(t, "C1", station.Connectors[0].ConnectorID)
+			assert.NotNil(t, station.Connectors[0].Booking)
+			assert.Equal(t, "OP-1-3", station.Connectors[1].ConnectorID)
+			return nil
+		})
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), existing.ID.Hex()).Return(existing, nil)
+
+	report, err := uc.ImportStations(adminContext(), request.ImportStationsRequest{Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.ImportActionUpdate, report.Rows[0].Action)
+
+	// the ID was taken by another station in the meantime
+	mockRepo.EXPECT().FindStationByConnectorID(gomock.Any(), "OP-1-3").Return(patchTestStation(primitive.NewObjectID()), nil)
+	report, err = uc.ImportStations(adminContext(), request.ImportStationsRequest{Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.ImportActionError, report.Rows[0].Action)
+	assert.Equal(t, "connectors[1].connector_id", report.Rows[0].Errors[0].Field)
+}
+
+func TestImportStations_InvalidFile(t *testing.T) {
+	uc := usecase.NewEVStationUsecase(nil, nil, nil, nil)
+
+	_, err := uc.ImportStations(adminContext(), request.ImportStationsRequest{
+		Format: constants.ImportFormatCSV,
+		Data:   []byte("name,latitude\nCentral,13.75\n"),
+	})
+	assert.ErrorIs(t, err, usecase.ErrInvalidImportFile)
+}
+
+func TestImportOpenChargeMap_MapsSitesAndReportsUnmappedTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	data := []byte(`[
+		{"ID": 101, "OperatorInfo": {"ID": 3534, "Title": "EV Co"},
+		 "AddressInfo": {"Title": "Central Plaza", "Latitude": 13.7563, "Longitude": 100.5018},
+		 "Connections": [
+			{"ConnectionTypeID": 25, "ConnectionType": {"Title": "Type 2 (Socket Only)"}, "PowerKW": 22, "Quantity": 2},
+			{"ConnectionTypeID": 33, "ConnectionType": {"Title": "CCS (Type 2)"}, "PowerKW": 120.4},
+			{"ConnectionTypeID": 27, "ConnectionType": {"Title": "Tesla Supercharger"}, "PowerKW": 150}
+		 ]},
+		{"ID": 102, "OperatorInfo": null,
+		 "AddressInfo": {"Title": "Mall Parking", "Latitude": 13.8, "Longitude": 100.55},
+		 "Connections": [{"ConnectionTypeID": 28, "ConnectionType": {"Title": "Schuko"}, "PowerKW": 3.7}]}
+	]`)
+
+	mockRepo.EXPECT().FindStationsInArea(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().FindStationByExternalRef(gomock.Any(), "ocm:101").Return(nil, nil)
+	mockRepo.EXPECT().
+		CreateStation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, station models.EVStation) error {
+			assert.Equal(t, "ocm:101", station.ExternalRef)
+			assert.Equal(t, "Central Plaza", station.Name)
+			assert.Equal(t, "EV Co", station.Company)
+			assert.True(t, station.Status.Schedule.AlwaysOpen)
+			assert.Len(t, station.Connectors, 3)
+			assert.Equal(t, constants.Type2, station.Connectors[1].PlugName)
+			assert.Equal(t, constants.DC, station.Connectors[2].Type)
+			assert.Equal(t, constants.CCSType2, station.Connectors[2].PlugName)
+			assert.Equal(t, 120, station.Connectors[2].PowerOutput)
+			assert.Equal(t, 7.5, station.Connectors[2].PricePerUnit)
+			return nil
+		})
+	mockRepo.EXPECT().FindStationByID(gomock.Any(), gomock.Any()).Return(&repoModels.EVStationDB{Name: "Central Plaza"}, nil)
+
+	report, err := uc.ImportOpenChargeMap(adminContext(), request.ImportOpenChargeMapRequest{PricePerUnit: 7.5, Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, []response.FieldError{{Field: "Connections", Message: "no connection of a supported type"}}, report.Rows[1].Errors)
+	assert.Equal(t, []response.UnmappedConnectionType{
+		{ID: 27, Title: "Tesla Supercharger", Connections: 1},
+		{ID: 28, Title: "Schuko", Connections: 1},
+	}, report.UnmappedConnectionTypes)
+}
+
+func TestImportOpenChargeMap_SkipsNearbyStationsWithSimilarNames(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	// about 20 m from the first site and named alike
+	nearby := repoModels.EVStationDB{ID: primitive.NewObjectID(), Name: "CENTRAL PLAZA - Charging", Latitude: 13.7565, Longitude: 100.5017}
+	// the station created by a previous import of the same site
+	own := repoModels.EVStationDB{ID: primitive.NewObjectID(), ExternalRef: "ocm:202", Name: "Riverside", Latitude: 13.70, Longitude: 100.49}
+
+	data := []byte(`[
+		{"ID": 201, "AddressInfo": {"Title": "Central Plaza", "Latitude": 13.7563, "Longitude": 100.5018},
+		 "Connections": [{"ConnectionTypeID": 25, "PowerKW": 22}]},
+		{"ID": 202, "AddressInfo": {"Title": "Riverside", "Latitude": 13.70, "Longitude": 100.49},
+		 "Connections": [{"ConnectionTypeID": 2, "PowerKW": 50}]},
+		{"ID": 203, "AddressInfo": {"Title": "Riverside", "Latitude": 13.7001, "Longitude": 100.49},
+		 "Connections": [{"ConnectionTypeID": 2, "PowerKW": 50}]}
+	]`)
+
+	mockRepo.EXPECT().
+		FindStationsInArea(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, minLat, maxLat, minLng, maxLng float64) ([]repoModels.EVStationDB, error) {
+			assert.InDelta(t, 13.7563-50.0/111320, minLat, 1e-9)
+			return []repoModels.EVStationDB{nearby}, nil
+		})
+	mockRepo.EXPECT().FindStationsInArea(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]repoModels.EVStationDB{own}, nil)
+	mockRepo.EXPECT().FindStationByExternalRef(gomock.Any(), "ocm:202").Return(&own, nil)
+
+	report, err := uc.ImportOpenChargeMap(adminContext(), request.ImportOpenChargeMapRequest{PricePerUnit: 7.5, DryRun: true, Data: data})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Duplicates)
+	assert.Equal(t, constants.ImportActionDuplicate, report.Rows[0].Action)
+	assert.Equal(t, nearby.ID.Hex(), report.Rows[0].DuplicateOf)
+	assert.Equal(t, constants.ImportActionUpdate, report.Rows[1].Action)
+	assert.Equal(t, own.ID.Hex(), report.Rows[1].StationID)
+	assert.Equal(t, constants.ImportActionDuplicate, report.Rows[2].Action)
+	assert.Equal(t, "ocm:202", report.Rows[2].DuplicateOf)
+}
+
+func TestExportStations_StreamsFilteredStations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewEVStationUsecase(mockRepo, nil, nil, nil)
+
+	today := time.Now().In(time.UTC).Format("2006-01-02")
+	mockRepo.EXPECT().
+		StreamStations(gomock.Any(), "EV Co", "DC", "", "", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, fn func(repoModels.EVStationDB) error) error {
+			for _, station := range []repoModels.EVStationDB{
+				{Name: "24/7", Status: repoModels.StationStatusDB{Schedule: &repoModels.OpeningHoursDB{TimeZone: "UTC", AlwaysOpen: true}}},
+				{Name: "Holiday", Status: repoModels.StationStatusDB{Schedule: &repoModels.OpeningHoursDB{
+					TimeZone:   "UTC",
+					AlwaysOpen: true,
+					Exceptions: []repoModels.ScheduleExceptionDB{{Date: today, Closed: true}},
+				}}},
+			} {
+				if err := fn(station); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	var exported []string
+	err := uc.ExportStations(context.TODO(), request.StationFilterRequest{Company: "EV Co", Type: "DC", Status: "open"}, func(station response.EVStationResponse) error {
+		exported = append(exported, station.Name)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"24/7"}, exported)
+}
+
+func TestExportStations_InvalidStatus(t *testing.T) {
+	uc := usecase.NewEVStationUsecase(nil, nil, nil, nil)
+
+	err := uc.ExportStations(context.TODO(), request.StationFilterRequest{Status: "maybe"}, func(response.EVStationResponse) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//go:generate mockgen -source=login_protection_usecase.go -destination=../mocks/mock_login_protection_usecase.go -package=mocks
+type LoginProtectionUsecase interface {
+	// CheckLogin returns a *LoginThrottledError while the account or the IP is locked or must wait
+	CheckLogin(ctx context.Context, accountKey string, clientIP string) error
+	RecordLoginFailure(ctx context.Context, accountKey string, clientIP string) error
+	RecordLoginSuccess(ctx context.Context, accountKey string) error
+	UnlockAccount(ctx context.Context, userID string) error
+}
+
+// ErrTooManyLoginAttempts matches every *LoginThrottledError
+var ErrTooManyLoginAttempts = errors.New("too many failed login attempts")
+
+// LoginThrottledError tells the client how long to wait before the next login attempt
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool // locked out after too many failures, rather than waiting out the progressive delay
+}
+
+func (e *LoginThrottledError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("%s, login is locked for %s", ErrTooManyLoginAttempts, e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("%s, try again in %s", ErrTooManyLoginAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginThrottledError) Is(target error) bool {
+	return target == ErrTooManyLoginAttempts
+}
+
+type loginProtectionUsecase struct {
+	attemptRepo repository.LoginAttemptRepository
+	auditLog    AuditRecorder
+	config      configs.LoginProtectionConfig
+}
+
+// NewLoginProtectionUsecase counts failed logins per account and per client IP. After each
+// failure the next attempt must wait BaseDelay, doubled per further failure up to MaxDelay;
+// reaching MaxAccountFailures or MaxIPFailures locks the key for LockoutDuration.
+func NewLoginProtectionUsecase(attemptRepo repository.LoginAttemptRepository, auditLog AuditRecorder, config configs.LoginProtectionConfig) LoginProtectionUsecase {
+	if auditLog == nil {
+		auditLog = nopAuditRecorder{}
+	}
+	return &loginProtectionUsecase{attemptRepo: attemptRepo, auditLog: auditLog, config: config}
+}
+
+// loginAccountKey counts a known account by ID, so its username and email share one counter.
+// Unknown names are counted too, and locked the same way, so a lockout does not reveal
+// whether an account exists. Each unknown name has its own counter, see MaxAccountFailures.
+func loginAccountKey(user *models.UserModel, usernameOrEmail string) string {
+	if user != nil {
+		return userLoginKey(user.ID)
+	}
+	return "account:" + strings.ToLower(strings.TrimSpace(usernameOrEmail))
+}
+
+func userLoginKey(userID string) string {
+	return "user:" + userID
+}
+
+func ipLoginKey(clientIP string) string {
+	return "ip:" + clientIP
+}
+
+func (u *loginProtectionUsecase) CheckLogin(ctx context.Context, accountKey string, clientIP string) error {
+	now := time.Now()
+	var throttled *LoginThrottledError
+	for _, key := range u.keys(accountKey, clientIP) {
+		attempt, err := u.attemptRepo.FindLoginAttempt(ctx, key)
+		if err != nil {
+			return err
+		}
+		if wait := u.waitFor(attempt, now); wait != nil && (throttled == nil || wait.RetryAfter > throttled.RetryAfter) {
+			throttled = wait
+		}
+	}
+	if throttled != nil {
+		return throttled
+	}
+	return nil
+}
+
+func (u *loginProtectionUsecase) RecordLoginFailure(ctx context.Context, accountKey string, clientIP string) error {
+	now := time.Now()
+	for _, key := range u.keys(accountKey, clientIP) {
+		attempt, err := u.attemptRepo.IncrementLoginFailures(ctx, key, now, u.config.FailureWindow)
+		if err != nil {
+			return err
+		}
+		if attempt.Failures < u.maxFailures(key) {
+			continue
+		}
+
+		lockedUntil := now.Add(u.config.LockoutDuration)
+		if err := u.attemptRepo.LockLogin(ctx, key, lockedUntil); err != nil {
+			return err
+		}
+		u.auditLockout(ctx, key, attempt.Failures, lockedUntil)
+	}
+	return nil
+}
+
+// RecordLoginSuccess clears the account counter only; the IP keeps counting failures against other accounts
+func (u *loginProtectionUsecase) RecordLoginSuccess(ctx context.Context, accountKey string) error {
+	return u.attemptRepo.ClearLoginAttempts(ctx, accountKey)
+}
+
+func (u *loginProtectionUsecase) UnlockAccount(ctx context.Context, userID string) error {
+	return u.attemptRepo.ClearLoginAttempts(ctx, userLoginKey(userID))
+}
+
+func (u *loginProtectionUsecase) keys(accountKey string, clientIP string) []string {
+	keys := []string{accountKey}
+	if clientIP != "" {
+		keys = append(keys, ipLoginKey(clientIP))
+	}
+	return keys
+}
+
+func (u *loginProtectionUsecase) maxFailures(key string) int {
+	if strings.HasPrefix(key, "ip:") {
+		return u.config.MaxIPFailures
+	}
+	return u.config.MaxAccountFailures
+}
+
+// waitFor returns nil when attempt allows a login at now
+func (u *loginProtectionUsecase) waitFor(attempt *models.LoginAttempt, now time.Time) *LoginThrottledError {
+	if attempt == nil {
+		return nil
+	}
+	if attempt.LockedUntil != nil && now.Before(*attempt.LockedUntil) {
+		return &LoginThrottledError{RetryAfter: attempt.LockedUntil.Sub(now), Locked: true}
+	}
+	if attempt.Failures == 0 || attempt.LastFailureAt.Before(now.Add(-u.config.FailureWindow)) {
+		return nil
+	}
+
+	delay := u.config.BaseDelay
+	for i := 1; i < attempt.Failures && delay < u.config.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > u.config.MaxDelay {
+		delay = u.config.MaxDelay
+	}
+	if next := attempt.LastFailureAt.Add(delay); now.Before(next) {
+		return &LoginThrottledError{RetryAfter: next.Sub(now)}
+	}
+	return nil
+}
+
+// auditLockout records account and IP lockouts; unknown account names are locked without an entry
+func (u *loginProtectionUsecase) auditLockout(ctx context.Context, key string, failures int, lockedUntil time.Time) {
+	after := map[string]interface{}{"failures": failures, "locked_until": lockedUntil.UTC()}
+	switch {
+	case strings.HasPrefix(key, "user:"):
+		u.auditLog.Record(ctx, constants.AuditLoginLockout, constants.AuditTargetUser, strings.TrimPrefix(key, "user:"), nil, after)
+	case strings.HasPrefix(key, "ip:"):
+		u.auditLog.Record(ctx, constants.AuditLoginLockout, constants.AuditTargetIP, strings.TrimPrefix(key, "ip:"), nil, after)
+	}
+}
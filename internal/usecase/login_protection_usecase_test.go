@@ -0,0 +1,119 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/internal/usecase"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testLoginProtectionConfig = configs.LoginProtectionConfig{
+	MaxAccountFailures: 3,
+	MaxIPFailures:      5,
+	FailureWindow:      time.Hour,
+	LockoutDuration:    30 * time.Minute,
+	BaseDelay:          time.Minute,
+	MaxDelay:           3 * time.Minute,
+}
+
+func loginThrottle(t *testing.T, err error) *usecase.LoginThrottledError {
+	var throttled *usecase.LoginThrottledError
+	if !errors.As(err, &throttled) {
+		t.Fatalf("expected a throttled login, got %v", err)
+	}
+	return throttled
+}
+
+func TestLoginProtection_DelayDoublesUpToMax(t *testing.T) {
+	config := testLoginProtectionConfig
+	config.MaxAccountFailures = 10
+	uc := usecase.NewLoginProtectionUsecase(repository.NewMemoryLoginAttemptRepository(), nil, config)
+	ctx := context.TODO()
+
+	assert.NoError(t, uc.CheckLogin(ctx, "user:u1", ""))
+
+	// 1, 2, then 4 minutes capped at MaxDelay
+	for _, delay := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+		assert.NoError(t, uc.RecordLoginFailure(ctx, "user:u1", ""))
+		throttled := loginThrottle(t, uc.CheckLogin(ctx, "user:u1", ""))
+		assert.False(t, throttled.Locked)
+		assert.InDelta(t, delay.Seconds(), throttled.RetryAfter.Seconds(), 1)
+	}
+}
+
+func TestLoginProtection_LocksAccountAndAudits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewLoginProtectionUsecase(repository.NewMemoryLoginAttemptRepository(), mockAudit, testLoginProtectionConfig)
+	ctx := context.TODO()
+
+	mockAudit.EXPECT().
+		Record(gomock.Any(), constants.AuditLoginLockout, constants.AuditTargetUser, "u1", nil, gomock.Any()).
+		Do(func(_ context.Context, _ constants.AuditAction, _ string, _ string, _ interface{}, after interface{}) {
+			assert.Equal(t, 3, after.(map[string]interface{})["failures"])
+		})
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, uc.RecordLoginFailure(ctx, "user:u1", "198.51.100.7"))
+	}
+
+	throttled := loginThrottle(t, uc.CheckLogin(ctx, "user:u1", "203.0.113.9"))
+	assert.True(t, throttled.Locked)
+	assert.InDelta(t, (30 * time.Minute).Seconds(), throttled.RetryAfter.Seconds(), 1)
+
+	assert.NoError(t, uc.UnlockAccount(ctx, "u1"))
+	assert.NoError(t, uc.CheckLogin(ctx, "user:u1", "203.0.113.9"))
+}
+
+func TestLoginProtection_LocksIPAcrossAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewLoginProtectionUsecase(repository.NewMemoryLoginAttemptRepository(), mockAudit, testLoginProtectionConfig)
+	ctx := context.TODO()
+
+	mockAudit.EXPECT().Record(gomock.Any(), constants.AuditLoginLockout, constants.AuditTargetIP, "198.51.100.7", nil, gomock.Any())
+
+	for _, account := range []string{"account:a", "account:b", "account:c", "account:d", "account:e"} {
+		assert.NoError(t, uc.RecordLoginFailure(ctx, account, "198.51.100.7"))
+	}
+
+	throttled := loginThrottle(t, uc.CheckLogin(ctx, "user:u1", "198.51.100.7"))
+	assert.True(t, throttled.Locked)
+	assert.NoError(t, uc.CheckLogin(ctx, "user:u1", "203.0.113.9"))
+}
+
+func TestLoginProtection_SuccessClearsAccountButNotIP(t *testing.T) {
+	uc := usecase.NewLoginProtectionUsecase(repository.NewMemoryLoginAttemptRepository(), nil, testLoginProtectionConfig)
+	ctx := context.TODO()
+
+	assert.NoError(t, uc.RecordLoginFailure(ctx, "user:u1", "198.51.100.7"))
+	assert.NoError(t, uc.RecordLoginSuccess(ctx, "user:u1"))
+
+	assert.NoError(t, uc.CheckLogin(ctx, "user:u1", ""))
+	assert.ErrorIs(t, uc.CheckLogin(ctx, "user:u1", "198.51.100.7"), usecase.ErrTooManyLoginAttempts)
+}
+
+func TestLoginProtection_IgnoresFailuresOutsideWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockLoginAttemptRepository(ctrl)
+	uc := usecase.NewLoginProtectionUsecase(mockRepo, nil, testLoginProtectionConfig)
+
+	expiredLock := time.Now().Add(-time.Minute)
+	mockRepo.EXPECT().FindLoginAttempt(gomock.Any(), "user:u1").
+		Return(&models.LoginAttempt{Key: "user:u1", Failures: 2, LastFailureAt: time.Now().Add(-2 * time.Hour), LockedUntil: &expiredLock}, nil)
+
+	assert.NoError(t, uc.CheckLogin(context.TODO(), "user:u1", ""))
+}
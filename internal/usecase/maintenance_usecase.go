@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/internal/authz"
+	"Ev-Charge-Hub/Server/internal/constants"
+	domainModel "Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/notification"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/internal/repository/models"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//go:generate mockgen -source=maintenance_usecase.go -destination=../mocks/mock_maintenance_usecase.go -package=mocks
+type MaintenanceUsecase interface {
+	GetMaintenanceWindows(ctx context.Context, req request.GetMaintenanceWindowsRequest) ([]response.MaintenanceWindowResponse, error)
+	CreateMaintenanceWindow(ctx context.Context, req request.MaintenanceWindowRequest) (*response.CreateMaintenanceWindowResponse, error)
+	EditMaintenanceWindow(ctx context.Context, req request.MaintenanceWindowRequest) (*response.MaintenanceWindowResponse, error)
+	RemoveMaintenanceWindow(ctx context.Context, req request.RemoveMaintenanceWindowRequest) error
+}
+
+type maintenanceUsecase struct {
+	stationRepo repository.EVStationRepository
+	notifier    notification.Notifier
+	auditLog    AuditRecorder
+}
+
+func NewMaintenanceUsecase(stationRepo repository.EVStationRepository, notifier notification.Notifier, auditLog AuditRecorder) MaintenanceUsecase {
+	if auditLog == nil {
+		auditLog = nopAuditRecorder{}
+	}
+	return &maintenanceUsecase{stationRepo: stationRepo, notifier: notifier, auditLog: auditLog}
+}
+
+func (u *maintenanceUsecase) GetMaintenanceWindows(ctx context.Context, req request.GetMaintenanceWindowsRequest) ([]response.MaintenanceWindowResponse, error) {
+	if err := authz.Check(ctx, constants.PermMaintenanceManage); err != nil {
+		return nil, err
+	}
+
+	station, err := u.stationRepo.FindStationByID(ctx, req.StationID)
+	if err != nil {
+		return nil, fmt.Errorf("station not found")
+	}
+
+	result := make([]response.MaintenanceWindowResponse, 0, len(station.MaintenanceWindows))
+	for _, w := range mapMaintenanceWindowsDBToDomain(station.MaintenanceWindows) {
+		result = append(result, mapMaintenanceWindowToResponse(w))
+	}
+	return result, nil
+}
+
+func (u *maintenanceUsecase) CreateMaintenanceWindow(ctx context.Context, req request.MaintenanceWindowRequest) (*response.CreateMaintenanceWindowResponse, error) {
+	if err := authz.Check(ctx, constants.PermMaintenanceManage); err != nil {
+		return nil, err
+	}
+
+	station, err := u.stationRepo.FindStationByID(ctx, req.StationID)
+	if err != nil {
+		return nil, fmt.Errorf("station not found")
+	}
+
+	window, err := buildMaintenanceWindow(req, station)
+	if err != nil {
+		return nil, err
+	}
+	window.ID = primitive.NewObjectID().Hex()
+	window.CreatedBy = req.CreatedBy
+
+	if err := u.stationRepo.AddMaintenanceWindow(ctx, req.StationID, window); err != nil {
+		return nil, err
+	}
+	u.auditLog.Record(ctx, constants.AuditMaintenanceCreate, constants.AuditTargetStation, req.StationID, nil, maintenanceAuditSnapshot(window))
+
+	resp := &response.CreateMaintenanceWindowResponse{
+		Window:        mapMaintenanceWindowToResponse(window),
+		NotifiedUsers: []string{},
+	}
+	if req.Notify {
+		resp.NotifiedUsers = u.notifyAffectedUsers(ctx, station, window)
+	}
+	return resp, nil
+}
+
+func (u *maintenanceUsecase) EditMaintenanceWindow(ctx context.Context, req request.MaintenanceWindowRequest) (*response.MaintenanceWindowResponse, error) {
+	if err := authz.Check(ctx, constants.PermMaintenanceManage); err != nil {
+		return nil, err
+	}
+
+	station, err := u.stationRepo.FindStationByID(ctx, req.StationID)
+	if err != nil {
+		return nil, fmt.Errorf("station not found")
+	}
+
+	var existing *domainModel.MaintenanceWindow
+	for _, w := range mapMaintenanceWindowsDBToDomain(station.MaintenanceWindows) {
+		if w.ID == req.WindowID {
+			found := w
+			existing = &found
+			break
+		}
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("maintenance window not found")
+	}
+
+	window, err := buildMaintenanceWindow(req, station)
+	if err != nil {
+		return nil, err
+	}
+	window.ID = existing.ID
+	window.CreatedBy = existing.CreatedBy
+
+	if err := u.stationRepo.EditMaintenanceWindow(ctx, req.StationID, window); err != nil {
+		return nil, err
+	}
+	u.auditLog.Record(ctx, constants.AuditMaintenanceEdit, constants.AuditTargetStation, req.StationID, maintenanceAuditSnapshot(*existing), maintenanceAuditSnapshot(window))
+
+	if req.Notify {
+		u.notifyAffectedUsers(ctx, station, window)
+	}
+
+	resp := mapMaintenanceWindowToResponse(window)
+	return &resp, nil
+}
+
+func (u *maintenanceUsecase) RemoveMaintenanceWindow(ctx context.Context, req request.RemoveMaintenanceWindowRequest) error {
+	if err := authz.Check(ctx, constants.PermMaintenanceManage); err != nil {
+		return err
+	}
+
+	station, err := u.stationRepo.FindStationByID(ctx, req.StationID)
+	if err != nil {
+		return fmt.Errorf("station not found")
+	}
+
+	if err := u.stationRepo.RemoveMaintenanceWindow(ctx, req.StationID, req.WindowID); err != nil {
+		return err
+	}
+
+	for _, w := range mapMaintenanceWindowsDBToDomain(station.MaintenanceWindows) {
+		if w.ID == req.WindowID {
+			u.auditLog.Record(ctx, constants.AuditMaintenanceRemove, constants.AuditTargetStation, req.StationID, maintenanceAuditSnapshot(w), nil)
+			break
+		}
+	}
+	return nil
+}
+
+// maintenanceAuditSnapshot nests the window under its ID so diffs read "maintenance_windows.<id>.reason"
+func maintenanceAuditSnapshot(window domainModel.MaintenanceWindow) interface{} {
+	return map[string]interface{}{
+		"maintenance_windows": map[string]interface{}{
+			window.ID: mapMaintenanceWindowToResponse(window),
+		},
+	}
+}
+
+// notifyAffectedUsers tells holders of active bookings that overlap the window; failures are only logged
+func (u *maintenanceUsecase) notifyAffectedUsers(ctx context.Context, station *models.EVStationDB, window domainModel.MaintenanceWindow) []string {
+	now := time.Now()
+	notified := []string{}
+	seen := map[string]bool{}
+
+	for _, c := range station.Connectors {
+		if c.Booking == nil || !window.Covers(c.ConnectorID) || seen[c.Booking.Username] {
+			continue
+		}
+		bookingEnd, err := time.Parse(constants.DateTimeLayout, c.Booking.BookingEndTime)
+		if err != nil || !window.Overlaps(now, bookingEnd) {
+			continue
+		}
+
+		message := fmt.Sprintf("Connector %s at %s is under maintenance from %s to %s (%s). Your booking until %s may be affected.",
+			c.ConnectorID, station.Name,
+			window.StartTime.Format(constants.DateTimeLayout), window.EndTime.Format(constants.DateTimeLayout),
+			window.Reason, c.Booking.BookingEndTime)
+		if err := u.notifier.Notify(ctx, c.Booking.Username, "Planned maintenance", message); err != nil {
+			log.Printf("failed to notify %s about maintenance %s: %v", c.Booking.Username, window.ID, err)
+			continue
+		}
+
+		seen[c.Booking.Username] = true
+		notified = append(notified, c.Booking.Username)
+	}
+	return notified
+}
+
+func buildMaintenanceWindow(req request.MaintenanceWindowRequest, station *models.EVStationDB) (domainModel.MaintenanceWindow, error) {
+	start, err := time.Parse(constants.DateTimeLayout, req.StartTime)
+	if err != nil {
+		return domainModel.MaintenanceWindow{}, fmt.Errorf("invalid start_time format")
+	}
+	end, err := time.Parse(constants.DateTimeLayout, req.EndTime)
+	if err != nil {
+		return domainModel.MaintenanceWindow{}, fmt.Errorf("invalid end_time format")
+	}
+	if !end.After(start) {
+		return domainModel.MaintenanceWindow{}, fmt.Errorf("end_time must be after start_time")
+	}
+
+	window := domainModel.MaintenanceWindow{
+		Scope:     constants.MaintenanceScopeStation,
+		StartTime: start,
+		EndTime:   end,
+		Reason:    req.Reason,
+	}
+
+	if req.ConnectorID != "" {
+		found := false
+		for _, c := range station.Connectors {
+			if c.ConnectorID == req.ConnectorID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return domainModel.MaintenanceWindow{}, fmt.Errorf("connector %s not found in station", req.ConnectorID)
+		}
+		window.Scope = constants.MaintenanceScopeConnector
+		window.ConnectorID = req.ConnectorID
+	}
+	return window, nil
+}
+
+func isUnderMaintenance(windows []domainModel.MaintenanceWindow, connectorID string, now time.Time) bool {
+	for _, w := range windows {
+		if w.Covers(connectorID) && !now.Before(w.StartTime) && now.Before(w.EndTime) {
+			return true
+		}
+	}
+	return false
+}
+
+func mapMaintenanceWindowsDBToDomain(windows []models.MaintenanceWindowDB) []domainModel.MaintenanceWindow {
+	result := make([]domainModel.MaintenanceWindow, 0, len(windows))
+	for _, w := range windows {
+		result = append(result, domainModel.MaintenanceWindow{
+			ID:          w.ID,
+			Scope:       w.Scope,
+			ConnectorID: w.ConnectorID,
+			StartTime:   w.StartTime,
+			EndTime:     w.EndTime,
+			Reason:      w.Reason,
+			CreatedBy:   w.CreatedBy,
+		})
+	}
+	return result
+}
+
+func mapMaintenanceWindowToResponse(w domainModel.MaintenanceWindow) response.MaintenanceWindowResponse {
+	return response.MaintenanceWindowResponse{
+		ID:          w.ID,
+		Scope:       w.Scope,
+		ConnectorID: w.ConnectorID,
+		StartTime:   w.StartTime.Format(constants.DateTimeLayout),
+		EndTime:     w.EndTime.Format(constants.DateTimeLayout),
+		Reason:      w.Reason,
+		CreatedBy:   w.CreatedBy,
+	}
+}
@@ -0,0 +1,167 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	repoModels "Ev-Charge-Hub/Server/internal/repository/models"
+	"Ev-Charge-Hub/Server/internal/usecase"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMaintenanceWindow_NotifiesAffectedUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	mockNotifier := mocks.NewMockNotifier(ctrl)
+	uc := usecase.NewMaintenanceUsecase(mockRepo, mockNotifier, nil)
+
+	now := time.Now()
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), "station123").
+		Return(&repoModels.EVStationDB{
+			Name: "Central",
+			Connectors: []repoModels.ConnectorDB{
+				{ConnectorID: "C1", Booking: &repoModels.BookingDB{Username: "alice", BookingEndTime: now.Add(3 * time.Hour).Format(constants.DateTimeLayout)}},
+				{ConnectorID: "C2", Booking: &repoModels.BookingDB{Username: "bob", BookingEndTime: now.Add(3 * time.Hour).Format(constants.DateTimeLayout)}},
+			},
+		}, nil)
+
+	mockRepo.EXPECT().
+		AddMaintenanceWindow(gomock.Any(), "station123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, w models.MaintenanceWindow) error {
+			assert.Equal(t, constants.MaintenanceScopeConnector, w.Scope)
+			assert.Equal(t, "admin", w.CreatedBy)
+			assert.NotEmpty(t, w.ID)
+			return nil
+		})
+
+	mockNotifier.EXPECT().
+		Notify(gomock.Any(), "alice", gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	resp, err := uc.CreateMaintenanceWindow(adminContext(), request.MaintenanceWindowRequest{
+		StationID:   "station123",
+		ConnectorID: "C1",
+		StartTime:   now.Add(time.Hour).Format(constants.DateTimeLayout),
+		EndTime:     now.Add(2 * time.Hour).Format(constants.DateTimeLayout),
+		Reason:      "Cable replacement",
+		CreatedBy:   "admin",
+		Notify:      true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, resp.NotifiedUsers)
+	assert.Equal(t, "C1", resp.Window.ConnectorID)
+}
+
+func TestCreateMaintenanceWindow_NotifyFailureIsNotFatal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	mockNotifier := mocks.NewMockNotifier(ctrl)
+	uc := usecase.NewMaintenanceUsecase(mockRepo, mockNotifier, nil)
+
+	now := time.Now()
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), "station123").
+		Return(&repoModels.EVStationDB{
+			Connectors: []repoModels.ConnectorDB{
+				{ConnectorID: "C1", Booking: &repoModels.BookingDB{Username: "alice", BookingEndTime: now.Add(3 * time.Hour).Format(constants.DateTimeLayout)}},
+			},
+		}, nil)
+	mockRepo.EXPECT().AddMaintenanceWindow(gomock.Any(), "station123", gomock.Any()).Return(nil)
+	mockNotifier.EXPECT().Notify(gomock.Any(), "alice", gomock.Any(), gomock.Any()).Return(errors.New("smtp down"))
+
+	resp, err := uc.CreateMaintenanceWindow(adminContext(), request.MaintenanceWindowRequest{
+		StationID: "station123",
+		StartTime: now.Add(time.Hour).Format(constants.DateTimeLayout),
+		EndTime:   now.Add(2 * time.Hour).Format(constants.DateTimeLayout),
+		Reason:    "Site inspection",
+		Notify:    true,
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, resp.NotifiedUsers)
+	assert.Equal(t, constants.MaintenanceScopeStation, resp.Window.Scope)
+}
+
+func TestCreateMaintenanceWindow_EndBeforeStart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewMaintenanceUsecase(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), "station123").
+		Return(&repoModels.EVStationDB{}, nil)
+
+	_, err := uc.CreateMaintenanceWindow(adminContext(), request.MaintenanceWindowRequest{
+		StationID: "station123",
+		StartTime: "2030-01-01T12:00:00",
+		EndTime:   "2030-01-01T10:00:00",
+		Reason:    "Backwards",
+	})
+
+	assert.EqualError(t, err, "end_time must be after start_time")
+}
+
+func TestCreateMaintenanceWindow_UnknownConnector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewMaintenanceUsecase(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), "station123").
+		Return(&repoModels.EVStationDB{Connectors: []repoModels.ConnectorDB{{ConnectorID: "C1"}}}, nil)
+
+	_, err := uc.CreateMaintenanceWindow(adminContext(), request.MaintenanceWindowRequest{
+		StationID:   "station123",
+		ConnectorID: "C9",
+		StartTime:   "2030-01-01T10:00:00",
+		EndTime:     "2030-01-01T12:00:00",
+		Reason:      "Cable replacement",
+	})
+
+	assert.EqualError(t, err, "connector C9 not found in station")
+}
+
+func TestEditMaintenanceWindow_KeepsIDAndCreator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewMaintenanceUsecase(mockRepo, nil, nil)
+
+	mockRepo.EXPECT().
+		FindStationByID(gomock.Any(), "station123").
+		Return(&repoModels.EVStationDB{
+			MaintenanceWindows: []repoModels.MaintenanceWindowDB{{ID: "w1", Scope: constants.MaintenanceScopeStation, CreatedBy: "admin"}},
+		}, nil)
+	mockRepo.EXPECT().
+		EditMaintenanceWindow(gomock.Any(), "station123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, w models.MaintenanceWindow) error {
+			assert.Equal(t, "w1", w.ID)
+			assert.Equal(t, "admin", w.CreatedBy)
+			return nil
+		})
+
+	resp, err := uc.EditMaintenanceWindow(adminContext(), request.MaintenanceWindowRequest{
+		StationID: "station123",
+		WindowID:  "w1",
+		StartTime: "2030-01-01T10:00:00",
+		EndTime:   "2030-01-01T14:00:00",
+		Reason:    "Extended",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2030-01-01T14:00:00", resp.EndTime)
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/constants"
+	domainModel "Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/repository/models"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// nominal voltages reported for connectors; stations only store their power output
+const (
+	ocpiVoltageAC1Phase = 230
+	ocpiVoltageAC3Phase = 400
+	ocpiVoltageDC       = 400
+)
+
+// ocpiConnectorID is the ID of the single connector of every EVSE: each station connector
+// is booked on its own, so it is published as its own EVSE
+const ocpiConnectorID = "1"
+
+// mapStationDBToOCPILocation publishes a station as an OCPI Location with one EVSE per connector.
+// A station in the trash is unpublished and its EVSEs are REMOVED.
+func mapStationDBToOCPILocation(station models.EVStationDB, config configs.OCPIConfig, now time.Time) response.OCPILocation {
+	schedule := mapStatusDBToDomain(station.Status).Schedule
+	windows := mapMaintenanceWindowsDBToDomain(station.MaintenanceWindows)
+	lastUpdated := ocpiDateTime(stationLastUpdated(station))
+
+	evses := make([]response.OCPIEVSE, 0, len(station.Connectors))
+	for _, c := range station.Connectors {
+		evse := mapConnectorDBToOCPIEVSE(c, windows, config, lastUpdated, now)
+		if station.DeletedAt != nil {
+			evse.Status = constants.OCPIEVSERemoved
+		}
+		evses = append(evses, evse)
+	}
+
+	// Stations carry no address yet: the required Address stays empty and every location
+	// shares the configured City, which strict partners reject
+	location := response.OCPILocation{
+		CountryCode: config.CountryCode,
+		PartyID:     config.PartyID,
+		ID:          station.ID.Hex(),
+		Publish:     station.DeletedAt == nil,
+		Name:        station.Name,
+		City:        config.City,
+		Country:     config.Country,
+		Coordinates: response.OCPIGeoLocation{
+			Latitude:  strconv.FormatFloat(station.Latitude, 'f', 6, 64),
+			Longitude: strconv.FormatFloat(station.Longitude, 'f', 6, 64),
+		},
+		EVSEs:        evses,
+		TimeZone:     schedule.Location().String(),
+		OpeningTimes: mapOpeningHoursToOCPI(schedule),
+		LastUpdated:  lastUpdated,
+	}
+	if station.Company != "" {
+		location.Operator = &response.OCPIBusinessDetails{Name: station.Company}
+	}
+	return location
+}
+
+func mapConnectorDBToOCPIEVSE(c models.ConnectorDB, windows []domainModel.MaintenanceWindow, config configs.OCPIConfig, lastUpdated string, now time.Time) response.OCPIEVSE {
+	status := constants.OCPIEVSEAvailable
+	if isUnderMaintenance(windows, c.ConnectorID, now) {
+		status = constants.OCPIEVSEInoperative
+	} else if c.Booking != nil {
+		if end, err := parseBookingEndTime(c.Booking.BookingEndTime); err == nil && now.Before(end) {
+			status = constants.OCPIEVSEReserved
+		}
+	}
+
+	standard, _ := c.PlugName.OCPIStandard()
+	powerType := ocpiPowerType(c)
+	voltage := ocpiVoltage(powerType)
+	phases := 1.0
+	if powerType == constants.OCPIPowerAC3Phase {
+		phases = math.Sqrt(3)
+	}
+
+	return response.OCPIEVSE{
+		UID:    c.ConnectorID,
+		EVSEID: ocpiEVSEID(config, c.ConnectorID),
+		Status: status,
+		Connectors: []response.OCPIConnector{{
+			ID:               ocpiConnectorID,
+			Standard:         standard,
+			Format:           c.PlugName.OCPIFormat(),
+			PowerType:        powerType,
+			MaxVoltage:       voltage,
+			MaxAmperage:      int(math.Round(float64(c.PowerOutput) * 1000 / (float64(voltage) * phases))),
+			MaxElectricPower: c.PowerOutput * 1000,
+			LastUpdated:      lastUpdated,
+		}},
+		LastUpdated: lastUpdated,
+	}
+}
+
+// ocpiPowerType treats AC connectors above what a 32 A single-phase supply delivers as three-phase
+func ocpiPowerType(c models.ConnectorDB) string {
+	switch {
+	case c.Type == constants.DC:
+		return constants.OCPIPowerDC
+	case c.PowerOutput > 7:
+		return constants.OCPIPowerAC3Phase
+	default:
+		return constants.OCPIPowerAC1Phase
+	}
+}
+
+func ocpiVoltage(powerType string) int {
+	switch powerType {
+	case constants.OCPIPowerDC:
+		return ocpiVoltageDC
+	case constants.OCPIPowerAC3Phase:
+		return ocpiVoltageAC3Phase
+	default:
+		return ocpiVoltageAC1Phase
+	}
+}
+
+// ocpiEVSEID builds an eMI3 EVSE ID such as "TH*EVH*ECT0010" from the alphanumeric part of the connector ID
+func ocpiEVSEID(config configs.OCPIConfig, connectorID string) string {
+	var id strings.Builder
+	for _, r := range strings.ToUpper(connectorID) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			id.WriteRune(r)
+		}
+	}
+	if id.Len() == 0 {
+		return ""
+	}
+	return config.CountryCode + "*" + config.PartyID + "*E" + id.String()
+}
+
+// mapOpeningHoursToOCPI converts the weekly schedule; intervals running past midnight are split
+// in two because OCPI periods must end after they begin on the same day
+func mapOpeningHoursToOCPI(schedule domainModel.OpeningHours) *response.OCPIHours {
+	hours := &response.OCPIHours{TwentyFourSeven: schedule.AlwaysOpen}
+
+	if !schedule.AlwaysOpen {
+		for _, day := range schedule.Weekly {
+			weekday := ocpiWeekday(day.Day)
+			if weekday == 0 {
+				continue
+			}
+			for _, iv := range day.Intervals {
+				if iv.Close > iv.Open {
+					hours.RegularHours = append(hours.RegularHours, response.OCPIRegularHours{Weekday: weekday, PeriodBegin: iv.Open, PeriodEnd: iv.Close})
+					continue
+				}
+				hours.RegularHours = append(hours.RegularHours, response.OCPIRegularHours{Weekday: weekday, PeriodBegin: iv.Open, PeriodEnd: "23:59"})
+				if iv.Close != "00:00" {
+					hours.RegularHours = append(hours.RegularHours, response.OCPIRegularHours{Weekday: weekday%7 + 1, PeriodBegin: "00:00", PeriodEnd: iv.Close})
+				}
+			}
+		}
+	}
+
+	loc := schedule.Location()
+	for _, exception := range schedule.Exceptions {
+		date, err := time.ParseInLocation("2006-01-02", exception.Date, loc)
+		if err != nil {
+			continue
+		}
+		if exception.Closed {
+			hours.ExceptionalClosings = append(hours.ExceptionalClosings, response.OCPIExceptionalPeriod{
+				PeriodBegin: ocpiDateTime(date),
+				PeriodEnd:   ocpiDateTime(date.AddDate(0, 0, 1)),
+			})
+			continue
+		}
+		for _, iv := range exception.Intervals {
+			begin, errBegin := atClock(date, iv.Open)
+			end, errEnd := atClock(date, iv.Close)
+			if errBegin != nil || errEnd != nil {
+				continue
+			}
+			if !end.After(begin) {
+				end = end.AddDate(0, 0, 1)
+			}
+			hours.ExceptionalOpenings = append(hours.ExceptionalOpenings, response.OCPIExceptionalPeriod{
+				PeriodBegin: ocpiDateTime(begin),
+				PeriodEnd:   ocpiDateTime(end),
+			})
+		}
+	}
+	return hours
+}
+
+// ocpiWeekday numbers days from 1 (Monday) to 7 (Sunday); 0 means an unknown day code
+func ocpiWeekday(day string) int {
+	for i, code := range domainModel.WeekdayCodes {
+		if code == day {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// atClock returns the given "HH:MM" on the date's day, in the date's location
+func atClock(date time.Time, clock string) (time.Time, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), parsed.Hour(), parsed.Minute(), 0, 0, date.Location()), nil
+}
+
+// stationLastUpdated falls back to the creation time for stations written before updated_at existed
+func stationLastUpdated(station models.EVStationDB) time.Time {
+	if station.UpdatedAt != nil {
+		return *station.UpdatedAt
+	}
+	return station.ID.Timestamp()
+}
+
+// ocpiDateTime formats a time as an OCPI DateTime (UTC, second precision)
+func ocpiDateTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
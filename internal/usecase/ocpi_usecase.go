@@ -0,0 +1,403 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/authz"
+	"Ev-Charge-Hub/Server/internal/constants"
+	domainModel "Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/ocpi"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/utils"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//go:generate mockgen -source=ocpi_usecase.go -destination=../mocks/mock_ocpi_usecase.go -package=mocks
+type OCPIUsecase interface {
+	AuthenticateToken(ctx context.Context, token string) (*domainModel.OCPIPartner, error)
+	GetVersions(ctx context.Context) []response.OCPIVersion
+	GetVersionDetails(ctx context.Context) response.OCPIVersionDetails
+	GetCredentials(ctx context.Context, token string) response.OCPICredentials
+	RegisterCredentials(ctx context.Context, partnerID string, req request.OCPICredentialsRequest) (*response.OCPICredentials, error)
+	UpdateCredentials(ctx context.Context, partnerID string, req request.OCPICredentialsRequest) (*response.OCPICredentials, error)
+	DeleteCredentials(ctx context.Context, partnerID string) error
+	GetLocations(ctx context.Context, req request.OCPILocationsRequest) (*response.OCPILocationPage, error)
+	GetLocation(ctx context.Context, req request.OCPILocationRequest) (*response.OCPILocation, error)
+	GetEVSE(ctx context.Context, req request.OCPILocationRequest) (*response.OCPIEVSE, error)
+	GetConnector(ctx context.Context, req request.OCPILocationRequest) (*response.OCPIConnector, error)
+	CreatePartner(ctx context.Context, req request.CreateOCPIPartnerRequest) (*response.OCPIPartnerCreatedResponse, error)
+	GetPartners(ctx context.Context) ([]response.OCPIPartnerResponse, error)
+}
+
+var (
+	// ErrOCPIUnknownToken is returned for tokens that belong to no partner
+	ErrOCPIUnknownToken = errors.New("unknown OCPI token")
+	// ErrOCPIAlreadyRegistered is returned when a registered partner posts its credentials again
+	ErrOCPIAlreadyRegistered = errors.New("partner is already registered, use PUT to update the credentials")
+	// ErrOCPINotRegistered is returned when credentials are updated or deleted before registration
+	ErrOCPINotRegistered = errors.New("partner is not registered")
+	// ErrOCPIUnsupportedVersion is returned when the partner does not offer OCPI 2.2.1
+	ErrOCPIUnsupportedVersion = errors.New("no mutual OCPI version")
+	// ErrOCPIPartnerUnreachable is returned when the partner's versions endpoints cannot be used
+	ErrOCPIPartnerUnreachable = errors.New("unable to use the partner's API")
+	// ErrOCPIUnknownLocation is returned for unknown locations, EVSEs and connectors
+	ErrOCPIUnknownLocation = errors.New("unknown location")
+	// ErrOCPIInvalidParams is returned for malformed query parameters
+	ErrOCPIInvalidParams = errors.New("invalid or missing parameters")
+)
+
+// page size of GET /locations when the partner asks for none or for more than the maximum
+const (
+	defaultOCPILimit = 50
+	maxOCPILimit     = 100
+)
+
+type ocpiUsecase struct {
+	partnerRepo repository.OCPIPartnerRepository
+	stationRepo repository.EVStationRepository
+	client      ocpi.Client
+	config      configs.OCPIConfig
+}
+
+func NewOCPIUsecase(partnerRepo repository.OCPIPartnerRepository, stationRepo repository.EVStationRepository, client ocpi.Client, config configs.OCPIConfig) OCPIUsecase {
+	return &ocpiUsecase{partnerRepo: partnerRepo, stationRepo: stationRepo, client: client, config: config}
+}
+
+// AuthenticateToken finds the partner holding a token we issued: token A before registration, token C after
+func (u *ocpiUsecase) AuthenticateToken(ctx context.Context, token string) (*domainModel.OCPIPartner, error) {
+	partner, err := u.partnerRepo.FindPartnerByTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if partner == nil {
+		return nil, ErrOCPIUnknownToken
+	}
+	return partner, nil
+}
+
+func (u *ocpiUsecase) GetVersions(ctx context.Context) []response.OCPIVersion {
+	return []response.OCPIVersion{{Version: constants.OCPIVersion, URL: u.versionURL()}}
+}
+
+func (u *ocpiUsecase) GetVersionDetails(ctx context.Context) response.OCPIVersionDetails {
+	return response.OCPIVersionDetails{
+		Version: constants.OCPIVersion,
+		Endpoints: []response.OCPIEndpoint{
+			{Identifier: constants.OCPIModuleCredentials, Role: constants.OCPIInterfaceSender, URL: u.versionURL() + "/" + constants.OCPIModuleCredentials},
+			{Identifier: constants.OCPIModuleLocations, Role: constants.OCPIInterfaceSender, URL: u.versionURL() + "/" + constants.OCPIModuleLocations},
+		},
+	}
+}
+
+// GetCredentials describes this platform to the partner along with the token it called us with
+func (u *ocpiUsecase) GetCredentials(ctx context.Context, token string) response.OCPICredentials {
+	return response.OCPICredentials{
+		Token: token,
+		URL:   u.config.BaseURL + "/ocpi/versions",
+		Roles: []response.OCPICredentialsRole{{
+			Role:            constants.OCPIRoleCPO,
+			BusinessDetails: response.OCPIBusinessDetails{Name: u.config.BusinessName},
+			PartyID:         u.config.PartyID,
+			CountryCode:     u.config.CountryCode,
+		}},
+	}
+}
+
+// RegisterCredentials completes the handshake started with token A: it reads the partner's
+// versions with token B, then replaces token A with a fresh token C that is returned once
+func (u *ocpiUsecase) RegisterCredentials(ctx context.Context, partnerID string, req request.OCPICredentialsRequest) (*response.OCPICredentials, error) {
+	partner, err := u.findPartner(ctx, partnerID)
+	if err != nil {
+		return nil, err
+	}
+	if partner.Status == constants.OCPIPartnerRegistered {
+		return nil, ErrOCPIAlreadyRegistered
+	}
+	return u.issueCredentials(ctx, partner, req)
+}
+
+// UpdateCredentials repeats the handshake for a registered partner, e.g. after it changed
+// version or rotated token B, and rotates token C
+func (u *ocpiUsecase) UpdateCredentials(ctx context.Context, partnerID string, req request.OCPICredentialsRequest) (*response.OCPICredentials, error) {
+	partner, err := u.findPartner(ctx, partnerID)
+	if err != nil {
+		return nil, err
+	}
+	if partner.Status != constants.OCPIPartnerRegistered {
+		return nil, ErrOCPINotRegistered
+	}
+	return u.issueCredentials(ctx, partner, req)
+}
+
+// DeleteCredentials unregisters the partner; all of its tokens stop working
+func (u *ocpiUsecase) DeleteCredentials(ctx context.Context, partnerID string) error {
+	partner, err := u.findPartner(ctx, partnerID)
+	if err != nil {
+		return err
+	}
+	if partner.Status != constants.OCPIPartnerRegistered {
+		return ErrOCPINotRegistered
+	}
+
+	partner.Status = constants.OCPIPartnerUnregistered
+	partner.TokenAHash = ""
+	partner.TokenCHash = ""
+	partner.TokenB = ""
+	partner.UpdatedAt = time.Now().UTC()
+	return u.partnerRepo.UpdatePartner(ctx, partner)
+}
+
+func (u *ocpiUsecase) issueCredentials(ctx context.Context, partner *domainModel.OCPIPartner, req request.OCPICredentialsRequest) (*response.OCPICredentials, error) {
+	versions, err := u.client.GetVersions(ctx, req.URL, req.Token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOCPIPartnerUnreachable, err)
+	}
+	var versionURL string
+	for _, v := range versions {
+		if v.Version == constants.OCPIVersion {
+			versionURL = v.URL
+		}
+	}
+	if versionURL == "" {
+		return nil, ErrOCPIUnsupportedVersion
+	}
+
+	details, err := u.client.GetVersionDetails(ctx, versionURL, req.Token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOCPIPartnerUnreachable, err)
+	}
+
+	tokenC, err := utils.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	partner.Endpoints = make([]domainModel.OCPIEndpoint, 0, len(details.Endpoints))
+	for _, e := range details.Endpoints {
+		partner.Endpoints = append(partner.Endpoints, domainModel.OCPIEndpoint{Identifier: e.Identifier, Role: e.Role, URL: e.URL})
+	}
+	partner.Roles = make([]domainModel.OCPIRole, 0, len(req.Roles))
+	for _, r := range req.Roles {
+		partner.Roles = append(partner.Roles, domainModel.OCPIRole{
+			Role:         r.Role,
+			CountryCode:  r.CountryCode,
+			PartyID:      r.PartyID,
+			BusinessName: r.BusinessDetails.Name,
+		})
+	}
+	partner.Status = constants.OCPIPartnerRegistered
+	partner.TokenAHash = ""
+	partner.TokenCHash = utils.HashToken(tokenC)
+	partner.TokenB = req.Token
+	partner.VersionsURL = req.URL
+	partner.Version = constants.OCPIVersion
+	partner.UpdatedAt = time.Now().UTC()
+
+	if err := u.partnerRepo.UpdatePartner(ctx, partner); err != nil {
+		return nil, err
+	}
+
+	credentials := u.GetCredentials(ctx, tokenC)
+	return &credentials, nil
+}
+
+func (u *ocpiUsecase) findPartner(ctx context.Context, partnerID string) (*domainModel.OCPIPartner, error) {
+	partner, err := u.partnerRepo.FindPartnerByID(ctx, partnerID)
+	if err != nil {
+		return nil, err
+	}
+	if partner == nil {
+		return nil, ErrOCPIUnknownToken
+	}
+	return partner, nil
+}
+
+// GetLocations returns one page of locations ordered by ID. date_from and date_to filter on last_updated.
+func (u *ocpiUsecase) GetLocations(ctx context.Context, req request.OCPILocationsRequest) (*response.OCPILocationPage, error) {
+	from, err := parseOCPIDateTime(req.DateFrom)
+	if err != nil {
+		return nil, fmt.Errorf("%w: date_from: %v", ErrOCPIInvalidParams, err)
+	}
+	to, err := parseOCPIDateTime(req.DateTo)
+	if err != nil {
+		return nil, fmt.Errorf("%w: date_to: %v", ErrOCPIInvalidParams, err)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultOCPILimit
+	}
+	if limit > maxOCPILimit {
+		limit = maxOCPILimit
+	}
+
+	stations, total, err := u.stationRepo.FindStationsUpdatedBetween(ctx, from, to, req.Offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	page := &response.OCPILocationPage{
+		Locations: make([]response.OCPILocation, 0, len(stations)),
+		Total:     total,
+		Limit:     limit,
+	}
+	for _, station := range stations {
+		page.Locations = append(page.Locations, mapStationDBToOCPILocation(station, u.config, now))
+	}
+
+	if next := req.Offset + int64(len(stations)); len(stations) > 0 && next < total {
+		query := url.Values{}
+		query.Set("offset", strconv.FormatInt(next, 10))
+		query.Set("limit", strconv.FormatInt(limit, 10))
+		if req.DateFrom != "" {
+			query.Set("date_from", req.DateFrom)
+		}
+		if req.DateTo != "" {
+			query.Set("date_to", req.DateTo)
+		}
+		page.NextURL = u.versionURL() + "/" + constants.OCPIModuleLocations + "?" + query.Encode()
+	}
+	return page, nil
+}
+
+// GetLocation answers ErrOCPIUnknownLocation only for IDs that match no live station; other
+// repository errors are returned as they are
+func (u *ocpiUsecase) GetLocation(ctx context.Context, req request.OCPILocationRequest) (*response.OCPILocation, error) {
+	if _, err := primitive.ObjectIDFromHex(req.LocationID); err != nil {
+		return nil, ErrOCPIUnknownLocation
+	}
+	station, err := u.stationRepo.FindStationByID(ctx, req.LocationID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrOCPIUnknownLocation
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	location := mapStationDBToOCPILocation(*station, u.config, time.Now())
+	return &location, nil
+}
+
+func (u *ocpiUsecase) GetEVSE(ctx context.Context, req request.OCPILocationRequest) (*response.OCPIEVSE, error) {
+	location, err := u.GetLocation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, evse := range location.EVSEs {
+		if evse.UID == req.EVSEUID {
+			return &evse, nil
+		}
+	}
+	return nil, ErrOCPIUnknownLocation
+}
+
+func (u *ocpiUsecase) GetConnector(ctx context.Context, req request.OCPILocationRequest) (*response.OCPIConnector, error) {
+	evse, err := u.GetEVSE(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, connector := range evse.Connectors {
+		if connector.ID == req.ConnectorID {
+			return &connector, nil
+		}
+	}
+	return nil, ErrOCPIUnknownLocation
+}
+
+// CreatePartner registers a roaming partner and hands out its token A, which is shown only once
+func (u *ocpiUsecase) CreatePartner(ctx context.Context, req request.CreateOCPIPartnerRequest) (*response.OCPIPartnerCreatedResponse, error) {
+	if err := authz.Check(ctx, constants.PermOCPIPartnerManage); err != nil {
+		return nil, err
+	}
+
+	tokenA, err := utils.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	partner := &domainModel.OCPIPartner{
+		Name:       req.Name,
+		Status:     constants.OCPIPartnerPending,
+		TokenAHash: utils.HashToken(tokenA),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := u.partnerRepo.CreatePartner(ctx, partner); err != nil {
+		return nil, err
+	}
+
+	return &response.OCPIPartnerCreatedResponse{
+		OCPIPartnerResponse: mapOCPIPartnerToResponse(*partner),
+		Token:               tokenA,
+		OurVersionsURL:      u.config.BaseURL + "/ocpi/versions",
+	}, nil
+}
+
+func (u *ocpiUsecase) GetPartners(ctx context.Context) ([]response.OCPIPartnerResponse, error) {
+	if err := authz.Check(ctx, constants.PermOCPIPartnerManage); err != nil {
+		return nil, err
+	}
+
+	partners, err := u.partnerRepo.FindPartners(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.OCPIPartnerResponse, 0, len(partners))
+	for _, p := range partners {
+		result = append(result, mapOCPIPartnerToResponse(p))
+	}
+	return result, nil
+}
+
+func (u *ocpiUsecase) versionURL() string {
+	return u.config.BaseURL + "/ocpi/" + constants.OCPIVersion
+}
+
+func mapOCPIPartnerToResponse(partner domainModel.OCPIPartner) response.OCPIPartnerResponse {
+	roles := make([]response.OCPICredentialsRole, 0, len(partner.Roles))
+	for _, r := range partner.Roles {
+		roles = append(roles, response.OCPICredentialsRole{
+			Role:            r.Role,
+			BusinessDetails: response.OCPIBusinessDetails{Name: r.BusinessName},
+			PartyID:         r.PartyID,
+			CountryCode:     r.CountryCode,
+		})
+	}
+
+	return response.OCPIPartnerResponse{
+		ID:          partner.ID,
+		Name:        partner.Name,
+		Status:      partner.Status,
+		VersionsURL: partner.VersionsURL,
+		Version:     partner.Version,
+		Roles:       roles,
+		CreatedAt:   partner.CreatedAt.UTC().Format(constants.DateTimeLayout),
+		UpdatedAt:   partner.UpdatedAt.UTC().Format(constants.DateTimeLayout),
+	}
+}
+
+// parseOCPIDateTime reads an optional RFC3339 timestamp
+func parseOCPIDateTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,283 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/ocpi"
+	"Ev-Charge-Hub/Server/internal/ocpi/ocpitest"
+	repoModels "Ev-Charge-Hub/Server/internal/repository/models"
+	"Ev-Charge-Hub/Server/internal/usecase"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var testOCPIConfig = configs.OCPIConfig{
+	BaseURL:      "https://hub.example.com",
+	CountryCode:  "TH",
+	PartyID:      "EVH",
+	BusinessName: "EV Charge Hub",
+	Country:      "THA",
+	City:         "Bangkok",
+}
+
+func sha256Hex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestOCPIRegisterCredentials_HandshakeWithFakePartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockPartnerRepo := mocks.NewMockOCPIPartnerRepository(ctrl)
+	uc := usecase.NewOCPIUsecase(mockPartnerRepo, nil, ocpi.NewHTTPClient(nil), testOCPIConfig)
+
+	partner := ocpitest.NewPartner("token-b")
+	defer partner.Close()
+
+	mockPartnerRepo.EXPECT().FindPartnerByID(gomock.Any(), "p1").Return(&models.OCPIPartner{
+		ID:         "p1",
+		Status:     constants.OCPIPartnerPending,
+		TokenAHash: sha256Hex("token-a"),
+	}, nil)
+
+	var stored models.OCPIPartner
+	mockPartnerRepo.EXPECT().
+		UpdatePartner(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, p *models.OCPIPartner) error {
+			stored = *p
+			return nil
+		})
+
+	credentials, err := uc.RegisterCredentials(context.TODO(), "p1", partner.Credentials())
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, credentials.Token)
+	assert.NotEqual(t, "token-a", credentials.Token)
+	assert.Equal(t, "https://hub.example.com/ocpi/versions", credentials.URL)
+	assert.Equal(t, constants.OCPIRoleCPO, credentials.Roles[0].Role)
+	assert.Equal(t, []string{"/ocpi/versions", "/ocpi/2.2.1"}, partner.Requests())
+
+	// token A is spent, token C is only stored hashed, token B is kept to call the partner
+	assert.Equal(t, constants.OCPIPartnerRegistered, stored.Status)
+	assert.Empty(t, stored.TokenAHash)
+	assert.Equal(t, sha256Hex(credentials.Token), stored.TokenCHash)
+	assert.Equal(t, "token-b", stored.TokenB)
+	assert.Equal(t, constants.OCPIVersion, stored.Version)
+	assert.Len(t, stored.Endpoints, 2)
+	assert.Equal(t, "FRM", stored.Roles[0].PartyID)
+}
+
+func TestOCPIRegisterCredentials_NoMutualVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockPartnerRepo := mocks.NewMockOCPIPartnerRepository(ctrl)
+	uc := usecase.NewOCPIUsecase(mockPartnerRepo, nil, ocpi.NewHTTPClient(nil), testOCPIConfig)
+
+	partner := ocpitest.NewPartner("token-b", "2.1.1")
+	defer partner.Close()
+
+	mockPartnerRepo.EXPECT().FindPartnerByID(gomock.Any(), "p1").Return(&models.OCPIPartner{ID: "p1", Status: constants.OCPIPartnerPending}, nil)
+
+	_, err := uc.RegisterCredentials(context.TODO(), "p1", partner.Credentials())
+	assert.ErrorIs(t, err, usecase.ErrOCPIUnsupportedVersion)
+}
+
+func TestOCPIRegisterCredentials_PartnerRejectsTokenB(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockPartnerRepo := mocks.NewMockOCPIPartnerRepository(ctrl)
+	uc := usecase.NewOCPIUsecase(mockPartnerRepo, nil, ocpi.NewHTTPClient(nil), testOCPIConfig)
+
+	partner := ocpitest.NewPartner("token-b")
+	defer partner.Close()
+
+	mockPartnerRepo.EXPECT().FindPartnerByID(gomock.Any(), "p1").Return(&models.OCPIPartner{ID: "p1", Status: constants.OCPIPartnerPending}, nil)
+
+	credentials := partner.Credentials()
+	credentials.Token = "wrong"
+	_, err := uc.RegisterCredentials(context.TODO(), "p1", credentials)
+	assert.ErrorIs(t, err, usecase.ErrOCPIPartnerUnreachable)
+}
+
+func TestOCPIRegisterCredentials_AlreadyRegistered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockPartnerRepo := mocks.NewMockOCPIPartnerRepository(ctrl)
+	mockClient := mocks.NewMockClient(ctrl)
+	uc := usecase.NewOCPIUsecase(mockPartnerRepo, nil, mockClient, testOCPIConfig)
+
+	mockPartnerRepo.EXPECT().FindPartnerByID(gomock.Any(), "p1").Return(&models.OCPIPartner{ID: "p1", Status: constants.OCPIPartnerRegistered}, nil)
+
+	_, err := uc.RegisterCredentials(context.TODO(), "p1", request.OCPICredentialsRequest{Token: "b", URL: "https://partner.example.com/ocpi/versions"})
+	assert.ErrorIs(t, err, usecase.ErrOCPIAlreadyRegistered)
+}
+
+func TestOCPIAuthenticateToken_LooksUpHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockPartnerRepo := mocks.NewMockOCPIPartnerRepository(ctrl)
+	uc := usecase.NewOCPIUsecase(mockPartnerRepo, nil, nil, testOCPIConfig)
+
+	mockPartnerRepo.EXPECT().FindPartnerByTokenHash(gomock.Any(), sha256Hex("token-c")).Return(&models.OCPIPartner{ID: "p1"}, nil)
+	mockPartnerRepo.EXPECT().FindPartnerByTokenHash(gomock.Any(), sha256Hex("unknown")).Return(nil, nil)
+
+	partner, err := uc.AuthenticateToken(context.TODO(), "token-c")
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", partner.ID)
+
+	_, err = uc.AuthenticateToken(context.TODO(), "unknown")
+	assert.ErrorIs(t, err, usecase.ErrOCPIUnknownToken)
+}
+
+func ocpiTestStation() repoModels.EVStationDB {
+	updatedAt := time.Date(2025, 4, 1, 3, 0, 0, 0, time.UTC)
+	return repoModels.EVStationDB{
+		ID:        primitive.NewObjectID(),
+		Name:      "Central",
+		Latitude:  13.7563,
+		Longitude: 100.5018,
+		Company:   "EV Co",
+		UpdatedAt: &updatedAt,
+		Status: repoModels.StationStatusDB{Schedule: &repoModels.OpeningHoursDB{
+			TimeZone: "Asia/Bangkok",
+			Weekly: []repoModels.DayScheduleDB{
+				{Day: "MON", Intervals: []repoModels.TimeIntervalDB{{Open: "08:00", Close: "20:00"}}},
+				{Day: "SUN", Intervals: []repoModels.TimeIntervalDB{{Open: "22:00", Close: "02:00"}}},
+			},
+			Exceptions: []repoModels.ScheduleExceptionDB{{Date: "2025-04-13", Closed: true}},
+		}},
+		Connectors: []repoModels.ConnectorDB{
+			{ConnectorID: "CT-01", Type: constants.AC, PlugName: constants.Type2, PricePerUnit: 5, PowerOutput: 22},
+			{ConnectorID: "CT-02", Type: constants.DC, PlugName: constants.CCSType2, PricePerUnit: 9, PowerOutput: 120,
+				Booking: &repoModels.BookingDB{Username: "alice", BookingEndTime: time.Now().Add(time.Hour).Format(constants.DateTimeLayout)}},
+		},
+	}
+}
+
+func TestOCPIGetLocations_MapsStationsAndPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStationRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewOCPIUsecase(nil, mockStationRepo, nil, testOCPIConfig)
+
+	station := ocpiTestStation()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockStationRepo.EXPECT().
+		FindStationsUpdatedBetween(gomock.Any(), &from, nil, int64(0), int64(1)).
+		Return([]repoModels.EVStationDB{station}, int64(3), nil)
+
+	page, err := uc.GetLocations(context.TODO(), request.OCPILocationsRequest{DateFrom: "2025-03-01T00:00:00Z", Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), page.Total)
+	assert.Equal(t, "https://hub.example.com/ocpi/2.2.1/locations?date_from=2025-03-01T00%3A00%3A00Z&limit=1&offset=1", page.NextURL)
+
+	location := page.Locations[0]
+	assert.Equal(t, station.ID.Hex(), location.ID)
+	assert.Equal(t, "TH", location.CountryCode)
+	assert.Equal(t, "EVH", location.PartyID)
+	assert.Equal(t, "13.756300", location.Coordinates.Latitude)
+	assert.Equal(t, "Asia/Bangkok", location.TimeZone)
+	assert.Equal(t, "2025-04-01T03:00:00Z", location.LastUpdated)
+
+	// Sunday 22:00-02:00 is split at midnight into Sunday and Monday
+	assert.Len(t, location.OpeningTimes.RegularHours, 3)
+	assert.Equal(t, 7, location.OpeningTimes.RegularHours[1].Weekday)
+	assert.Equal(t, 1, location.OpeningTimes.RegularHours[2].Weekday)
+	assert.Equal(t, "02:00", location.OpeningTimes.RegularHours[2].PeriodEnd)
+	assert.Equal(t, "2025-04-12T17:00:00Z", location.OpeningTimes.ExceptionalClosings[0].PeriodBegin)
+
+	assert.Len(t, location.EVSEs, 2)
+	ac := location.EVSEs[0]
+	assert.Equal(t, "CT-01", ac.UID)
+	assert.Equal(t, "TH*EVH*ECT01", ac.EVSEID)
+	assert.Equal(t, constants.OCPIEVSEAvailable, ac.Status)
+	assert.Equal(t, "IEC_62196_T2", ac.Connectors[0].Standard)
+	assert.Equal(t, constants.OCPIFormatSocket, ac.Connectors[0].Format)
+	assert.Equal(t, constants.OCPIPowerAC3Phase, ac.Connectors[0].PowerType)
+	assert.Equal(t, 22000, ac.Connectors[0].MaxElectricPower)
+	assert.Equal(t, 32, ac.Connectors[0].MaxAmperage)
+
+	dc := location.EVSEs[1]
+	assert.Equal(t, constants.OCPIEVSEReserved, dc.Status)
+	assert.Equal(t, "IEC_62196_T2_COMBO", dc.Connectors[0].Standard)
+	assert.Equal(t, constants.OCPIPowerDC, dc.Connectors[0].PowerType)
+}
+
+func TestOCPIGetLocations_RemovedStationIsUnpublished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStationRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewOCPIUsecase(nil, mockStationRepo, nil, testOCPIConfig)
+
+	station := ocpiTestStation()
+	deletedAt := time.Date(2025, 4, 2, 8, 0, 0, 0, time.UTC)
+	station.DeletedAt = &deletedAt
+	mockStationRepo.EXPECT().
+		FindStationsUpdatedBetween(gomock.Any(), gomock.Any(), nil, int64(0), gomock.Any()).
+		Return([]repoModels.EVStationDB{station}, int64(1), nil)
+
+	page, err := uc.GetLocations(context.TODO(), request.OCPILocationsRequest{DateFrom: "2025-04-01T00:00:00Z"})
+	assert.NoError(t, err)
+	location := page.Locations[0]
+	assert.False(t, location.Publish)
+	for _, evse := range location.EVSEs {
+		assert.Equal(t, constants.OCPIEVSERemoved, evse.Status)
+	}
+}
+
+func TestOCPIGetLocations_InvalidDate(t *testing.T) {
+	uc := usecase.NewOCPIUsecase(nil, nil, nil, testOCPIConfig)
+
+	_, err := uc.GetLocations(context.TODO(), request.OCPILocationsRequest{DateFrom: "yesterday"})
+	assert.ErrorIs(t, err, usecase.ErrOCPIInvalidParams)
+}
+
+func TestOCPIGetLocation_RepositoryFailureIsNotUnknown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStationRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewOCPIUsecase(nil, mockStationRepo, nil, testOCPIConfig)
+
+	id := primitive.NewObjectID().Hex()
+	mockStationRepo.EXPECT().FindStationByID(gomock.Any(), id).Return(nil, mongo.ErrNoDocuments)
+	_, err := uc.GetLocation(context.TODO(), request.OCPILocationRequest{LocationID: id})
+	assert.ErrorIs(t, err, usecase.ErrOCPIUnknownLocation)
+
+	_, err = uc.GetLocation(context.TODO(), request.OCPILocationRequest{LocationID: "not-an-id"})
+	assert.ErrorIs(t, err, usecase.ErrOCPIUnknownLocation)
+
+	mockStationRepo.EXPECT().FindStationByID(gomock.Any(), id).Return(nil, errors.New("server selection timeout"))
+	_, err = uc.GetLocation(context.TODO(), request.OCPILocationRequest{LocationID: id})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, usecase.ErrOCPIUnknownLocation)
+}
+
+func TestOCPIGetConnector_UnknownEVSE(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStationRepo := mocks.NewMockEVStationRepository(ctrl)
+	uc := usecase.NewOCPIUsecase(nil, mockStationRepo, nil, testOCPIConfig)
+
+	station := ocpiTestStation()
+	mockStationRepo.EXPECT().FindStationByID(gomock.Any(), station.ID.Hex()).Return(&station, nil).Times(2)
+
+	connector, err := uc.GetConnector(context.TODO(), request.OCPILocationRequest{LocationID: station.ID.Hex(), EVSEUID: "CT-02", ConnectorID: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "IEC_62196_T2_COMBO", connector.Standard)
+
+	_, err = uc.GetConnector(context.TODO(), request.OCPILocationRequest{LocationID: station.ID.Hex(), EVSEUID: "CT-99", ConnectorID: "1"})
+	assert.ErrorIs(t, err, usecase.ErrOCPIUnknownLocation)
+}
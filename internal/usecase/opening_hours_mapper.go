@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	domainModel "Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/repository/models"
+	"time"
+)
+
+// mapStatusRequestToDomain builds a validated schedule, accepting the legacy open/close pair as a daily schedule
+func mapStatusRequestToDomain(req request.StationStatusRequest) (domainModel.StationStatus, error) {
+	timeZone := req.TimeZone
+	if timeZone == "" {
+		timeZone = constants.DefaultTimeZone
+	}
+
+	var schedule domainModel.OpeningHours
+	if !req.AlwaysOpen && len(req.Weekly) == 0 && (req.OpenHours != "" || req.CloseHours != "") {
+		legacy, err := domainModel.LegacyOpeningHours(req.OpenHours, req.CloseHours, timeZone)
+		if err != nil {
+			return domainModel.StationStatus{}, err
+		}
+		schedule = legacy
+	} else {
+		schedule = domainModel.OpeningHours{
+			TimeZone:   timeZone,
+			AlwaysOpen: req.AlwaysOpen,
+		}
+		for _, day := range req.Weekly {
+			schedule.Weekly = append(schedule.Weekly, domainModel.DaySchedule{
+				Day:       day.Day,
+				Intervals: mapIntervalsReqToDomain(day.Intervals),
+			})
+		}
+	}
+
+	for _, ex := range req.Exceptions {
+		schedule.Exceptions = append(schedule.Exceptions, domainModel.ScheduleException{
+			Date:      ex.Date,
+			Closed:    ex.Closed,
+			Intervals: mapIntervalsReqToDomain(ex.Intervals),
+			Reason:    ex.Reason,
+		})
+	}
+
+	if err := schedule.Validate(); err != nil {
+		return domainModel.StationStatus{}, err
+	}
+	return domainModel.StationStatus{Schedule: schedule}, nil
+}
+
+// mapStatusDomainToRequest is the inverse of mapStatusRequestToDomain, used to build patchable documents
+func mapStatusDomainToRequest(status domainModel.StationStatus) request.StationStatusRequest {
+	schedule := status.Schedule
+	req := request.StationStatusRequest{
+		TimeZone:   schedule.TimeZone,
+		AlwaysOpen: schedule.AlwaysOpen,
+	}
+	for _, day := range schedule.Weekly {
+		req.Weekly = append(req.Weekly, request.DayScheduleRequest{
+			Day:       day.Day,
+			Intervals: mapIntervalsDomainToReq(day.Intervals),
+		})
+	}
+	for _, ex := range schedule.Exceptions {
+		req.Exceptions = append(req.Exceptions, request.ScheduleExceptionRequest{
+			Date:      ex.Date,
+			Closed:    ex.Closed,
+			Intervals: mapIntervalsDomainToReq(ex.Intervals),
+			Reason:    ex.Reason,
+		})
+	}
+	return req
+}
+
+func mapIntervalsDomainToReq(intervals []domainModel.TimeInterval) []request.TimeIntervalRequest {
+	result := make([]request.TimeIntervalRequest, 0, len(intervals))
+	for _, iv := range intervals {
+		result = append(result, request.TimeIntervalRequest{Open: iv.Open, Close: iv.Close})
+	}
+	return result
+}
+
+func mapIntervalsReqToDomain(intervals []request.TimeIntervalRequest) []domainModel.TimeInterval {
+	result := make([]domainModel.TimeInterval, 0, len(intervals))
+	for _, iv := range intervals {
+		result = append(result, domainModel.TimeInterval{Open: iv.Open, Close: iv.Close})
+	}
+	return result
+}
+
+// mapStatusDBToDomain reads the stored schedule, converting legacy free-text hours on the fly
+func mapStatusDBToDomain(db models.StationStatusDB) domainModel.StationStatus {
+	if db.Schedule == nil {
+		legacy, err := domainModel.LegacyOpeningHours(db.OpenHours, db.CloseHours, constants.DefaultTimeZone)
+		if err != nil {
+			// unparseable legacy hours: treat as closed until an admin fixes them
+			return domainModel.StationStatus{Schedule: domainModel.OpeningHours{TimeZone: constants.DefaultTimeZone}}
+		}
+		return domainModel.StationStatus{Schedule: legacy}
+	}
+
+	schedule := domainModel.OpeningHours{
+		TimeZone:   db.Schedule.TimeZone,
+		AlwaysOpen: db.Schedule.AlwaysOpen,
+	}
+	for _, day := range db.Schedule.Weekly {
+		schedule.Weekly = append(schedule.Weekly, domainModel.DaySchedule{
+			Day:       day.Day,
+			Intervals: mapIntervalsDBToDomain(day.Intervals),
+		})
+	}
+	for _, ex := range db.Schedule.Exceptions {
+		schedule.Exceptions = append(schedule.Exceptions, domainModel.ScheduleException{
+			Date:      ex.Date,
+			Closed:    ex.Closed,
+			Intervals: mapIntervalsDBToDomain(ex.Intervals),
+			Reason:    ex.Reason,
+		})
+	}
+	return domainModel.StationStatus{Schedule: schedule}
+}
+
+func mapIntervalsDBToDomain(intervals []models.TimeIntervalDB) []domainModel.TimeInterval {
+	result := make([]domainModel.TimeInterval, 0, len(intervals))
+	for _, iv := range intervals {
+		result = append(result, domainModel.TimeInterval{Open: iv.Open, Close: iv.Close})
+	}
+	return result
+}
+
+// mapStatusToResponse exposes the schedule and computes is_open for the given instant
+func mapStatusToResponse(status domainModel.StationStatus, now time.Time) response.StationStatusResponse {
+	schedule := status.Schedule
+	resp := response.StationStatusResponse{
+		TimeZone:   schedule.TimeZone,
+		AlwaysOpen: schedule.AlwaysOpen,
+		Weekly:     []response.DayScheduleResponse{},
+		IsOpen:     schedule.IsOpenAt(now),
+	}
+	for _, day := range schedule.Weekly {
+		resp.Weekly = append(resp.Weekly, response.DayScheduleResponse{
+			Day:       day.Day,
+			Intervals: mapIntervalsToResponse(day.Intervals),
+		})
+	}
+	for _, ex := range schedule.Exceptions {
+		resp.Exceptions = append(resp.Exceptions, response.ScheduleExceptionResponse{
+			Date:      ex.Date,
+			Closed:    ex.Closed,
+			Intervals: mapIntervalsToResponse(ex.Intervals),
+			Reason:    ex.Reason,
+		})
+	}
+	return resp
+}
+
+func mapIntervalsToResponse(intervals []domainModel.TimeInterval) []response.TimeIntervalResponse {
+	result := make([]response.TimeIntervalResponse, 0, len(intervals))
+	for _, iv := range intervals {
+		result = append(result, response.TimeIntervalResponse{Open: iv.Open, Close: iv.Close})
+	}
+	return result
+}
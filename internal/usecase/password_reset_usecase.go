@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/mail"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/utils"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+)
+
+//go:generate mockgen -source=password_reset_usecase.go -destination=../mocks/mock_password_reset_usecase.go -package=mocks
+type PasswordResetUsecase interface {
+	RequestPasswordReset(ctx context.Context, req request.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req request.ResetPasswordRequest) error
+}
+
+// ErrInvalidResetToken is returned for unknown, expired or already used reset tokens
+var ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+
+type passwordResetUsecase struct {
+	resetRepo repository.PasswordResetRepository
+	userRepo  repository.UserRepositoryInterface
+	sessions  SessionUsecase
+	mailer    mail.Mailer
+	auditLog  AuditRecorder
+	config    configs.PasswordResetConfig
+}
+
+// NewPasswordResetUsecase takes the session usecase so a reset logs the account out everywhere;
+// sessions may be nil when refresh tokens are not used
+func NewPasswordResetUsecase(resetRepo repository.PasswordResetRepository, userRepo repository.UserRepositoryInterface, sessions SessionUsecase, mailer mail.Mailer, auditLog AuditRecorder, config configs.PasswordResetConfig) PasswordResetUsecase {
+	if auditLog == nil {
+		auditLog = nopAuditRecorder{}
+	}
+	return &passwordResetUsecase{resetRepo: resetRepo, userRepo: userRepo, sessions: sessions, mailer: mailer, auditLog: auditLog, config: config}
+}
+
+// RequestPasswordReset emails a reset link when the email belongs to an account. Unknown emails
+// and mail delivery failures are not reported, so the caller cannot tell whether an account
+// exists. Only the newest link of an account works.
+func (u *passwordResetUsecase) RequestPasswordReset(ctx context.Context, req request.ForgotPasswordRequest) error {
+	user, err := u.userRepo.FindUserByEmail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return nil
+	}
+
+	token, err := utils.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if err := u.resetRepo.InvalidateUserPasswordResetTokens(ctx, user.ID, now); err != nil {
+		return err
+	}
+	if err := u.resetRepo.CreatePasswordResetToken(ctx, &models.PasswordResetToken{
+		TokenHash: utils.HashToken(token),
+		UserID:    user.ID,
+		ExpiresAt: now.Add(u.config.TokenTTL),
+		CreatedAt: now,
+	}); err != nil {
+		return err
+	}
+
+	if err := u.mailer.Send(ctx, u.resetMessage(user, token)); err != nil {
+		log.Printf("[MAIL] ❌ password reset email for user %s failed: %v", user.ID, err)
+	}
+	u.auditLog.Record(userActorContext(ctx, user), constants.AuditPasswordResetRequest, constants.AuditTargetUser, user.ID, nil, nil)
+	return nil
+}
+
+// ResetPassword sets a new password with a token from RequestPasswordReset. The token works once,
+// and every session of the account is revoked.
+func (u *passwordResetUsecase) ResetPassword(ctx context.Context, req request.ResetPasswordRequest) error {
+	stored, err := u.resetRepo.FindPasswordResetTokenByHash(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if stored == nil || stored.UsedAt != nil || !now.Before(stored.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+	marked, err := u.resetRepo.MarkPasswordResetTokenUsed(ctx, stored.ID, now)
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return ErrInvalidResetToken
+	}
+
+	user, err := u.userRepo.FindUserByID(ctx, stored.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrInvalidResetToken
+	}
+
+	hashedPassword, err := utils.EncryptPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+	if err := u.userRepo.UpdateUserPassword(ctx, user.ID, hashedPassword, now); err != nil {
+		return err
+	}
+	if err := u.resetRepo.InvalidateUserPasswordResetTokens(ctx, user.ID, now); err != nil {
+		return err
+	}
+
+	ctx = userActorContext(ctx, user)
+	if u.sessions != nil {
+		if err := u.sessions.LogoutAll(ctx, request.LogoutAllRequest{UserID: user.ID}); err != nil {
+			return err
+		}
+	}
+	u.auditLog.Record(ctx, constants.AuditPasswordReset, constants.AuditTargetUser, user.ID, nil, nil)
+	return nil
+}
+
+func (u *passwordResetUsecase) resetMessage(user *models.UserModel, token string) mail.Message {
+	link := u.config.URL + "?token=" + url.QueryEscape(token)
+	return mail.Message{
+		To:      user.Email,
+		Subject: "Reset your EV Charge Hub password",
+		Body: fmt.Sprintf("Hello %s,\n\nUse the link below to choose a new password. It expires in %s and works once.\n\n%s\n\n"+
+			"If you did not ask for a password reset, you can ignore this email.\n",
+			user.Username, u.config.TokenTTL, link),
+	}
+}
+
+// userActorContext makes the account its own actor for requests that come without a JWT
+func userActorContext(ctx context.Context, user *models.UserModel) context.Context {
+	if _, ok := audit.ActorFromContext(ctx); ok {
+		return ctx
+	}
+	return audit.WithActor(ctx, audit.Actor{UserID: user.ID, UserName: user.Username, Role: user.Role})
+}
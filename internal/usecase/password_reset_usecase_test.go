@@ -0,0 +1,147 @@
+package usecase_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/mail"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"Ev-Charge-Hub/Server/utils"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPasswordResetConfig = configs.PasswordResetConfig{TokenTTL: time.Hour, URL: "https://app.example.com/reset-password"}
+
+// resetTokenFromMail extracts the token from the link in the email body
+func resetTokenFromMail(t *testing.T, msg mail.Message) string {
+	start := strings.Index(msg.Body, testPasswordResetConfig.URL)
+	require.NotEqual(t, -1, start, "no reset link in %q", msg.Body)
+	link, _, _ := strings.Cut(msg.Body[start:], "\n")
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	return parsed.Query().Get("token")
+}
+
+func TestRequestPasswordReset_EmailsHashedSingleToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockResetRepo := mocks.NewMockPasswordResetRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	mailer := mail.NewMemoryMailer()
+	uc := usecase.NewPasswordResetUsecase(mockResetRepo, mockUserRepo, nil, mailer, mockAudit, testPasswordResetConfig)
+
+	mockUserRepo.EXPECT().FindUserByEmail(gomock.Any(), "alice@example.com").
+		Return(&models.UserModel{ID: "u1", Username: "alice", Email: "alice@example.com", Role: constants.RoleUser}, nil)
+	invalidate := mockResetRepo.EXPECT().InvalidateUserPasswordResetTokens(gomock.Any(), "u1", gomock.Any()).Return(nil)
+	var stored *models.PasswordResetToken
+	mockResetRepo.EXPECT().
+		CreatePasswordResetToken(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, token *models.PasswordResetToken) { stored = token }).
+		Return(nil).
+		After(invalidate)
+	mockAudit.EXPECT().Record(gomock.Any(), constants.AuditPasswordResetRequest, constants.AuditTargetUser, "u1", nil, nil)
+
+	err := uc.RequestPasswordReset(context.TODO(), request.ForgotPasswordRequest{Email: "alice@example.com"})
+	require.NoError(t, err)
+
+	sent := mailer.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, "alice@example.com", sent[0].To)
+	token := resetTokenFromMail(t, sent[0])
+	assert.NotEmpty(t, token)
+	assert.Equal(t, sha256Hex(token), stored.TokenHash)
+	assert.NotContains(t, sent[0].Body, stored.TokenHash)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiresAt, 5*time.Second)
+}
+
+func TestRequestPasswordReset_UnknownEmailSendsNothing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mailer := mail.NewMemoryMailer()
+	uc := usecase.NewPasswordResetUsecase(mocks.NewMockPasswordResetRepository(ctrl), mockUserRepo, nil, mailer, nil, testPasswordResetConfig)
+
+	mockUserRepo.EXPECT().FindUserByEmail(gomock.Any(), "nobody@example.com").Return(nil, nil)
+
+	err := uc.RequestPasswordReset(context.TODO(), request.ForgotPasswordRequest{Email: "nobody@example.com"})
+	assert.NoError(t, err)
+	assert.Empty(t, mailer.Sent())
+}
+
+func TestRequestPasswordReset_MailFailureIsNotReported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockResetRepo := mocks.NewMockPasswordResetRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockMailer := mocks.NewMockMailer(ctrl)
+	uc := usecase.NewPasswordResetUsecase(mockResetRepo, mockUserRepo, nil, mockMailer, nil, testPasswordResetConfig)
+
+	mockUserRepo.EXPECT().FindUserByEmail(gomock.Any(), gomock.Any()).Return(&models.UserModel{ID: "u1", Email: "alice@example.com"}, nil)
+	mockResetRepo.EXPECT().InvalidateUserPasswordResetTokens(gomock.Any(), "u1", gomock.Any()).Return(nil)
+	mockResetRepo.EXPECT().CreatePasswordResetToken(gomock.Any(), gomock.Any()).Return(nil)
+	mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+	err := uc.RequestPasswordReset(context.TODO(), request.ForgotPasswordRequest{Email: "alice@example.com"})
+	assert.NoError(t, err)
+}
+
+func TestResetPassword_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockResetRepo := mocks.NewMockPasswordResetRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockSessions := mocks.NewMockSessionUsecase(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewPasswordResetUsecase(mockResetRepo, mockUserRepo, mockSessions, mail.NewMemoryMailer(), mockAudit, testPasswordResetConfig)
+
+	mockResetRepo.EXPECT().FindPasswordResetTokenByHash(gomock.Any(), sha256Hex("reset-token")).
+		Return(&models.PasswordResetToken{ID: "t1", UserID: "u1", ExpiresAt: time.Now().Add(time.Minute)}, nil)
+	mockResetRepo.EXPECT().MarkPasswordResetTokenUsed(gomock.Any(), "t1", gomock.Any()).Return(true, nil)
+	mockUserRepo.EXPECT().FindUserByID(gomock.Any(), "u1").Return(&models.UserModel{ID: "u1", Username: "alice", Role: constants.RoleUser}, nil)
+	mockUserRepo.EXPECT().
+		UpdateUserPassword(gomock.Any(), "u1", gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, _ string, hash string, _ time.Time) {
+			assert.True(t, utils.ComparePassword("new-secret", hash))
+		}).
+		Return(nil)
+	mockResetRepo.EXPECT().InvalidateUserPasswordResetTokens(gomock.Any(), "u1", gomock.Any()).Return(nil)
+	mockSessions.EXPECT().LogoutAll(gomock.Any(), request.LogoutAllRequest{UserID: "u1"}).Return(nil)
+	mockAudit.EXPECT().Record(gomock.Any(), constants.AuditPasswordReset, constants.AuditTargetUser, "u1", nil, nil)
+
+	err := uc.ResetPassword(context.TODO(), request.ResetPasswordRequest{Token: "reset-token", NewPassword: "new-secret"})
+	assert.NoError(t, err)
+}
+
+func TestResetPassword_InvalidTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockResetRepo := mocks.NewMockPasswordResetRepository(ctrl)
+	uc := usecase.NewPasswordResetUsecase(mockResetRepo, nil, nil, mail.NewMemoryMailer(), nil, testPasswordResetConfig)
+
+	usedAt := time.Now().Add(-time.Minute)
+	mockResetRepo.EXPECT().FindPasswordResetTokenByHash(gomock.Any(), sha256Hex("unknown")).Return(nil, nil)
+	mockResetRepo.EXPECT().FindPasswordResetTokenByHash(gomock.Any(), sha256Hex("expired")).
+		Return(&models.PasswordResetToken{ID: "t1", UserID: "u1", ExpiresAt: time.Now().Add(-time.Second)}, nil)
+	mockResetRepo.EXPECT().FindPasswordResetTokenByHash(gomock.Any(), sha256Hex("used")).
+		Return(&models.PasswordResetToken{ID: "t2", UserID: "u1", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}, nil)
+	mockResetRepo.EXPECT().FindPasswordResetTokenByHash(gomock.Any(), sha256Hex("raced")).
+		Return(&models.PasswordResetToken{ID: "t3", UserID: "u1", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	mockResetRepo.EXPECT().MarkPasswordResetTokenUsed(gomock.Any(), "t3", gomock.Any()).Return(false, nil)
+
+	for _, token := range []string{"unknown", "expired", "used", "raced"} {
+		err := uc.ResetPassword(context.TODO(), request.ResetPasswordRequest{Token: token, NewPassword: "new-secret"})
+		assert.ErrorIs(t, err, usecase.ErrInvalidResetToken, token)
+	}
+}
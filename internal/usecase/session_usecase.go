@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/utils"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//go:generate mockgen -source=session_usecase.go -destination=../mocks/mock_session_usecase.go -package=mocks
+type SessionUsecase interface {
+	CreateSession(ctx context.Context, user *models.UserModel) (*response.LoginResponse, error)
+	RefreshSession(ctx context.Context, req request.RefreshTokenRequest) (*response.LoginResponse, error)
+	Logout(ctx context.Context, req request.LogoutRequest) error
+	LogoutAll(ctx context.Context, req request.LogoutAllRequest) error
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+var (
+	// ErrInvalidRefreshToken is returned for unknown, expired or revoked refresh tokens
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	// ErrRefreshTokenReused is returned when a refresh token is presented a second time; the
+	// whole session is revoked because the token has probably been stolen
+	ErrRefreshTokenReused = errors.New("refresh token was already used, the session has been revoked")
+)
+
+type sessionUsecase struct {
+	tokenRepo repository.RefreshTokenRepository
+	userRepo  repository.UserRepositoryInterface
+	auditLog  AuditRecorder
+	config    configs.SessionConfig
+}
+
+func NewSessionUsecase(tokenRepo repository.RefreshTokenRepository, userRepo repository.UserRepositoryInterface, auditLog AuditRecorder, config configs.SessionConfig) SessionUsecase {
+	if auditLog == nil {
+		auditLog = nopAuditRecorder{}
+	}
+	return &sessionUsecase{tokenRepo: tokenRepo, userRepo: userRepo, auditLog: auditLog, config: config}
+}
+
+// CreateSession starts a new token family for a user who just logged in
+func (u *sessionUsecase) CreateSession(ctx context.Context, user *models.UserModel) (*response.LoginResponse, error) {
+	return u.issueTokens(ctx, user, primitive.NewObjectID().Hex())
+}
+
+// RefreshSession exchanges a refresh token for a new access and refresh token. Each refresh
+// token works once; reusing one revokes every token of its family.
+func (u *sessionUsecase) RefreshSession(ctx context.Context, req request.RefreshTokenRequest) (*response.LoginResponse, error) {
+	stored, err := u.tokenRepo.FindRefreshTokenByHash(ctx, utils.HashToken(req.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if stored == nil || stored.RevokedAt != nil || !now.Before(stored.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if stored.UsedAt != nil {
+		return nil, u.revokeReusedFamily(ctx, stored)
+	}
+
+	marked, err := u.tokenRepo.MarkRefreshTokenUsed(ctx, stored.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, u.revokeReusedFamily(ctx, stored)
+	}
+
+	// the role is read again so a demotion takes effect at the next refresh
+	user, err := u.userRepo.FindUserByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		if err := u.tokenRepo.RevokeRefreshTokenFamily(ctx, stored.FamilyID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+	return u.issueTokens(ctx, user, stored.FamilyID)
+}
+
+func (u *sessionUsecase) revokeReusedFamily(ctx context.Context, stored *models.RefreshToken) error {
+	if err := u.tokenRepo.RevokeRefreshTokenFamily(ctx, stored.FamilyID, time.Now()); err != nil {
+		return err
+	}
+	u.auditLog.Record(ctx, constants.AuditRefreshTokenReuse, constants.AuditTargetUser, stored.UserID, nil, map[string]interface{}{"family_id": stored.FamilyID})
+	return ErrRefreshTokenReused
+}
+
+// Logout revokes the session the refresh token belongs to, including its current access token.
+// Unknown tokens are ignored so logging out twice is not an error.
+func (u *sessionUsecase) Logout(ctx context.Context, req request.LogoutRequest) error {
+	stored, err := u.tokenRepo.FindRefreshTokenByHash(ctx, utils.HashToken(req.RefreshToken))
+	if err != nil || stored == nil {
+		return err
+	}
+	return u.tokenRepo.RevokeRefreshTokenFamily(ctx, stored.FamilyID, time.Now())
+}
+
+// LogoutAll revokes every session of the user
+func (u *sessionUsecase) LogoutAll(ctx context.Context, req request.LogoutAllRequest) error {
+	if err := u.tokenRepo.RevokeUserRefreshTokens(ctx, req.UserID, time.Now()); err != nil {
+		return err
+	}
+	u.auditLog.Record(ctx, constants.AuditUserLogoutAll, constants.AuditTargetUser, req.UserID, nil, nil)
+	return nil
+}
+
+// IsAccessTokenRevoked reports whether the session that issued the access token was revoked.
+// Tokens without a jti, or not issued with a refresh token, are never revoked.
+func (u *sessionUsecase) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	if jti == "" {
+		return false, nil
+	}
+	stored, err := u.tokenRepo.FindRefreshTokenByAccessJTI(ctx, jti)
+	if err != nil {
+		return false, err
+	}
+	return stored != nil && stored.RevokedAt != nil, nil
+}
+
+func (u *sessionUsecase) issueTokens(ctx context.Context, user *models.UserModel, familyID string) (*response.LoginResponse, error) {
+	jti := primitive.NewObjectID().Hex()
+	accessToken, err := utils.CreateAccessToken(user.ID, user.Username, user.Role, jti, u.config.AccessTokenTTL)
+	if err != nil {
+		return nil, errors.New("failed to generate token")
+	}
+	refreshToken, err := utils.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := u.tokenRepo.CreateRefreshToken(ctx, &models.RefreshToken{
+		TokenHash: utils.HashToken(refreshToken),
+		FamilyID:  familyID,
+		UserID:    user.ID,
+		AccessJTI: jti,
+		ExpiresAt: now.Add(u.config.RefreshTokenTTL),
+		CreatedAt: now,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &response.LoginResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(u.config.AccessTokenTTL.Seconds()),
+	}, nil
+}
@@ -0,0 +1,177 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"Ev-Charge-Hub/Server/utils"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSessionConfig = configs.SessionConfig{AccessTokenTTL: 15 * time.Minute, RefreshTokenTTL: 24 * time.Hour}
+
+func TestCreateSession_StoresHashedRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	uc := usecase.NewSessionUsecase(mockTokenRepo, nil, nil, testSessionConfig)
+
+	var stored *models.RefreshToken
+	mockTokenRepo.EXPECT().
+		CreateRefreshToken(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, token *models.RefreshToken) { stored = token }).
+		Return(nil)
+
+	tokens, err := uc.CreateSession(context.TODO(), &models.UserModel{ID: "u1", Username: "alice", Role: constants.RoleUser})
+	require.NoError(t, err)
+
+	assert.Equal(t, 900, tokens.ExpiresIn)
+	assert.Equal(t, sha256Hex(tokens.RefreshToken), stored.TokenHash)
+	assert.NotEmpty(t, stored.FamilyID)
+	assert.Equal(t, "u1", stored.UserID)
+
+	claims, err := utils.ValidateToken(tokens.Token)
+	require.NoError(t, err)
+	assert.Equal(t, stored.AccessJTI, claims.ID)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+}
+
+func TestRefreshSession_RotatesWithinFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewSessionUsecase(mockTokenRepo, mockUserRepo, nil, testSessionConfig)
+
+	current := &models.RefreshToken{ID: "r1", FamilyID: "f1", UserID: "u1", ExpiresAt: time.Now().Add(time.Hour)}
+	mockTokenRepo.EXPECT().FindRefreshTokenByHash(gomock.Any(), sha256Hex("old-token")).Return(current, nil)
+	mockTokenRepo.EXPECT().MarkRefreshTokenUsed(gomock.Any(), "r1", gomock.Any()).Return(true, nil)
+	mockUserRepo.EXPECT().FindUserByID(gomock.Any(), "u1").Return(&models.UserModel{ID: "u1", Username: "alice", Role: constants.RoleAdmin}, nil)
+	mockTokenRepo.EXPECT().
+		CreateRefreshToken(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, token *models.RefreshToken) {
+			assert.Equal(t, "f1", token.FamilyID)
+		}).
+		Return(nil)
+
+	tokens, err := uc.RefreshSession(context.TODO(), request.RefreshTokenRequest{RefreshToken: "old-token"})
+	require.NoError(t, err)
+	assert.NotEqual(t, "old-token", tokens.RefreshToken)
+
+	claims, err := utils.ValidateToken(tokens.Token)
+	require.NoError(t, err)
+	assert.Equal(t, constants.RoleAdmin, claims.Role)
+}
+
+func TestRefreshSession_ReuseRevokesFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewSessionUsecase(mockTokenRepo, nil, mockAudit, testSessionConfig)
+
+	usedAt := time.Now().Add(-time.Minute)
+	mockTokenRepo.EXPECT().
+		FindRefreshTokenByHash(gomock.Any(), sha256Hex("stolen")).
+		Return(&models.RefreshToken{ID: "r1", FamilyID: "f1", UserID: "u1", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}, nil)
+	mockTokenRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "f1", gomock.Any()).Return(nil)
+	mockAudit.EXPECT().Record(gomock.Any(), constants.AuditRefreshTokenReuse, constants.AuditTargetUser, "u1", nil, gomock.Any())
+
+	_, err := uc.RefreshSession(context.TODO(), request.RefreshTokenRequest{RefreshToken: "stolen"})
+	assert.ErrorIs(t, err, usecase.ErrRefreshTokenReused)
+}
+
+func TestRefreshSession_ConcurrentRefreshCountsAsReuse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	uc := usecase.NewSessionUsecase(mockTokenRepo, nil, nil, testSessionConfig)
+
+	mockTokenRepo.EXPECT().
+		FindRefreshTokenByHash(gomock.Any(), gomock.Any()).
+		Return(&models.RefreshToken{ID: "r1", FamilyID: "f1", UserID: "u1", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	mockTokenRepo.EXPECT().MarkRefreshTokenUsed(gomock.Any(), "r1", gomock.Any()).Return(false, nil)
+	mockTokenRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "f1", gomock.Any()).Return(nil)
+
+	_, err := uc.RefreshSession(context.TODO(), request.RefreshTokenRequest{RefreshToken: "raced"})
+	assert.ErrorIs(t, err, usecase.ErrRefreshTokenReused)
+}
+
+func TestRefreshSession_ExpiredOrRevoked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	uc := usecase.NewSessionUsecase(mockTokenRepo, nil, nil, testSessionConfig)
+
+	revokedAt := time.Now()
+	mockTokenRepo.EXPECT().FindRefreshTokenByHash(gomock.Any(), sha256Hex("expired")).
+		Return(&models.RefreshToken{ID: "r1", ExpiresAt: time.Now().Add(-time.Second)}, nil)
+	mockTokenRepo.EXPECT().FindRefreshTokenByHash(gomock.Any(), sha256Hex("revoked")).
+		Return(&models.RefreshToken{ID: "r2", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil)
+	mockTokenRepo.EXPECT().FindRefreshTokenByHash(gomock.Any(), sha256Hex("unknown")).Return(nil, nil)
+
+	for _, token := range []string{"expired", "revoked", "unknown"} {
+		_, err := uc.RefreshSession(context.TODO(), request.RefreshTokenRequest{RefreshToken: token})
+		assert.ErrorIs(t, err, usecase.ErrInvalidRefreshToken, token)
+	}
+}
+
+func TestLogout_RevokesFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	uc := usecase.NewSessionUsecase(mockTokenRepo, nil, nil, testSessionConfig)
+
+	mockTokenRepo.EXPECT().FindRefreshTokenByHash(gomock.Any(), sha256Hex("mine")).Return(&models.RefreshToken{ID: "r1", FamilyID: "f1"}, nil)
+	mockTokenRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "f1", gomock.Any()).Return(nil)
+	mockTokenRepo.EXPECT().FindRefreshTokenByHash(gomock.Any(), sha256Hex("gone")).Return(nil, nil)
+
+	assert.NoError(t, uc.Logout(context.TODO(), request.LogoutRequest{RefreshToken: "mine"}))
+	assert.NoError(t, uc.Logout(context.TODO(), request.LogoutRequest{RefreshToken: "gone"}))
+}
+
+func TestLogoutAll_RevokesEverySession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewSessionUsecase(mockTokenRepo, nil, mockAudit, testSessionConfig)
+
+	mockTokenRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), "u1", gomock.Any()).Return(nil)
+	mockAudit.EXPECT().Record(gomock.Any(), constants.AuditUserLogoutAll, constants.AuditTargetUser, "u1", nil, nil)
+
+	assert.NoError(t, uc.LogoutAll(context.TODO(), request.LogoutAllRequest{UserID: "u1"}))
+}
+
+func TestIsAccessTokenRevoked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTokenRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	uc := usecase.NewSessionUsecase(mockTokenRepo, nil, nil, testSessionConfig)
+
+	revokedAt := time.Now()
+	mockTokenRepo.EXPECT().FindRefreshTokenByAccessJTI(gomock.Any(), "revoked").Return(&models.RefreshToken{RevokedAt: &revokedAt}, nil)
+	mockTokenRepo.EXPECT().FindRefreshTokenByAccessJTI(gomock.Any(), "active").Return(&models.RefreshToken{}, nil)
+
+	revoked, err := uc.IsAccessTokenRevoked(context.TODO(), "revoked")
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = uc.IsAccessTokenRevoked(context.TODO(), "active")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	revoked, err = uc.IsAccessTokenRevoked(context.TODO(), "")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
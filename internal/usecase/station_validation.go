@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	domainModel "Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// StationValidationError lists every field of a station document that broke a rule.
+// It matches ErrInvalidStationDocument with errors.Is.
+type StationValidationError struct {
+	Fields []response.FieldError
+}
+
+func (e *StationValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		if f.Field == "" {
+			messages = append(messages, f.Message)
+			continue
+		}
+		messages = append(messages, f.Field+" "+f.Message)
+	}
+	return ErrInvalidStationDocument.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *StationValidationError) Unwrap() error {
+	return ErrInvalidStationDocument
+}
+
+// stationValidationError returns nil when there are no field errors
+func stationValidationError(fieldErrors []response.FieldError) error {
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+	return &StationValidationError{Fields: fieldErrors}
+}
+
+// validateStationDocument checks a full station document: its binding tags, the domain rules
+// for coordinates and connectors, and the opening hours. A field that fails its binding tag
+// is not reported again by the domain rules.
+func validateStationDocument(req request.EVStationRequest) []response.FieldError {
+	fieldErrors := bindingFieldErrors(binding.Validator.ValidateStruct(&req))
+	bound := make(map[string]bool, len(fieldErrors))
+	for _, e := range fieldErrors {
+		bound[e.Field] = true
+	}
+
+	station := domainModel.EVStation{Latitude: req.Latitude, Longitude: req.Longitude}
+	for _, c := range req.Connectors {
+		station.Connectors = append(station.Connectors, domainModel.Connector{
+			Type:         c.Type,
+			PlugName:     c.PlugName,
+			PricePerUnit: c.PricePerUnit,
+			PowerOutput:  c.PowerOutput,
+		})
+	}
+	for _, e := range mapDomainFieldErrors(station.Validate()) {
+		if !bound[e.Field] {
+			fieldErrors = append(fieldErrors, e)
+		}
+	}
+
+	if _, err := mapStatusRequestToDomain(req.Status); err != nil {
+		fieldErrors = append(fieldErrors, response.FieldError{Field: "status", Message: err.Error()})
+	}
+	return fieldErrors
+}
+
+func mapDomainFieldErrors(errs []domainModel.FieldError) []response.FieldError {
+	fieldErrors := make([]response.FieldError, 0, len(errs))
+	for _, e := range errs {
+		fieldErrors = append(fieldErrors, response.FieldError{Field: e.Field, Message: e.Message})
+	}
+	return fieldErrors
+}
+
+// patchFieldErrors keeps the field errors a patch is responsible for. Errors on fields and
+// connectors the patch left untouched were already stored and must not block an unrelated change;
+// a connector is untouched when it keeps its connector_id and every value.
+func patchFieldErrors(fieldErrors []response.FieldError, current request.EVStationRequest, patched request.EVStationRequest) []response.FieldError {
+	touched := map[string]bool{
+		"external_ref": patched.ExternalRef != current.ExternalRef,
+		"name":         patched.Name != current.Name,
+		"latitude":     patched.Latitude != current.Latitude,
+		"longitude":    patched.Longitude != current.Longitude,
+		"company":      patched.Company != current.Company,
+		"status":       !reflect.DeepEqual(patched.Status, current.Status),
+		"connectors":   !reflect.DeepEqual(patched.Connectors, current.Connectors),
+	}
+	currentConnectors := make(map[string]request.ConnectorRequest, len(current.Connectors))
+	for _, c := range current.Connectors {
+		currentConnectors[c.ConnectorID] = c
+	}
+
+	kept := make([]response.FieldError, 0, len(fieldErrors))
+	for _, e := range fieldErrors {
+		name := e.Field
+		if end := strings.IndexAny(name, ".["); end >= 0 {
+			name = name[:end]
+		}
+
+		var i int
+		if _, err := fmt.Sscanf(e.Field, "connectors[%d]", &i); err == nil && i < len(patched.Connectors) {
+			connector := patched.Connectors[i]
+			if old, ok := currentConnectors[connector.ConnectorID]; ok && connector.ConnectorID != "" && reflect.DeepEqual(connector, old) {
+				continue
+			}
+		} else if changed, known := touched[name]; known && !changed {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept
+}
+
+// bindingFieldErrors turns validator errors into JSON-path field errors
+func bindingFieldErrors(err error) []response.FieldError {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []response.FieldError{{Message: err.Error()}}
+	}
+
+	fieldErrors := make([]response.FieldError, 0, len(validationErrors))
+	for _, e := range validationErrors {
+		fieldErrors = append(fieldErrors, response.FieldError{
+			Field:   jsonFieldPath(e.Namespace()),
+			Message: fmt.Sprintf("failed on the '%s' rule", e.Tag()),
+		})
+	}
+	return fieldErrors
+}
+
+// jsonFieldPath maps a validator namespace such as "EVStationRequest.Connectors[0].PricePerUnit"
+// to the JSON path "connectors[0].price_per_unit"
+func jsonFieldPath(namespace string) string {
+	parts := strings.Split(namespace, ".")
+	if len(parts) > 1 {
+		parts = parts[1:]
+	}
+
+	for i, part := range parts {
+		var b strings.Builder
+		prev := rune(0)
+		for _, r := range part {
+			if unicode.IsUpper(r) {
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+					b.WriteByte('_')
+				}
+				b.WriteRune(unicode.ToLower(r))
+			} else {
+				b.WriteRune(r)
+			}
+			prev = r
+		}
+		parts[i] = b.String()
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,407 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/authz"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/mail"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"Ev-Charge-Hub/Server/utils"
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+//go:generate mockgen -source=two_factor_usecase.go -destination=../mocks/mock_two_factor_usecase.go -package=mocks
+type TwoFactorUsecase interface {
+	// LoginChallenge returns nil when the user logs in with the password alone
+	LoginChallenge(ctx context.Context, user *models.UserModel) (*response.LoginResponse, error)
+	VerifyLoginChallenge(ctx context.Context, req request.TwoFactorLoginRequest) (*response.LoginResponse, error)
+	SetupFromChallenge(ctx context.Context, req request.TwoFactorChallengeSetupRequest) (*response.TOTPSetupResponse, error)
+	SetupTOTP(ctx context.Context, userID string) (*response.TOTPSetupResponse, error)
+	ActivateTOTP(ctx context.Context, req request.TOTPCodeRequest) (*response.RecoveryCodesResponse, error)
+	RegenerateRecoveryCodes(ctx context.Context, req request.TOTPCodeRequest) (*response.RecoveryCodesResponse, error)
+	DisableTOTP(ctx context.Context, req request.TOTPCodeRequest) error
+	ResetTwoFactor(ctx context.Context, userID string) error
+}
+
+var (
+	// ErrInvalidTwoFactorChallenge is returned for unknown or expired login challenge tokens
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
+	// ErrInvalidTOTPCode is returned for a wrong, expired or already used code
+	ErrInvalidTOTPCode = errors.New("invalid two-factor code")
+	// ErrTOTPAlreadyEnabled is returned when setting up TOTP for an account that has it
+	ErrTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrTOTPNotEnabled is returned when changing TOTP settings of an account without TOTP
+	ErrTOTPNotEnabled = errors.New("two-factor authentication is not enabled")
+	// ErrTOTPSetupNotStarted is returned when confirming an enrollment that was not set up
+	ErrTOTPSetupNotStarted = errors.New("two-factor setup has not been started")
+	// ErrTOTPRequired is returned when an account whose role requires TOTP tries to turn it off
+	ErrTOTPRequired = errors.New("two-factor authentication is required for this account")
+	// ErrInvalidEnrollmentCode is returned when an enrollment started at login is completed without
+	// the code mailed to the account
+	ErrInvalidEnrollmentCode = errors.New("invalid email confirmation code")
+)
+
+// recoveryCodeCount is how many single-use recovery codes an enrollment gets
+const recoveryCodeCount = 10
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type twoFactorUsecase struct {
+	userRepo   repository.UserRepositoryInterface
+	sessions   SessionUsecase
+	loginGuard LoginProtectionUsecase
+	mailer     mail.Mailer
+	auditLog   AuditRecorder
+	config     configs.TwoFactorConfig
+}
+
+// NewTwoFactorUsecase takes the session usecase that issues tokens once the code is verified and
+// the login guard that counts wrong codes like wrong passwords; both may be nil. The mailer sends
+// the confirmation code of an enrollment started at login.
+func NewTwoFactorUsecase(userRepo repository.UserRepositoryInterface, sessions SessionUsecase, loginGuard LoginProtectionUsecase, mailer mail.Mailer, auditLog AuditRecorder, config configs.TwoFactorConfig) TwoFactorUsecase {
+	if auditLog == nil {
+		auditLog = nopAuditRecorder{}
+	}
+	return &twoFactorUsecase{userRepo: userRepo, sessions: sessions, loginGuard: loginGuard, mailer: mailer, auditLog: auditLog, config: config}
+}
+
+// LoginChallenge is called once the password is right. Accounts with TOTP, and ADMIN accounts
+// when TwoFactorConfig.RequiredForAdmins is set, get a challenge token instead of tokens.
+func (u *twoFactorUsecase) LoginChallenge(ctx context.Context, user *models.UserModel) (*response.LoginResponse, error) {
+	if !user.TOTPEnabled && !u.required(user) {
+		return nil, nil
+	}
+
+	challenge, err := utils.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.userRepo.SetTwoFactorChallenge(ctx, user.ID, utils.HashToken(challenge), time.Now().Add(u.config.ChallengeTTL)); err != nil {
+		return nil, err
+	}
+	return &response.LoginResponse{
+		TwoFactorRequired:           true,
+		TwoFactorEnrollmentRequired: !user.TOTPEnabled,
+		ChallengeToken:              challenge,
+	}, nil
+}
+
+// VerifyLoginChallenge completes a login with a TOTP or recovery code. For an ADMIN that must
+// enroll, the code confirms the secret from SetupFromChallenge together with the code mailed to
+// the account, as the password alone must not be enough to enroll; the recovery codes are
+// returned with the tokens.
+func (u *twoFactorUsecase) VerifyLoginChallenge(ctx context.Context, req request.TwoFactorLoginRequest) (*response.LoginResponse, error) {
+	user, err := u.userRepo.FindUserByTwoFactorChallenge(ctx, utils.HashToken(req.ChallengeToken), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	accountKey := userLoginKey(user.ID)
+	if u.loginGuard != nil {
+		if err := u.loginGuard.CheckLogin(ctx, accountKey, req.ClientIP); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx = userActorContext(ctx, user)
+	var recoveryCodes []string
+	if user.TOTPEnabled {
+		err = u.verifyCode(ctx, user, req.Code, true)
+	} else {
+		recoveryCodes, err = u.enrollAtLogin(ctx, user, req.Code, req.EmailCode)
+	}
+	if (errors.Is(err, ErrInvalidTOTPCode) || errors.Is(err, ErrInvalidEnrollmentCode)) && u.loginGuard != nil {
+		if err := u.loginGuard.RecordLoginFailure(ctx, accountKey, req.ClientIP); err != nil {
+			log.Printf("⚠️ recording failed login for %s failed: %v", accountKey, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.userRepo.ClearTwoFactorChallenge(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	if u.loginGuard != nil {
+		if err := u.loginGuard.RecordLoginSuccess(ctx, accountKey); err != nil {
+			log.Printf("⚠️ clearing failed logins for %s failed: %v", accountKey, err)
+		}
+	}
+
+	tokens, err := issueLoginTokens(ctx, u.sessions, user)
+	if err != nil {
+		return nil, err
+	}
+	tokens.RecoveryCodes = recoveryCodes
+	return tokens, nil
+}
+
+// SetupFromChallenge starts the enrollment of an account that must have TOTP to log in and mails a
+// confirmation code to the account; VerifyLoginChallenge needs both
+func (u *twoFactorUsecase) SetupFromChallenge(ctx context.Context, req request.TwoFactorChallengeSetupRequest) (*response.TOTPSetupResponse, error) {
+	user, err := u.userRepo.FindUserByTwoFactorChallenge(ctx, utils.HashToken(req.ChallengeToken), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	emailCode, emailCodeHash, err := newRecoveryCode()
+	if err != nil {
+		return nil, err
+	}
+	setup, err := u.setup(ctx, user, emailCodeHash)
+	if err != nil {
+		return nil, err
+	}
+	err = u.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Confirm two-factor authentication for EV Charge Hub",
+		Body: fmt.Sprintf("Hello %s,\n\nSomeone signed in with your password and is setting up two-factor authentication. "+
+			"To finish, enter this confirmation code together with the first code from your authenticator app:\n\n%s\n\n"+
+			"If this was not you, do not share the code: change your password and contact an administrator.\n",
+			user.Username, emailCode),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return setup, nil
+}
+
+// SetupTOTP starts an enrollment for the authenticated user; ActivateTOTP completes it
+func (u *twoFactorUsecase) SetupTOTP(ctx context.Context, userID string) (*response.TOTPSetupResponse, error) {
+	user, err := u.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.setup(ctx, user, "")
+}
+
+func (u *twoFactorUsecase) ActivateTOTP(ctx context.Context, req request.TOTPCodeRequest) (*response.RecoveryCodesResponse, error) {
+	user, err := u.findUser(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	recoveryCodes, err := u.activate(ctx, user, req.Code, constants.AuditTwoFactorEnable)
+	if err != nil {
+		return nil, err
+	}
+	return &response.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// RegenerateRecoveryCodes replaces every recovery code; it takes an authenticator code, not a recovery code
+func (u *twoFactorUsecase) RegenerateRecoveryCodes(ctx context.Context, req request.TOTPCodeRequest) (*response.RecoveryCodesResponse, error) {
+	user, err := u.findUser(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, ErrTOTPNotEnabled
+	}
+	if err := u.verifyCode(ctx, user, req.Code, false); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.userRepo.SetTOTPRecoveryCodes(ctx, user.ID, hashes); err != nil {
+		return nil, err
+	}
+	u.auditLog.Record(ctx, constants.AuditRecoveryCodesReset, constants.AuditTargetUser, user.ID, nil, nil)
+	return &response.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (u *twoFactorUsecase) DisableTOTP(ctx context.Context, req request.TOTPCodeRequest) error {
+	user, err := u.findUser(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return ErrTOTPNotEnabled
+	}
+	if u.required(user) {
+		return ErrTOTPRequired
+	}
+	if err := u.verifyCode(ctx, user, req.Code, true); err != nil {
+		return err
+	}
+
+	if err := u.userRepo.DisableTOTP(ctx, user.ID, time.Now()); err != nil {
+		return err
+	}
+	u.auditLog.Record(ctx, constants.AuditTwoFactorDisable, constants.AuditTargetUser, user.ID, nil, nil)
+	return nil
+}
+
+// ResetTwoFactor turns TOTP off for an account that lost its authenticator and its recovery
+// codes; an ADMIN that must have TOTP enrolls again at the next login
+func (u *twoFactorUsecase) ResetTwoFactor(ctx context.Context, userID string) error {
+	if err := authz.Check(ctx, constants.PermUserManage); err != nil {
+		return err
+	}
+	user, err := u.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := u.userRepo.DisableTOTP(ctx, user.ID, time.Now()); err != nil {
+		return err
+	}
+	u.auditLog.Record(ctx, constants.AuditTwoFactorDisable, constants.AuditTargetUser, user.ID, nil, nil)
+	return nil
+}
+
+func (u *twoFactorUsecase) required(user *models.UserModel) bool {
+	return u.config.RequiredForAdmins && user.Role == constants.RoleAdmin
+}
+
+func (u *twoFactorUsecase) findUser(ctx context.Context, userID string) (*models.UserModel, error) {
+	user, err := u.userRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// setup stores a new pending secret; a repeated setup replaces the secret not yet confirmed.
+// emailCodeHash is set for an enrollment started at login.
+func (u *twoFactorUsecase) setup(ctx context.Context, user *models.UserModel, emailCodeHash string) (*response.TOTPSetupResponse, error) {
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.userRepo.SetPendingTOTPSecret(ctx, user.ID, secret, emailCodeHash); err != nil {
+		return nil, err
+	}
+	return &response.TOTPSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(u.config.Issuer, user.Email, secret),
+	}, nil
+}
+
+// enrollAtLogin completes an enrollment started by SetupFromChallenge. It is recorded in the audit
+// log under its own action and the account is told by email, since only a password was needed to start it.
+func (u *twoFactorUsecase) enrollAtLogin(ctx context.Context, user *models.UserModel, code string, emailCode string) ([]string, error) {
+	if user.TOTPEnrollmentCodeHash == "" || utils.HashToken(normalizeRecoveryCode(emailCode)) != user.TOTPEnrollmentCodeHash {
+		return nil, ErrInvalidEnrollmentCode
+	}
+	recoveryCodes, err := u.activate(ctx, user, code, constants.AuditTwoFactorLoginEnroll)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Two-factor authentication turned on for EV Charge Hub",
+		Body: fmt.Sprintf("Hello %s,\n\nTwo-factor authentication was turned on for your account at %s, during a sign-in.\n\n"+
+			"If this was not you, contact an administrator immediately: your password and your email are known to someone else.\n",
+			user.Username, time.Now().UTC().Format(time.RFC1123)),
+	})
+	if err != nil {
+		log.Printf("⚠️ sending the enrollment notice to user %s failed: %v", user.ID, err)
+	}
+	return recoveryCodes, nil
+}
+
+// activate confirms the pending secret with a code from the authenticator app, records action in
+// the audit log and returns the new recovery codes
+func (u *twoFactorUsecase) activate(ctx context.Context, user *models.UserModel, code string, action constants.AuditAction) ([]string, error) {
+	if user.TOTPPendingSecret == "" {
+		return nil, ErrTOTPSetupNotStarted
+	}
+	step, ok := utils.ValidateTOTP(user.TOTPPendingSecret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTOTPCode
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.userRepo.EnableTOTP(ctx, user.ID, user.TOTPPendingSecret, step, hashes, time.Now()); err != nil {
+		return nil, err
+	}
+	u.auditLog.Record(ctx, action, constants.AuditTargetUser, user.ID, nil, nil)
+	return recoveryCodes, nil
+}
+
+// verifyCode accepts a code from the authenticator app once, or with allowRecovery an unused recovery code
+func (u *twoFactorUsecase) verifyCode(ctx context.Context, user *models.UserModel, code string, allowRecovery bool) error {
+	if step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now()); ok {
+		fresh, err := u.userRepo.UseTOTPStep(ctx, user.ID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return ErrInvalidTOTPCode
+		}
+		return nil
+	}
+	if !allowRecovery {
+		return ErrInvalidTOTPCode
+	}
+
+	used, err := u.userRepo.UseTOTPRecoveryCode(ctx, user.ID, utils.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTOTPCode
+	}
+	u.auditLog.Record(ctx, constants.AuditRecoveryCodeUse, constants.AuditTargetUser, user.ID, nil, nil)
+	return nil
+}
+
+// newRecoveryCodes returns recoveryCodeCount codes from newRecoveryCode and their hashes
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, hash, err := newRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hash)
+	}
+	return codes, hashes, nil
+}
+
+// newRecoveryCode returns a code like "abcd-efgh" (40 random bits) and the hash of its normalized form
+func newRecoveryCode() (string, string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+	return code[:4] + "-" + code[4:], utils.HashToken(code), nil
+}
+
+// normalizeRecoveryCode ignores case, spaces and the dash, as users retype the codes by hand
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
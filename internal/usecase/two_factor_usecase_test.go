@@ -0,0 +1,281 @@
+package usecase_test
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"Ev-Charge-Hub/Server/configs"
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/authz"
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/mail"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"Ev-Charge-Hub/Server/utils"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+var testTwoFactorConfig = configs.TwoFactorConfig{Issuer: "EV Charge Hub", ChallengeTTL: 5 * time.Minute, RequiredForAdmins: true}
+
+func currentTOTPCode(t *testing.T, secret string) string {
+	code, err := utils.TOTPCode(secret, utils.TOTPStep(time.Now()))
+	assert.NoError(t, err)
+	return code
+}
+
+func TestLoginChallenge_OnlyForTOTPAccountsAndRequiredAdmins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewTwoFactorUsecase(mockRepo, nil, nil, nil, nil, testTwoFactorConfig)
+
+	challenge, err := uc.LoginChallenge(context.TODO(), &models.UserModel{ID: "u1", Role: constants.RoleUser})
+	assert.NoError(t, err)
+	assert.Nil(t, challenge)
+
+	var storedHash string
+	mockRepo.EXPECT().SetTwoFactorChallenge(gomock.Any(), "a1", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, hash string, expiresAt time.Time) error {
+			storedHash = hash
+			assert.WithinDuration(t, time.Now().Add(5*time.Minute), expiresAt, time.Second)
+			return nil
+		})
+
+	challenge, err = uc.LoginChallenge(context.TODO(), &models.UserModel{ID: "a1", Role: constants.RoleAdmin})
+	assert.NoError(t, err)
+	assert.True(t, challenge.TwoFactorRequired)
+	assert.True(t, challenge.TwoFactorEnrollmentRequired)
+	assert.Empty(t, challenge.Token)
+	assert.Equal(t, sha256Hex(challenge.ChallengeToken), storedHash)
+}
+
+func TestVerifyLoginChallenge_TOTPCodeStartsSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockSessions := mocks.NewMockSessionUsecase(ctrl)
+	mockGuard := mocks.NewMockLoginProtectionUsecase(ctrl)
+	uc := usecase.NewTwoFactorUsecase(mockRepo, mockSessions, mockGuard, nil, nil, testTwoFactorConfig)
+
+	user := &models.UserModel{ID: "a1", Role: constants.RoleAdmin, TOTPEnabled: true, TOTPSecret: testTOTPSecret}
+	mockRepo.EXPECT().FindUserByTwoFactorChallenge(gomock.Any(), sha256Hex("challenge"), gomock.Any()).Return(user, nil)
+	mockGuard.EXPECT().CheckLogin(gomock.Any(), "user:a1", "198.51.100.7").Return(nil)
+	mockRepo.EXPECT().UseTOTPStep(gomock.Any(), "a1", gomock.Any()).Return(true, nil)
+	mockRepo.EXPECT().ClearTwoFactorChallenge(gomock.Any(), "a1").Return(nil)
+	mockGuard.EXPECT().RecordLoginSuccess(gomock.Any(), "user:a1").Return(nil)
+	mockSessions.EXPECT().CreateSession(gomock.Any(), user).Return(&response.LoginResponse{Token: "access", RefreshToken: "refresh"}, nil)
+
+	resp, err := uc.VerifyLoginChallenge(context.TODO(), request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: currentTOTPCode(t, testTOTPSecret), ClientIP: "198.51.100.7"})
+	assert.NoError(t, err)
+	assert.Equal(t, "access", resp.Token)
+}
+
+func TestVerifyLoginChallenge_UsedCodeCountsAsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockGuard := mocks.NewMockLoginProtectionUsecase(ctrl)
+	uc := usecase.NewTwoFactorUsecase(mockRepo, nil, mockGuard, nil, nil, testTwoFactorConfig)
+
+	user := &models.UserModel{ID: "a1", TOTPEnabled: true, TOTPSecret: testTOTPSecret}
+	mockRepo.EXPECT().FindUserByTwoFactorChallenge(gomock.Any(), gomock.Any(), gomock.Any()).Return(user, nil)
+	mockGuard.EXPECT().CheckLogin(gomock.Any(), "user:a1", "").Return(nil)
+	mockRepo.EXPECT().UseTOTPStep(gomock.Any(), "a1", gomock.Any()).Return(false, nil)
+	mockGuard.EXPECT().RecordLoginFailure(gomock.Any(), "user:a1", "").Return(nil)
+
+	_, err := uc.VerifyLoginChallenge(context.TODO(), request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: currentTOTPCode(t, testTOTPSecret)})
+	assert.ErrorIs(t, err, usecase.ErrInvalidTOTPCode)
+}
+
+func TestVerifyLoginChallenge_RecoveryCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockSessions := mocks.NewMockSessionUsecase(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewTwoFactorUsecase(mockRepo, mockSessions, nil, nil, mockAudit, testTwoFactorConfig)
+
+	user := &models.UserModel{ID: "a1", TOTPEnabled: true, TOTPSecret: testTOTPSecret}
+	mockRepo.EXPECT().FindUserByTwoFactorChallenge(gomock.Any(), gomock.Any(), gomock.Any()).Return(user, nil)
+	mockRepo.EXPECT().UseTOTPRecoveryCode(gomock.Any(), "a1", sha256Hex("abcdefgh")).Return(true, nil)
+	mockAudit.EXPECT().Record(gomock.Any(), constants.AuditRecoveryCodeUse, constants.AuditTargetUser, "a1", nil, nil)
+	mockRepo.EXPECT().ClearTwoFactorChallenge(gomock.Any(), "a1").Return(nil)
+	mockSessions.EXPECT().CreateSession(gomock.Any(), user).Return(&response.LoginResponse{Token: "access"}, nil)
+
+	_, err := uc.VerifyLoginChallenge(context.TODO(), request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "ABCD-EFGH"})
+	assert.NoError(t, err)
+}
+
+func TestSetupFromChallenge_MailsConfirmationCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mailer := mail.NewMemoryMailer()
+	uc := usecase.NewTwoFactorUsecase(mockRepo, nil, nil, mailer, nil, testTwoFactorConfig)
+
+	var codeHash string
+	mockRepo.EXPECT().FindUserByTwoFactorChallenge(gomock.Any(), sha256Hex("challenge"), gomock.Any()).
+		Return(&models.UserModel{ID: "a1", Username: "root", Email: "root@example.com", Role: constants.RoleAdmin}, nil)
+	mockRepo.EXPECT().SetPendingTOTPSecret(gomock.Any(), "a1", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, enrollmentCodeHash string) error {
+			codeHash = enrollmentCodeHash
+			return nil
+		})
+
+	setup, err := uc.SetupFromChallenge(context.TODO(), request.TwoFactorChallengeSetupRequest{ChallengeToken: "challenge"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, setup.Secret)
+
+	sent := mailer.Sent()
+	assert.Len(t, sent, 1)
+	assert.Equal(t, "root@example.com", sent[0].To)
+	code := regexp.MustCompile(`[a-z2-7]{4}-[a-z2-7]{4}`).FindString(sent[0].Body)
+	assert.Equal(t, sha256Hex(strings.ReplaceAll(code, "-", "")), codeHash)
+}
+
+func TestVerifyLoginChallenge_CompletesMandatoryEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockSessions := mocks.NewMockSessionUsecase(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	mailer := mail.NewMemoryMailer()
+	uc := usecase.NewTwoFactorUsecase(mockRepo, mockSessions, nil, mailer, mockAudit, testTwoFactorConfig)
+
+	user := &models.UserModel{ID: "a1", Email: "root@example.com", Role: constants.RoleAdmin, TOTPPendingSecret: testTOTPSecret, TOTPEnrollmentCodeHash: sha256Hex("abcdefgh")}
+	var storedHashes []string
+	mockRepo.EXPECT().FindUserByTwoFactorChallenge(gomock.Any(), gomock.Any(), gomock.Any()).Return(user, nil)
+	mockRepo.EXPECT().EnableTOTP(gomock.Any(), "a1", testTOTPSecret, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, _ int64, hashes []string, _ time.Time) error {
+			storedHashes = hashes
+			return nil
+		})
+	mockAudit.EXPECT().Record(gomock.Any(), constants.AuditTwoFactorLoginEnroll, constants.AuditTargetUser, "a1", nil, nil)
+	mockRepo.EXPECT().ClearTwoFactorChallenge(gomock.Any(), "a1").Return(nil)
+	mockSessions.EXPECT().CreateSession(gomock.Any(), user).Return(&response.LoginResponse{Token: "access"}, nil)
+
+	resp, err := uc.VerifyLoginChallenge(context.TODO(), request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: currentTOTPCode(t, testTOTPSecret), EmailCode: "ABCD-EFGH"})
+	assert.NoError(t, err)
+	assert.Equal(t, "access", resp.Token)
+	assert.Len(t, resp.RecoveryCodes, 10)
+	assert.Equal(t, sha256Hex(strings.ReplaceAll(resp.RecoveryCodes[0], "-", "")), storedHashes[0])
+	// the account is told about the enrollment
+	assert.Len(t, mailer.Sent(), 1)
+	assert.Equal(t, "root@example.com", mailer.Sent()[0].To)
+}
+
+func TestVerifyLoginChallenge_EnrollmentNeedsEmailCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockGuard := mocks.NewMockLoginProtectionUsecase(ctrl)
+	uc := usecase.NewTwoFactorUsecase(mockRepo, nil, mockGuard, nil, nil, testTwoFactorConfig)
+
+	user := &models.UserModel{ID: "a1", Role: constants.RoleAdmin, TOTPPendingSecret: testTOTPSecret, TOTPEnrollmentCodeHash: sha256Hex("abcdefgh")}
+	mockRepo.EXPECT().FindUserByTwoFactorChallenge(gomock.Any(), gomock.Any(), gomock.Any()).Return(user, nil).Times(2)
+	mockGuard.EXPECT().CheckLogin(gomock.Any(), "user:a1", gomock.Any()).Return(nil).Times(2)
+	mockGuard.EXPECT().RecordLoginFailure(gomock.Any(), "user:a1", gomock.Any()).Return(nil).Times(2)
+
+	_, err := uc.VerifyLoginChallenge(context.TODO(), request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: currentTOTPCode(t, testTOTPSecret)})
+	assert.ErrorIs(t, err, usecase.ErrInvalidEnrollmentCode)
+
+	_, err = uc.VerifyLoginChallenge(context.TODO(), request.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: currentTOTPCode(t, testTOTPSecret), EmailCode: "abcd-efgi"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidEnrollmentCode)
+}
+
+func TestVerifyLoginChallenge_UnknownChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewTwoFactorUsecase(mockRepo, nil, nil, nil, nil, testTwoFactorConfig)
+
+	mockRepo.EXPECT().FindUserByTwoFactorChallenge(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	_, err := uc.VerifyLoginChallenge(context.TODO(), request.TwoFactorLoginRequest{ChallengeToken: "expired", Code: "123456"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidTwoFactorChallenge)
+}
+
+func TestSetupTOTP_ReturnsProvisioningURI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewTwoFactorUsecase(mockRepo, nil, nil, nil, nil, testTwoFactorConfig)
+
+	var pendingSecret string
+	mockRepo.EXPECT().FindUserByID(gomock.Any(), "u1").Return(&models.UserModel{ID: "u1", Email: "alice@example.com"}, nil)
+	mockRepo.EXPECT().SetPendingTOTPSecret(gomock.Any(), "u1", gomock.Any(), "").
+		DoAndReturn(func(_ context.Context, _ string, secret string, _ string) error {
+			pendingSecret = secret
+			return nil
+		})
+
+	setup, err := uc.SetupTOTP(context.TODO(), "u1")
+	assert.NoError(t, err)
+	assert.Equal(t, pendingSecret, setup.Secret)
+	assert.True(t, strings.HasPrefix(setup.ProvisioningURI, "otpauth://totp/EV%20Charge%20Hub:alice@example.com?"))
+	assert.Contains(t, setup.ProvisioningURI, "secret="+pendingSecret)
+}
+
+func TestActivateTOTP_WrongCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewTwoFactorUsecase(mockRepo, nil, nil, nil, nil, testTwoFactorConfig)
+
+	mockRepo.EXPECT().FindUserByID(gomock.Any(), "u1").Return(&models.UserModel{ID: "u1", TOTPPendingSecret: testTOTPSecret}, nil)
+	mockRepo.EXPECT().FindUserByID(gomock.Any(), "u2").Return(&models.UserModel{ID: "u2"}, nil)
+
+	_, err := uc.ActivateTOTP(context.TODO(), request.TOTPCodeRequest{UserID: "u1", Code: "000000x"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidTOTPCode)
+	_, err = uc.ActivateTOTP(context.TODO(), request.TOTPCodeRequest{UserID: "u2", Code: "123456"})
+	assert.ErrorIs(t, err, usecase.ErrTOTPSetupNotStarted)
+}
+
+func TestRegenerateRecoveryCodes_RefusesRecoveryCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewTwoFactorUsecase(mockRepo, nil, nil, nil, nil, testTwoFactorConfig)
+
+	mockRepo.EXPECT().FindUserByID(gomock.Any(), "u1").Return(&models.UserModel{ID: "u1", TOTPEnabled: true, TOTPSecret: testTOTPSecret}, nil)
+
+	_, err := uc.RegenerateRecoveryCodes(context.TODO(), request.TOTPCodeRequest{UserID: "u1", Code: "abcd-efgh"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidTOTPCode)
+}
+
+func TestDisableTOTP_RequiredForAdmins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewTwoFactorUsecase(mockRepo, nil, nil, nil, mockAudit, testTwoFactorConfig)
+
+	mockRepo.EXPECT().FindUserByID(gomock.Any(), "a1").Return(&models.UserModel{ID: "a1", Role: constants.RoleAdmin, TOTPEnabled: true, TOTPSecret: testTOTPSecret}, nil)
+	mockRepo.EXPECT().FindUserByID(gomock.Any(), "u1").Return(&models.UserModel{ID: "u1", Role: constants.RoleUser, TOTPEnabled: true, TOTPSecret: testTOTPSecret}, nil)
+	mockRepo.EXPECT().UseTOTPStep(gomock.Any(), "u1", gomock.Any()).Return(true, nil)
+	mockRepo.EXPECT().DisableTOTP(gomock.Any(), "u1", gomock.Any()).Return(nil)
+	mockAudit.EXPECT().Record(gomock.Any(), constants.AuditTwoFactorDisable, constants.AuditTargetUser, "u1", nil, nil)
+
+	code := currentTOTPCode(t, testTOTPSecret)
+	assert.ErrorIs(t, uc.DisableTOTP(context.TODO(), request.TOTPCodeRequest{UserID: "a1", Code: code}), usecase.ErrTOTPRequired)
+	assert.NoError(t, uc.DisableTOTP(context.TODO(), request.TOTPCodeRequest{UserID: "u1", Code: code}))
+}
+
+func TestResetTwoFactor_UserActorIsForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uc := usecase.NewTwoFactorUsecase(mocks.NewMockUserRepositoryInterface(ctrl), nil, nil, nil, nil, testTwoFactorConfig)
+
+	ctx := audit.WithActor(context.TODO(), audit.Actor{UserID: "u2", UserName: "bob", Role: constants.RoleUser})
+	assert.ErrorIs(t, uc.ResetTwoFactor(ctx, "a1"), authz.ErrForbidden)
+}
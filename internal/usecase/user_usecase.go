@@ -1,6 +1,9 @@
 package usecase
 
 import (
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/authz"
+	"Ev-Charge-Hub/Server/internal/constants"
 	"Ev-Charge-Hub/Server/internal/dto/request"
 	"Ev-Charge-Hub/Server/internal/dto/response"
 	"Ev-Charge-Hub/Server/internal/domain/models"
@@ -8,55 +11,335 @@ import (
 	"Ev-Charge-Hub/Server/utils"
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"sync"
 	"time"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 //go:generate mockgen -source=user_usecase.go -destination=../mocks/mock_user_usecase.go -package=mocks
 type UserUsecaseInterface interface {
 	RegisterUser(ctx context.Context, req request.RegisterUserRequest) (error)
 	LoginUser(ctx context.Context, req request.LoginRequest) (*response.LoginResponse, error)
+	ProvisionUser(ctx context.Context, req request.ProvisionUserRequest) (*response.UserResponse, error)
+	ChangeUserRole(ctx context.Context, req request.ChangeUserRoleRequest) (*response.UserResponse, error)
+	GetAdminAccounts(ctx context.Context) ([]response.UserResponse, error)
+	UnlockUser(ctx context.Context, userID string) error
+}
+
+var (
+	// ErrEmailAlreadyExists is returned when registering or provisioning an email that is taken
+	ErrEmailAlreadyExists = errors.New("email already exists")
+	// ErrUsernameAlreadyExists is returned when registering or provisioning a username that is
+	// taken, ignoring letter case
+	ErrUsernameAlreadyExists = errors.New("username already exists")
+	// ErrInvalidRole is returned for a role other than ADMIN or USER
+	ErrInvalidRole = errors.New("invalid role")
+	// ErrUserNotFound is returned when the user to change does not exist
+	ErrUserNotFound = errors.New("user not found")
+	// ErrLastAdmin is returned when a role change would leave no ADMIN account
+	ErrLastAdmin = errors.New("cannot demote the last ADMIN account")
+	// ErrInvalidCredentials is returned by LoginUser for an unknown account and for a wrong
+	// password alike, so the response does not reveal which accounts exist
+	ErrInvalidCredentials = errors.New("invalid email or password")
+)
+
+var (
+	dummyPasswordHashOnce  sync.Once
+	dummyPasswordHashValue string
+)
+
+// dummyPasswordHash is compared against when the account does not exist
+func dummyPasswordHash() string {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPasswordHashValue, _ = utils.EncryptPassword("dummy password for unknown accounts")
+	})
+	return dummyPasswordHashValue
 }
 
 type userUsecase struct {
-	userRepo repository.UserRepositoryInterface
+	userRepo      repository.UserRepositoryInterface
+	auditLog      AuditRecorder
+	sessions      SessionUsecase
+	verifications EmailVerificationUsecase
+	loginGuard    LoginProtectionUsecase
+	twoFactor     TwoFactorUsecase
 }
 
-func NewUserUsecase(userRepo repository.UserRepositoryInterface) UserUsecaseInterface {
-	return &userUsecase{userRepo: userRepo}
+// NewUserUsecase takes the session usecase that issues login tokens; without one LoginUser
+// returns a plain 24-hour access token and no refresh token. Without verifications no
+// verification email is sent at registration, without loginGuard failed logins are not limited,
+// and without twoFactor the password alone logs in.
+func NewUserUsecase(userRepo repository.UserRepositoryInterface, auditLog AuditRecorder, sessions SessionUsecase, verifications EmailVerificationUsecase, loginGuard LoginProtectionUsecase, twoFactor TwoFactorUsecase) UserUsecaseInterface {
+	if auditLog == nil {
+		auditLog = nopAuditRecorder{}
+	}
+	return &userUsecase{userRepo: userRepo, auditLog: auditLog, sessions: sessions, verifications: verifications, loginGuard: loginGuard, twoFactor: twoFactor}
 }
 
+// RegisterUser is the public sign-up and always creates a USER; admins are provisioned with ProvisionUser
 func (u *userUsecase) RegisterUser(ctx context.Context, req request.RegisterUserRequest) error {
-	existingUser, _ := u.userRepo.FindByUsernameOrEmail(ctx, req.Email)
-	if existingUser != nil {
-		return errors.New("email already exists")
+	newUser, err := u.newUser(ctx, req.Username, req.Email, req.Password, constants.RoleUser)
+	if err != nil {
+		return err
+	}
+
+	if err := u.createUser(ctx, newUser); err != nil {
+		return err
+	}
+
+	// self-registration has no JWT yet, so the new account is its own actor
+	u.auditLog.Record(userActorContext(ctx, newUser), constants.AuditUserRegister, constants.AuditTargetUser, newUser.ID, nil, userAuditSnapshot(newUser))
+
+	u.sendVerification(ctx, newUser)
+	return nil
+}
+
+// sendVerification mails the verification link to a new account. The account exists either way;
+// the user can ask for a new link with /users/verify/resend.
+func (u *userUsecase) sendVerification(ctx context.Context, user *models.UserModel) {
+	if u.verifications == nil {
+		return
+	}
+	if err := u.verifications.SendVerification(ctx, user); err != nil {
+		log.Printf("⚠️ verification email for user %s failed: %v", user.ID, err)
+	}
+}
+
+// ProvisionUser creates an account with the requested role and mails it a verification link. The
+// acting admin is stored as created_by, or "system" when there is no actor in the context.
+func (u *userUsecase) ProvisionUser(ctx context.Context, req request.ProvisionUserRequest) (*response.UserResponse, error) {
+	if err := authz.Check(ctx, constants.PermUserManage); err != nil {
+		return nil, err
+	}
+	if !validRole(req.Role) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRole, req.Role)
+	}
+
+	newUser, err := u.newUser(ctx, req.Username, req.Email, req.Password, req.Role)
+	if err != nil {
+		return nil, err
+	}
+	newUser.CreatedBy = constants.AuditSystemActor
+	if actor, ok := audit.ActorFromContext(ctx); ok {
+		newUser.CreatedBy = actor.UserName
+	}
+
+	if err := u.createUser(ctx, newUser); err != nil {
+		return nil, err
+	}
+	u.auditLog.Record(ctx, constants.AuditUserProvision, constants.AuditTargetUser, newUser.ID, nil, userAuditSnapshot(newUser))
+
+	// provisioned accounts verify their email like registered ones, or they could not book
+	u.sendVerification(ctx, newUser)
+	return mapUserToResponse(newUser), nil
+}
+
+// ChangeUserRole sets the role of an existing account; the last ADMIN cannot be demoted
+func (u *userUsecase) ChangeUserRole(ctx context.Context, req request.ChangeUserRoleRequest) (*response.UserResponse, error) {
+	if err := authz.Check(ctx, constants.PermUserManage); err != nil {
+		return nil, err
+	}
+	if !validRole(req.Role) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRole, req.Role)
+	}
+
+	user, err := u.userRepo.FindUserByID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if user.Role == req.Role {
+		return mapUserToResponse(user), nil
+	}
+	if user.Role == constants.RoleAdmin {
+		admins, err := u.userRepo.FindUsersByRole(ctx, constants.RoleAdmin)
+		if err != nil {
+			return nil, err
+		}
+		if len(admins) <= 1 {
+			return nil, ErrLastAdmin
+		}
+	}
+
+	before := userAuditSnapshot(user)
+	user.Role = req.Role
+	user.UpdatedAt = time.Now()
+	if err := u.userRepo.UpdateUserRole(ctx, user.ID, user.Role, user.UpdatedAt); err != nil {
+		return nil, err
+	}
+	u.auditLog.Record(ctx, constants.AuditUserRoleChange, constants.AuditTargetUser, user.ID, before, userAuditSnapshot(user))
+	return mapUserToResponse(user), nil
+}
+
+// GetAdminAccounts lists every ADMIN, oldest first. Accounts without created_by were not
+// provisioned: they self-registered as ADMIN before registration was restricted to USER.
+func (u *userUsecase) GetAdminAccounts(ctx context.Context) ([]response.UserResponse, error) {
+	if err := authz.Check(ctx, constants.PermUserManage); err != nil {
+		return nil, err
+	}
+	admins, err := u.userRepo.FindUsersByRole(ctx, constants.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]response.UserResponse, 0, len(admins))
+	for i := range admins {
+		result = append(result, *mapUserToResponse(&admins[i]))
 	}
+	return result, nil
+}
 
-	hashedPassword, err := utils.EncryptPassword(req.Password)
+// UnlockUser lifts a login lockout of the account before it expires
+func (u *userUsecase) UnlockUser(ctx context.Context, userID string) error {
+	if err := authz.Check(ctx, constants.PermUserManage); err != nil {
+		return err
+	}
+	user, err := u.userRepo.FindUserByID(ctx, userID)
 	if err != nil {
 		return err
 	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if u.loginGuard != nil {
+		if err := u.loginGuard.UnlockAccount(ctx, user.ID); err != nil {
+			return err
+		}
+	}
+	u.auditLog.Record(ctx, constants.AuditUserUnlock, constants.AuditTargetUser, user.ID, nil, nil)
+	return nil
+}
 
-	newUser := &models.UserModel{
+// newUser checks the email and username are free and hashes the password; the caller stores
+// the user with createUser
+func (u *userUsecase) newUser(ctx context.Context, username string, email string, password string, role string) (*models.UserModel, error) {
+	existingUser, _ := u.userRepo.FindByUsernameOrEmail(ctx, email)
+	if existingUser != nil {
+		return nil, ErrEmailAlreadyExists
+	}
+	existingUsername, err := u.userRepo.FindUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if existingUsername != nil {
+		return nil, ErrUsernameAlreadyExists
+	}
+
+	hashedPassword, err := utils.EncryptPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &models.UserModel{
 		ID:        primitive.NewObjectID().Hex(),
-		Username:  req.Username,
-		Email:     req.Email,
+		Username:  username,
+		Email:     email,
 		Password:  hashedPassword,
-		Role:      req.Role,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Role:      role,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// createUser stores the user; the unique indexes catch a username or email taken since newUser checked
+func (u *userUsecase) createUser(ctx context.Context, user *models.UserModel) error {
+	err := u.userRepo.CreateUser(ctx, user)
+	switch {
+	case errors.Is(err, repository.ErrDuplicateEmail):
+		return ErrEmailAlreadyExists
+	case errors.Is(err, repository.ErrDuplicateUsername):
+		return ErrUsernameAlreadyExists
 	}
+	return err
+}
+
+func validRole(role string) bool {
+	return role == constants.RoleAdmin || role == constants.RoleUser
+}
 
-	return u.userRepo.CreateUser(ctx, newUser)
+func mapUserToResponse(user *models.UserModel) *response.UserResponse {
+	return &response.UserResponse{
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Role:          user.Role,
+		CreatedBy:     user.CreatedBy,
+		EmailVerified: user.EmailVerified,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
 }
 
+// userAuditSnapshot leaves the password hash out of the audit log
+func userAuditSnapshot(user *models.UserModel) interface{} {
+	return map[string]interface{}{
+		"username": user.Username,
+		"email":    user.Email,
+		"role":     user.Role,
+	}
+}
+
+// LoginUser refuses attempts while the account or the client IP is throttled, even with the
+// right password; see NewLoginProtectionUsecase
 func (u *userUsecase) LoginUser(ctx context.Context, req request.LoginRequest) (*response.LoginResponse, error) {
 	user, err := u.userRepo.FindByUsernameOrEmail(ctx, req.UsernameOrEmail)
-	if err != nil || user == nil {
-		return nil, errors.New("invalid email or password")
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		user, err = nil, nil
 	}
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	accountKey := loginAccountKey(user, req.UsernameOrEmail)
+	if u.loginGuard != nil {
+		if err := u.loginGuard.CheckLogin(ctx, accountKey, req.ClientIP); err != nil {
+			return nil, err
+		}
+	}
+
+	// unknown accounts pay for a bcrypt compare too, so the response time does not tell them apart
+	passwordHash := dummyPasswordHash()
+	if user != nil {
+		passwordHash = user.Password
+	}
+	if !utils.ComparePassword(req.Password, passwordHash) || user == nil {
+		if u.loginGuard != nil {
+			if err := u.loginGuard.RecordLoginFailure(ctx, accountKey, req.ClientIP); err != nil {
+				log.Printf("⚠️ recording failed login for %s failed: %v", accountKey, err)
+			}
+		}
+		return nil, ErrInvalidCredentials
+	}
+
+	// with a second factor the failed logins are cleared only once its code is verified
+	if u.twoFactor != nil {
+		challenge, err := u.twoFactor.LoginChallenge(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if challenge != nil {
+			return challenge, nil
+		}
+	}
+
+	if u.loginGuard != nil {
+		if err := u.loginGuard.RecordLoginSuccess(ctx, accountKey); err != nil {
+			log.Printf("⚠️ clearing failed logins for %s failed: %v", accountKey, err)
+		}
+	}
+
+	return issueLoginTokens(ctx, u.sessions, user)
+}
 
-	if !utils.ComparePassword(req.Password, user.Password) {
-		return nil, errors.New("invalid email or password it wrong")
+// issueLoginTokens starts a session, or without a session usecase signs a plain 24-hour access token
+func issueLoginTokens(ctx context.Context, sessions SessionUsecase, user *models.UserModel) (*response.LoginResponse, error) {
+	if sessions != nil {
+		return sessions.CreateSession(ctx, user)
 	}
 
 	// Create JWT Token
@@ -2,17 +2,24 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"Ev-Charge-Hub/Server/internal/audit"
+	"Ev-Charge-Hub/Server/internal/authz"
+	"Ev-Charge-Hub/Server/internal/constants"
 	"Ev-Charge-Hub/Server/internal/domain/models"
 	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
 	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/repository"
 	"Ev-Charge-Hub/Server/internal/usecase"
 	"Ev-Charge-Hub/Server/utils"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func TestRegisterUser_Success(t *testing.T) {
@@ -20,20 +27,23 @@ func TestRegisterUser_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
-	uc := usecase.NewUserUsecase(mockRepo)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
 
 	ctx := context.TODO()
 	req := request.RegisterUserRequest{
 		Username: "test",
 		Email:    "test@example.com",
 		Password: "password123",
-		Role:     "USER",
 	}
 
 	mockRepo.EXPECT().
 		FindByUsernameOrEmail(ctx, req.Email).
 		Return(nil, nil)
 
+	mockRepo.EXPECT().
+		FindUserByUsername(ctx, req.Username).
+		Return(nil, nil)
+
 	mockRepo.EXPECT().
 		CreateUser(ctx, gomock.Any()).
 		Return(nil)
@@ -45,19 +55,65 @@ func TestRegisterUser_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRegisterUser_SendsVerificationEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockVerifications := mocks.NewMockEmailVerificationUsecase(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, mockVerifications, nil, nil)
+
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "test@example.com").Return(nil, nil)
+	mockRepo.EXPECT().FindUserByUsername(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil)
+	mockVerifications.EXPECT().
+		SendVerification(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, user *models.UserModel) {
+			assert.Equal(t, "test@example.com", user.Email)
+			assert.False(t, user.EmailVerified)
+		}).
+		Return(errors.New("smtp down"))
+
+	err := uc.RegisterUser(context.TODO(), request.RegisterUserRequest{Username: "test", Email: "test@example.com", Password: "password123"})
+
+	assert.NoError(t, err)
+}
+
+func TestRegisterUser_RecordsAuditAsNewUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, mockAudit, nil, nil, nil, nil)
+
+	req := request.RegisterUserRequest{Username: "test", Email: "test@example.com", Password: "password123"}
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), req.Email).Return(nil, nil)
+	mockRepo.EXPECT().FindUserByUsername(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil)
+	mockAudit.EXPECT().
+		Record(gomock.Any(), constants.AuditUserRegister, constants.AuditTargetUser, gomock.Any(), nil, gomock.Any()).
+		Do(func(ctx context.Context, _ constants.AuditAction, _ string, targetID string, _ interface{}, after interface{}) {
+			actor, ok := audit.ActorFromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, targetID, actor.UserID)
+			assert.Equal(t, "test", actor.UserName)
+			assert.NotContains(t, after, "password")
+		})
+
+	assert.NoError(t, uc.RegisterUser(context.TODO(), req))
+}
+
 func TestRegisterUser_EmailAlreadyExists(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
-	uc := usecase.NewUserUsecase(mockRepo)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
 
 	ctx := context.TODO()
 	req := request.RegisterUserRequest{
 		Username: "test",
 		Email:    "existing@example.com",
 		Password: "password123",
-		Role:     "USER",
 	}
 
 	mockRepo.EXPECT().
@@ -76,7 +132,7 @@ func TestLoginUser_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
-	uc := usecase.NewUserUsecase(mockRepo)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
 
 	ctx := context.TODO()
 	plainPassword := "password123"
@@ -115,7 +171,7 @@ func TestLoginUser_InvalidPassword(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
-	uc := usecase.NewUserUsecase(mockRepo)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
 
 	ctx := context.TODO()
 
@@ -141,5 +197,309 @@ func TestLoginUser_InvalidPassword(t *testing.T) {
 
 	// Assert
 	assert.Nil(t, resp)
-	assert.EqualError(t, err, "invalid email or password it wrong")
+	assert.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+}
+
+func TestRegisterUser_AlwaysCreatesUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
+
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "mallory@example.com").Return(nil, nil)
+	mockRepo.EXPECT().FindUserByUsername(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().
+		CreateUser(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, user *models.UserModel) {
+			assert.Equal(t, constants.RoleUser, user.Role)
+			assert.Empty(t, user.CreatedBy)
+		}).
+		Return(nil)
+
+	err := uc.RegisterUser(context.TODO(), request.RegisterUserRequest{Username: "mallory", Email: "mallory@example.com", Password: "password123"})
+	assert.NoError(t, err)
+}
+
+func TestProvisionUser_AdminCreatesAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, mockAudit, nil, nil, nil, nil)
+
+	ctx := audit.WithActor(context.TODO(), audit.Actor{UserID: "a1", UserName: "root", Role: constants.RoleAdmin})
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "ops@example.com").Return(nil, nil)
+	mockRepo.EXPECT().FindUserByUsername(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil)
+	mockAudit.EXPECT().
+		Record(gomock.Any(), constants.AuditUserProvision, constants.AuditTargetUser, gomock.Any(), nil, gomock.Any()).
+		Do(func(_ context.Context, _ constants.AuditAction, _ string, _ string, _ interface{}, after interface{}) {
+			assert.Equal(t, constants.RoleAdmin, after.(map[string]interface{})["role"])
+		})
+
+	user, err := uc.ProvisionUser(ctx, request.ProvisionUserRequest{Username: "ops1", Email: "ops@example.com", Password: "password123", Role: constants.RoleAdmin})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.RoleAdmin, user.Role)
+	assert.Equal(t, "root", user.CreatedBy)
+}
+
+func TestProvisionUser_SendsVerificationEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockVerifications := mocks.NewMockEmailVerificationUsecase(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, mockVerifications, nil, nil)
+
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "bob@example.com").Return(nil, nil)
+	mockRepo.EXPECT().FindUserByUsername(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil)
+	mockVerifications.EXPECT().
+		SendVerification(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, user *models.UserModel) {
+			assert.Equal(t, "bob@example.com", user.Email)
+		}).
+		Return(errors.New("smtp down"))
+
+	user, err := uc.ProvisionUser(adminContext(), request.ProvisionUserRequest{Username: "bob", Email: "bob@example.com", Password: "password123", Role: constants.RoleUser})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.RoleUser, user.Role)
+}
+
+func TestProvisionUser_WithoutActorIsForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
+
+	_, err := uc.ProvisionUser(context.TODO(), request.ProvisionUserRequest{Username: "ops1", Email: "ops@example.com", Password: "password123", Role: constants.RoleAdmin})
+	assert.ErrorIs(t, err, authz.ErrNoActor)
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+}
+
+func TestProvisionUser_SystemActorIsCreatedBySystem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
+
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "ops@example.com").Return(nil, nil)
+	mockRepo.EXPECT().FindUserByUsername(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil)
+
+	ctx := audit.WithActor(context.TODO(), audit.SystemActor)
+	user, err := uc.ProvisionUser(ctx, request.ProvisionUserRequest{Username: "ops1", Email: "ops@example.com", Password: "password123", Role: constants.RoleAdmin})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.AuditSystemActor, user.CreatedBy)
+}
+
+func TestProvisionUser_UserActorIsForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
+
+	ctx := audit.WithActor(context.TODO(), audit.Actor{UserID: "u1", UserName: "alice", Role: constants.RoleUser})
+	_, err := uc.ProvisionUser(ctx, request.ProvisionUserRequest{Username: "alice2", Email: "a@example.com", Password: "password123", Role: constants.RoleAdmin})
+	assert.ErrorIs(t, err, authz.ErrForbidden)
+}
+
+func TestChangeUserRole_RecordsBeforeAndAfter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, mockAudit, nil, nil, nil, nil)
+
+	mockRepo.EXPECT().FindUserByID(gomock.Any(), "u1").Return(&models.UserModel{ID: "u1", Username: "alice", Role: constants.RoleUser}, nil)
+	mockRepo.EXPECT().UpdateUserRole(gomock.Any(), "u1", constants.RoleAdmin, gomock.Any()).Return(nil)
+	mockAudit.EXPECT().
+		Record(gomock.Any(), constants.AuditUserRoleChange, constants.AuditTargetUser, "u1", gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, _ constants.AuditAction, _ string, _ string, before interface{}, after interface{}) {
+			assert.Equal(t, constants.RoleUser, before.(map[string]interface{})["role"])
+			assert.Equal(t, constants.RoleAdmin, after.(map[string]interface{})["role"])
+		})
+
+	user, err := uc.ChangeUserRole(adminContext(), request.ChangeUserRoleRequest{UserID: "u1", Role: constants.RoleAdmin})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.RoleAdmin, user.Role)
+}
+
+func TestChangeUserRole_CannotDemoteLastAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
+
+	admin := models.UserModel{ID: "a1", Username: "root", Role: constants.RoleAdmin}
+	mockRepo.EXPECT().FindUserByID(gomock.Any(), "a1").Return(&admin, nil)
+	mockRepo.EXPECT().FindUsersByRole(gomock.Any(), constants.RoleAdmin).Return([]models.UserModel{admin}, nil)
+
+	_, err := uc.ChangeUserRole(adminContext(), request.ChangeUserRoleRequest{UserID: "a1", Role: constants.RoleUser})
+	assert.ErrorIs(t, err, usecase.ErrLastAdmin)
+}
+
+func TestChangeUserRole_UnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
+
+	mockRepo.EXPECT().FindUserByID(gomock.Any(), "missing").Return(nil, nil)
+
+	_, err := uc.ChangeUserRole(adminContext(), request.ChangeUserRoleRequest{UserID: "missing", Role: constants.RoleAdmin})
+	assert.ErrorIs(t, err, usecase.ErrUserNotFound)
+}
+
+func TestLoginUser_StartsSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockSessions := mocks.NewMockSessionUsecase(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, mockSessions, nil, nil, nil)
+
+	hashedPassword, _ := utils.EncryptPassword("password123")
+	user := &models.UserModel{ID: "u1", Username: "alice", Email: "alice@example.com", Password: hashedPassword, Role: constants.RoleUser}
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "alice").Return(user, nil)
+	mockSessions.EXPECT().CreateSession(gomock.Any(), user).Return(&response.LoginResponse{Token: "access", RefreshToken: "refresh"}, nil)
+
+	resp, err := uc.LoginUser(context.TODO(), request.LoginRequest{UsernameOrEmail: "alice", Password: "password123"})
+	assert.NoError(t, err)
+	assert.Equal(t, "refresh", resp.RefreshToken)
+}
+
+func TestRegisterUser_UsernameTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
+
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "new@example.com").Return(nil, nil)
+	mockRepo.EXPECT().FindUserByUsername(gomock.Any(), "Alice").Return(&models.UserModel{ID: "u1", Username: "alice"}, nil)
+
+	err := uc.RegisterUser(context.TODO(), request.RegisterUserRequest{Username: "Alice", Email: "new@example.com", Password: "password123"})
+
+	assert.ErrorIs(t, err, usecase.ErrUsernameAlreadyExists)
+}
+
+func TestRegisterUser_DuplicateKeyFromConcurrentRegistration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
+
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().FindUserByUsername(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(repository.ErrDuplicateUsername)
+	mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(repository.ErrDuplicateEmail)
+
+	err := uc.RegisterUser(context.TODO(), request.RegisterUserRequest{Username: "alice", Email: "alice@example.com", Password: "password123"})
+	assert.ErrorIs(t, err, usecase.ErrUsernameAlreadyExists)
+
+	err = uc.RegisterUser(context.TODO(), request.RegisterUserRequest{Username: "alice", Email: "alice@example.com", Password: "password123"})
+	assert.ErrorIs(t, err, usecase.ErrEmailAlreadyExists)
+}
+
+func TestLoginUser_ThrottledRefusesCorrectPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockGuard := mocks.NewMockLoginProtectionUsecase(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, mockGuard, nil)
+
+	hashedPassword, _ := utils.EncryptPassword("password123")
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "alice").Return(&models.UserModel{ID: "u1", Password: hashedPassword}, nil)
+	mockGuard.EXPECT().CheckLogin(gomock.Any(), "user:u1", "198.51.100.7").Return(&usecase.LoginThrottledError{RetryAfter: time.Minute, Locked: true})
+
+	_, err := uc.LoginUser(context.TODO(), request.LoginRequest{UsernameOrEmail: "alice", Password: "password123", ClientIP: "198.51.100.7"})
+	assert.ErrorIs(t, err, usecase.ErrTooManyLoginAttempts)
+}
+
+func TestLoginUser_RecordsFailuresAndClearsOnSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockGuard := mocks.NewMockLoginProtectionUsecase(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, mockGuard, nil)
+
+	hashedPassword, _ := utils.EncryptPassword("password123")
+	user := &models.UserModel{ID: "u1", Username: "alice", Password: hashedPassword, Role: constants.RoleUser}
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "alice").Return(user, nil).Times(2)
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "Nobody@Example.com").Return(nil, mongo.ErrNoDocuments)
+	mockGuard.EXPECT().CheckLogin(gomock.Any(), gomock.Any(), "198.51.100.7").Return(nil).Times(3)
+	mockGuard.EXPECT().RecordLoginFailure(gomock.Any(), "user:u1", "198.51.100.7").Return(nil)
+	mockGuard.EXPECT().RecordLoginFailure(gomock.Any(), "account:nobody@example.com", "198.51.100.7").Return(nil)
+	mockGuard.EXPECT().RecordLoginSuccess(gomock.Any(), "user:u1").Return(nil)
+
+	_, err := uc.LoginUser(context.TODO(), request.LoginRequest{UsernameOrEmail: "alice", Password: "wrong", ClientIP: "198.51.100.7"})
+	assert.Error(t, err)
+	_, err = uc.LoginUser(context.TODO(), request.LoginRequest{UsernameOrEmail: "Nobody@Example.com", Password: "wrong", ClientIP: "198.51.100.7"})
+	assert.Error(t, err)
+	resp, err := uc.LoginUser(context.TODO(), request.LoginRequest{UsernameOrEmail: "alice", Password: "password123", ClientIP: "198.51.100.7"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Token)
+}
+
+func TestUnlockUser_ClearsLockoutAndAudits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockAudit := mocks.NewMockAuditRecorder(ctrl)
+	mockGuard := mocks.NewMockLoginProtectionUsecase(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, mockAudit, nil, nil, mockGuard, nil)
+
+	ctx := audit.WithActor(context.TODO(), audit.Actor{UserID: "a1", UserName: "root", Role: constants.RoleAdmin})
+	mockRepo.EXPECT().FindUserByID(gomock.Any(), "u1").Return(&models.UserModel{ID: "u1"}, nil)
+	mockRepo.EXPECT().FindUserByID(gomock.Any(), "missing").Return(nil, nil)
+	mockGuard.EXPECT().UnlockAccount(gomock.Any(), "u1").Return(nil)
+	mockAudit.EXPECT().Record(gomock.Any(), constants.AuditUserUnlock, constants.AuditTargetUser, "u1", nil, nil)
+
+	assert.NoError(t, uc.UnlockUser(ctx, "u1"))
+	assert.ErrorIs(t, uc.UnlockUser(ctx, "missing"), usecase.ErrUserNotFound)
+}
+
+func TestUnlockUser_UserActorIsForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uc := usecase.NewUserUsecase(mocks.NewMockUserRepositoryInterface(ctrl), nil, nil, nil, mocks.NewMockLoginProtectionUsecase(ctrl), nil)
+
+	ctx := audit.WithActor(context.TODO(), audit.Actor{UserID: "u2", UserName: "bob", Role: constants.RoleUser})
+	assert.ErrorIs(t, uc.UnlockUser(ctx, "u1"), authz.ErrForbidden)
+}
+
+func TestLoginUser_ReturnsTwoFactorChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockSessions := mocks.NewMockSessionUsecase(ctrl)
+	mockGuard := mocks.NewMockLoginProtectionUsecase(ctrl)
+	mockTwoFactor := mocks.NewMockTwoFactorUsecase(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, mockSessions, nil, mockGuard, mockTwoFactor)
+
+	hashedPassword, _ := utils.EncryptPassword("password123")
+	user := &models.UserModel{ID: "a1", Username: "root", Password: hashedPassword, Role: constants.RoleAdmin, TOTPEnabled: true}
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "root").Return(user, nil)
+	mockGuard.EXPECT().CheckLogin(gomock.Any(), "user:a1", "").Return(nil)
+	mockTwoFactor.EXPECT().LoginChallenge(gomock.Any(), user).Return(&response.LoginResponse{TwoFactorRequired: true, ChallengeToken: "challenge"}, nil)
+
+	resp, err := uc.LoginUser(context.TODO(), request.LoginRequest{UsernameOrEmail: "root", Password: "password123"})
+	assert.NoError(t, err)
+	assert.True(t, resp.TwoFactorRequired)
+	assert.Empty(t, resp.Token)
+}
+
+func TestLoginUser_SameErrorForUnknownAccountAndWrongPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	uc := usecase.NewUserUsecase(mockRepo, nil, nil, nil, nil, nil)
+
+	hashedPassword, _ := utils.EncryptPassword("password123")
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "alice").Return(&models.UserModel{ID: "u1", Password: hashedPassword}, nil)
+	mockRepo.EXPECT().FindByUsernameOrEmail(gomock.Any(), "nobody").Return(nil, mongo.ErrNoDocuments)
+
+	_, wrongPassword := uc.LoginUser(context.TODO(), request.LoginRequest{UsernameOrEmail: "alice", Password: "wrong"})
+	_, unknownAccount := uc.LoginUser(context.TODO(), request.LoginRequest{UsernameOrEmail: "nobody", Password: "wrong"})
+
+	assert.ErrorIs(t, wrongPassword, usecase.ErrInvalidCredentials)
+	assert.Equal(t, wrongPassword, unknownAccount)
 }
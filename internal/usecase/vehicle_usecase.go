@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	domainModel "Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//go:generate mockgen -source=vehicle_usecase.go -destination=../mocks/mock_vehicle_usecase.go -package=mocks
+type VehicleUsecase interface {
+	GetVehicleCatalog(ctx context.Context) []response.VehicleCatalogResponse
+	CreateVehicle(ctx context.Context, req request.VehicleRequest) (*response.VehicleResponse, error)
+	GetVehicles(ctx context.Context, req request.GetVehiclesRequest) ([]response.VehicleResponse, error)
+	GetVehicleByID(ctx context.Context, req request.GetVehicleByIDRequest) (*response.VehicleResponse, error)
+	EditVehicle(ctx context.Context, req request.VehicleRequest) (*response.VehicleResponse, error)
+	RemoveVehicle(ctx context.Context, req request.RemoveVehicleRequest) error
+}
+
+// ErrVehicleNotFound is returned for unknown vehicles and vehicles of other users
+var ErrVehicleNotFound = errors.New("vehicle not found")
+
+type vehicleUsecase struct {
+	vehicleRepo repository.VehicleRepository
+}
+
+func NewVehicleUsecase(repo repository.VehicleRepository) VehicleUsecase {
+	return &vehicleUsecase{vehicleRepo: repo}
+}
+
+func (u *vehicleUsecase) GetVehicleCatalog(ctx context.Context) []response.VehicleCatalogResponse {
+	catalog := make([]response.VehicleCatalogResponse, 0, len(constants.VehicleCatalog))
+	for _, entry := range constants.VehicleCatalog {
+		catalog = append(catalog, response.VehicleCatalogResponse{
+			CatalogID:      entry.ID,
+			Make:           entry.Make,
+			Model:          entry.Model,
+			BatteryKWh:     entry.BatteryKWh,
+			SupportedPlugs: entry.SupportedPlugs,
+			MaxACPowerKW:   entry.MaxACPowerKW,
+			MaxDCPowerKW:   entry.MaxDCPowerKW,
+		})
+	}
+	return catalog
+}
+
+func (u *vehicleUsecase) CreateVehicle(ctx context.Context, req request.VehicleRequest) (*response.VehicleResponse, error) {
+	vehicle, err := buildVehicleFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle.ID = primitive.NewObjectID().Hex()
+	vehicle.UserID = req.UserID
+	vehicle.CreatedAt = time.Now()
+	vehicle.UpdatedAt = vehicle.CreatedAt
+
+	if err := u.vehicleRepo.CreateVehicle(ctx, &vehicle); err != nil {
+		return nil, err
+	}
+
+	resp := mapVehicleToResponse(vehicle)
+	return &resp, nil
+}
+
+func (u *vehicleUsecase) GetVehicles(ctx context.Context, req request.GetVehiclesRequest) ([]response.VehicleResponse, error) {
+	vehicles, err := u.vehicleRepo.FindVehiclesByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.VehicleResponse, 0, len(vehicles))
+	for _, v := range vehicles {
+		result = append(result, mapVehicleToResponse(v))
+	}
+	return result, nil
+}
+
+func (u *vehicleUsecase) GetVehicleByID(ctx context.Context, req request.GetVehicleByIDRequest) (*response.VehicleResponse, error) {
+	vehicle, err := findOwnedVehicle(ctx, u.vehicleRepo, req.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := mapVehicleToResponse(*vehicle)
+	return &resp, nil
+}
+
+func (u *vehicleUsecase) EditVehicle(ctx context.Context, req request.VehicleRequest) (*response.VehicleResponse, error) {
+	existing, err := findOwnedVehicle(ctx, u.vehicleRepo, req.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle, err := buildVehicleFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle.ID = existing.ID
+	vehicle.UserID = existing.UserID
+	vehicle.CreatedAt = existing.CreatedAt
+	vehicle.UpdatedAt = time.Now()
+
+	if err := u.vehicleRepo.EditVehicle(ctx, &vehicle); err != nil {
+		return nil, err
+	}
+
+	resp := mapVehicleToResponse(vehicle)
+	return &resp, nil
+}
+
+func (u *vehicleUsecase) RemoveVehicle(ctx context.Context, req request.RemoveVehicleRequest) error {
+	if _, err := findOwnedVehicle(ctx, u.vehicleRepo, req.ID, req.UserID); err != nil {
+		return err
+	}
+	return u.vehicleRepo.RemoveVehicle(ctx, req.ID)
+}
+
+// findOwnedVehicle loads a vehicle and hides it from anyone but its owner
+func findOwnedVehicle(ctx context.Context, repo repository.VehicleRepository, id string, userID string) (*domainModel.Vehicle, error) {
+	vehicle, err := repo.FindVehicleByID(ctx, id)
+	if errors.Is(err, repository.ErrVehicleNotFound) {
+		return nil, ErrVehicleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if vehicle.UserID != userID {
+		return nil, ErrVehicleNotFound
+	}
+	return vehicle, nil
+}
+
+// buildVehicleFromRequest merges the request over its catalog entry (if any) and validates the result
+func buildVehicleFromRequest(req request.VehicleRequest) (domainModel.Vehicle, error) {
+	vehicle := domainModel.Vehicle{
+		CatalogID:      req.CatalogID,
+		Make:           req.Make,
+		Model:          req.Model,
+		BatteryKWh:     req.BatteryKWh,
+		SupportedPlugs: req.SupportedPlugs,
+		MaxACPowerKW:   req.MaxACPowerKW,
+		MaxDCPowerKW:   req.MaxDCPowerKW,
+	}
+
+	if req.CatalogID != "" {
+		entry, ok := constants.FindVehicleCatalogEntry(req.CatalogID)
+		if !ok {
+			return vehicle, fmt.Errorf("unknown catalog_id: %s", req.CatalogID)
+		}
+		if vehicle.Make == "" {
+			vehicle.Make = entry.Make
+		}
+		if vehicle.Model == "" {
+			vehicle.Model = entry.Model
+		}
+		if vehicle.BatteryKWh == 0 {
+			vehicle.BatteryKWh = entry.BatteryKWh
+		}
+		if len(vehicle.SupportedPlugs) == 0 {
+			vehicle.SupportedPlugs = entry.SupportedPlugs
+		}
+		if vehicle.MaxACPowerKW == 0 {
+			vehicle.MaxACPowerKW = entry.MaxACPowerKW
+		}
+		if vehicle.MaxDCPowerKW == 0 {
+			vehicle.MaxDCPowerKW = entry.MaxDCPowerKW
+		}
+	}
+
+	if vehicle.Make == "" || vehicle.Model == "" {
+		return vehicle, errors.New("make and model are required")
+	}
+	if vehicle.BatteryKWh <= 0 {
+		return vehicle, errors.New("battery_kwh must be greater than 0")
+	}
+	if len(vehicle.SupportedPlugs) == 0 {
+		return vehicle, errors.New("supported_plugs must not be empty")
+	}
+	if vehicle.MaxACPowerKW <= 0 && vehicle.MaxDCPowerKW <= 0 {
+		return vehicle, errors.New("max_ac_power_kw or max_dc_power_kw must be greater than 0")
+	}
+	return vehicle, nil
+}
+
+func mapVehicleToResponse(v domainModel.Vehicle) response.VehicleResponse {
+	return response.VehicleResponse{
+		ID:             v.ID,
+		CatalogID:      v.CatalogID,
+		Make:           v.Make,
+		Model:          v.Model,
+		BatteryKWh:     v.BatteryKWh,
+		SupportedPlugs: v.SupportedPlugs,
+		MaxACPowerKW:   v.MaxACPowerKW,
+		MaxDCPowerKW:   v.MaxDCPowerKW,
+	}
+}
@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/request"
+	"Ev-Charge-Hub/Server/internal/mocks"
+	"Ev-Charge-Hub/Server/internal/usecase"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateVehicle_FromCatalog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockVehicleRepository(ctrl)
+	uc := usecase.NewVehicleUsecase(mockRepo)
+
+	mockRepo.EXPECT().
+		CreateVehicle(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, v *models.Vehicle) error {
+			assert.Equal(t, "user1", v.UserID)
+			assert.Equal(t, "Nissan", v.Make)
+			assert.Equal(t, 50.0, v.MaxDCPowerKW)
+			return nil
+		})
+
+	resp, err := uc.CreateVehicle(context.TODO(), request.VehicleRequest{
+		UserID:    "user1",
+		CatalogID: "nissan-leaf-40",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []constants.PlugName{constants.J1772, constants.CHAdeMO}, resp.SupportedPlugs)
+}
+
+func TestCreateVehicle_UnknownCatalogID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockVehicleRepository(ctrl)
+	uc := usecase.NewVehicleUsecase(mockRepo)
+
+	resp, err := uc.CreateVehicle(context.TODO(), request.VehicleRequest{UserID: "user1", CatalogID: "no-such-car"})
+
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "unknown catalog_id: no-such-car")
+}
+
+func TestCreateVehicle_MissingFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockVehicleRepository(ctrl)
+	uc := usecase.NewVehicleUsecase(mockRepo)
+
+	_, err := uc.CreateVehicle(context.TODO(), request.VehicleRequest{
+		UserID:     "user1",
+		Make:       "Custom",
+		Model:      "EV",
+		BatteryKWh: 40,
+	})
+
+	assert.EqualError(t, err, "supported_plugs must not be empty")
+}
+
+func TestGetVehicleByID_OtherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockVehicleRepository(ctrl)
+	uc := usecase.NewVehicleUsecase(mockRepo)
+
+	mockRepo.EXPECT().
+		FindVehicleByID(gomock.Any(), "v1").
+		Return(&models.Vehicle{ID: "v1", UserID: "owner"}, nil)
+
+	resp, err := uc.GetVehicleByID(context.TODO(), request.GetVehicleByIDRequest{ID: "v1", UserID: "intruder"})
+
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, usecase.ErrVehicleNotFound)
+}
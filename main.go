@@ -3,9 +3,16 @@ package main
 import (
 	"Ev-Charge-Hub/Server/configs"
 	"Ev-Charge-Hub/Server/internal/delivery/http"
+	"Ev-Charge-Hub/Server/internal/jobs"
+	"Ev-Charge-Hub/Server/internal/mail"
+	"Ev-Charge-Hub/Server/internal/notification"
+	"Ev-Charge-Hub/Server/internal/ocpi"
 	"Ev-Charge-Hub/Server/internal/repository"
 	"Ev-Charge-Hub/Server/internal/usecase"
+	"Ev-Charge-Hub/Server/middleware"
 	"Ev-Charge-Hub/Server/routes"
+	"Ev-Charge-Hub/Server/utils"
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -15,6 +22,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	ginprometheus "github.com/zsais/go-gin-prometheus"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func main() {
@@ -34,22 +42,61 @@ func main() {
 	// 🔧 Release mode
 	gin.SetMode(gin.ReleaseMode)
 
+	// ✅ JWT signing keys
+	setupJWTKeys()
+
 	// ✅ Connect to MongoDB
 	db := configs.ConnectDB()
 
 	// ✅ Initialize Dependencies
+	auditRepo := repository.NewAuditRepository(db)
+	auditUsecase := usecase.NewAuditUsecase(auditRepo)
+	auditHandler := http.NewAuditHandler(auditUsecase)
+
+	mailer := setupMailer()
 	userRepo := repository.NewUserRepository(db)
-	userUsecase := usecase.NewUserUsecase(userRepo)
+	ensureUserIndexes(userRepo)
+	sessionUsecase := usecase.NewSessionUsecase(repository.NewRefreshTokenRepository(db), userRepo, auditUsecase, configs.LoadSessionConfig())
+	sessionHandler := http.NewSessionHandler(sessionUsecase)
+	verificationConfig := configs.LoadEmailVerificationConfig()
+	verificationUsecase := usecase.NewEmailVerificationUsecase(userRepo, mailer, auditUsecase, verificationConfig)
+	emailVerificationHandler := http.NewEmailVerificationHandler(verificationUsecase)
+	loginProtectionUsecase := setupLoginProtection(db, auditUsecase)
+	twoFactorUsecase := usecase.NewTwoFactorUsecase(userRepo, sessionUsecase, loginProtectionUsecase, mailer, auditUsecase, configs.LoadTwoFactorConfig())
+	twoFactorHandler := http.NewTwoFactorHandler(twoFactorUsecase)
+	userUsecase := usecase.NewUserUsecase(userRepo, auditUsecase, sessionUsecase, verificationUsecase, loginProtectionUsecase, twoFactorUsecase)
 	userHandler := http.NewUserHandler(userUsecase)
+	passwordResetUsecase := usecase.NewPasswordResetUsecase(repository.NewPasswordResetRepository(db), userRepo, sessionUsecase, mailer, auditUsecase, configs.LoadPasswordResetConfig())
+	passwordResetHandler := http.NewPasswordResetHandler(passwordResetUsecase)
+
+	var bookingPolicy usecase.BookingPolicy
+	if verificationConfig.RequiredForBooking {
+		bookingPolicy = usecase.NewVerifiedEmailBookingPolicy(userRepo)
+	}
+
+	vehicleRepo := repository.NewVehicleRepository(db)
+	vehicleUsecase := usecase.NewVehicleUsecase(vehicleRepo)
+	vehicleHandler := http.NewVehicleHandler(vehicleUsecase)
 
 	stationRepo := repository.NewEVStationRepository(db)
-	stationUsecase := usecase.NewEVStationUsecase(stationRepo)
+	stationUsecase := usecase.NewEVStationUsecase(stationRepo, vehicleRepo, auditUsecase, bookingPolicy)
 	stationHandler := http.NewEVStationHandler(stationUsecase)
 
+	trashConfig := configs.LoadStationTrashConfig()
+	jobs.StartStationPurgeJob(context.Background(), stationUsecase, trashConfig.Retention, trashConfig.PurgeInterval)
+
+	maintenanceUsecase := usecase.NewMaintenanceUsecase(stationRepo, notification.NewLogNotifier(), auditUsecase)
+	maintenanceHandler := http.NewMaintenanceHandler(maintenanceUsecase)
+
+	ocpiPartnerRepo := repository.NewOCPIPartnerRepository(db)
+	ocpiUsecase := usecase.NewOCPIUsecase(ocpiPartnerRepo, stationRepo, ocpi.NewHTTPClient(nil), configs.LoadOCPIConfig())
+	ocpiHandler := http.NewOCPIHandler(ocpiUsecase)
+
 	// ✅ Set up Router
 	router := gin.New()                    // ❌ No default logger
 	router.Use(gin.Recovery())             // ✅ Add panic recovery
 	router.Use(requestPerformanceLogger()) // ✅ Log API duration
+	router.Use(middleware.RequestID())     // ✅ X-Request-ID for tracing and the audit log
 
 	// ✅ Prometheus /metrics
 	p := ginprometheus.NewPrometheus("ev_station")
@@ -58,9 +105,9 @@ func main() {
 	// ✅ CORS (can adjust for production)
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type", "If-Match", middleware.RequestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", "ETag", "Link", "X-Total-Count", "X-Limit", middleware.RequestIDHeader},
 		AllowCredentials: true,
 	}))
 
@@ -70,7 +117,7 @@ func main() {
 	}
 
 	// ✅ Register Routes
-	routes.SetupRoutes(router, userHandler, stationHandler)
+	routes.SetupRoutes(router, userHandler, sessionHandler, passwordResetHandler, emailVerificationHandler, twoFactorHandler, stationHandler, vehicleHandler, maintenanceHandler, auditHandler, ocpiHandler, ocpiUsecase, sessionUsecase)
 	printRegisteredRoutes(router)
 
 	fmt.Printf("🚀 Server is running on http://localhost%s\n", port)
@@ -99,6 +146,73 @@ func requestPerformanceLogger() gin.HandlerFunc {
 	}
 }
 
+// ✅ Load the asymmetric JWT keys from PEM files; without JWT_KEYS tokens are signed with JWT_SECRET
+func setupJWTKeys() {
+	jwtConfig, err := configs.LoadJWTConfig()
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+
+	keys := make([]utils.JWTKey, 0, len(jwtConfig.Keys))
+	for _, keyConfig := range jwtConfig.Keys {
+		key, err := utils.LoadJWTKey(keyConfig.ID, keyConfig.Path, keyConfig.ActivatesAt)
+		if err != nil {
+			log.Fatalf("Failed to load JWT key: %v", err)
+		}
+		keys = append(keys, key)
+	}
+
+	keySet, err := utils.NewKeySet(keys, jwtConfig.AcceptHS256)
+	if err != nil {
+		log.Fatalf("Invalid JWT keys: %v", err)
+	}
+	utils.SetKeySet(keySet)
+}
+
+// ✅ Unique usernames and emails; while duplicates exist the server starts without the indexes
+func ensureUserIndexes(userRepo repository.UserRepositoryInterface) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := userRepo.EnsureUserIndexes(ctx); err != nil {
+		log.Printf("⚠️ %v; run `go run ./cmd/dedupe-users` to resolve duplicate accounts", err)
+	}
+}
+
+// ✅ Count failed logins in memory, or in Mongo when LOGIN_ATTEMPT_STORE=mongo so every instance shares them
+func setupLoginProtection(db *mongo.Database, auditLog usecase.AuditRecorder) usecase.LoginProtectionUsecase {
+	config := configs.LoadLoginProtectionConfig()
+	var attemptRepo repository.LoginAttemptRepository
+	switch config.Store {
+	case "memory":
+		attemptRepo = repository.NewMemoryLoginAttemptRepository()
+	case "mongo":
+		attemptRepo = repository.NewLoginAttemptRepository(db)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := attemptRepo.EnsureLoginAttemptIndexes(ctx); err != nil {
+			log.Printf("⚠️ %v", err)
+		}
+	default:
+		log.Fatalf("Invalid LOGIN_ATTEMPT_STORE %q, use memory or mongo", config.Store)
+	}
+	return usecase.NewLoginProtectionUsecase(attemptRepo, auditLog, config)
+}
+
+// ✅ Send emails through SMTP when SMTP_HOST is set, otherwise write them to MAIL_DIR
+func setupMailer() mail.Mailer {
+	mailConfig := configs.LoadMailConfig()
+	if mailConfig.SMTPHost != "" {
+		return mail.NewAsyncMailer(mail.NewSMTPMailer(mailConfig.SMTPHost, mailConfig.SMTPPort, mailConfig.SMTPUsername, mailConfig.SMTPPassword, mailConfig.From))
+	}
+
+	log.Printf("⚠️ SMTP_HOST not set, emails are written to %s", mailConfig.Dir)
+	fileMailer, err := mail.NewFileMailer(mailConfig.Dir, mailConfig.From)
+	if err != nil {
+		log.Fatalf("Failed to create mail directory: %v", err)
+	}
+	return mail.NewAsyncMailer(fileMailer)
+}
+
 // ✅ Optional: Print all registered routes
 func printRegisteredRoutes(router *gin.Engine) {
 	for _, route := range router.Routes() {
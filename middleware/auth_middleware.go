@@ -1,15 +1,22 @@
 package middleware
 
 import (
+	"Ev-Charge-Hub/Server/internal/audit"
 	"Ev-Charge-Hub/Server/utils"
+	"context"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware validates the JWT token
-func AuthMiddleware() gin.HandlerFunc {
+// TokenRevocationChecker reports whether an access token was revoked by logout or refresh token reuse
+type TokenRevocationChecker interface {
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// AuthMiddleware validates the JWT token and refuses revoked token IDs; a nil checker skips the revocation check
+func AuthMiddleware(revocations TokenRevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -34,10 +41,29 @@ func AuthMiddleware() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if revocations != nil && claims.ID != "" {
+			revoked, err := revocations.IsAccessTokenRevoked(c.Request.Context(), claims.ID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to verify token"})
+				c.Abort()
+				return
+			}
+			if revoked {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+				c.Abort()
+				return
+			}
+		}
 
 		c.Set("userID", claims.UserID)
+		c.Set("tokenID", claims.ID)
 		c.Set("userName", claims.Username)
 		c.Set("role", claims.Role)
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), audit.Actor{
+			UserID:   claims.UserID,
+			UserName: claims.Username,
+			Role:     claims.Role,
+		}))
 
 		// Continue to the next handler
 		c.Next()
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"Ev-Charge-Hub/Server/internal/authz"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authorize allows the request only when the role stored by AuthMiddleware has the permission
+// that authz declares for the matched route; routes without a declared permission are refused
+func Authorize() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		permission, ok := authz.RoutePermission(c.Request.Method, c.FullPath())
+		if !ok || !authz.RoleHas(c.GetString("role"), permission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"Ev-Charge-Hub/Server/internal/constants"
+	"Ev-Charge-Hub/Server/internal/domain/models"
+	"Ev-Charge-Hub/Server/internal/dto/response"
+	"Ev-Charge-Hub/Server/internal/ocpi"
+	"Ev-Charge-Hub/Server/internal/usecase"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OCPITokenAuthenticator resolves the roaming partner a token belongs to
+type OCPITokenAuthenticator interface {
+	AuthenticateToken(ctx context.Context, token string) (*models.OCPIPartner, error)
+}
+
+// OCPITokenAuth validates "Authorization: Token <base64 token>" and stores the partner ID and the
+// token. With requireRegistered only partners that completed the credentials handshake (token C)
+// get through; otherwise the registration token (token A) is accepted as well.
+func OCPITokenAuth(authenticator OCPITokenAuthenticator, requireRegistered bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, token := range ocpi.TokensFromHeader(c.GetHeader("Authorization")) {
+			partner, err := authenticator.AuthenticateToken(c.Request.Context(), token)
+			if errors.Is(err, usecase.ErrOCPIUnknownToken) {
+				continue
+			}
+			if err != nil {
+				abortOCPI(c, http.StatusInternalServerError, constants.OCPIStatusClientError, err.Error())
+				return
+			}
+			if requireRegistered && partner.Status != constants.OCPIPartnerRegistered {
+				abortOCPI(c, http.StatusUnauthorized, constants.OCPIStatusClientError, "Complete the credentials handshake first")
+				return
+			}
+
+			c.Set("ocpiPartnerID", partner.ID)
+			c.Set("ocpiToken", token)
+			c.Next()
+			return
+		}
+
+		abortOCPI(c, http.StatusUnauthorized, constants.OCPIStatusClientError, "Invalid or missing token")
+	}
+}
+
+func abortOCPI(c *gin.Context, httpStatus int, statusCode int, message string) {
+	c.AbortWithStatusJSON(httpStatus, response.OCPIResponse{
+		StatusCode:    statusCode,
+		StatusMessage: message,
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+	})
+}
@@ -6,9 +6,11 @@ package mocks
 
 import (
 	models "Ev-Charge-Hub/Server/internal/domain/models"
+	repository "Ev-Charge-Hub/Server/internal/repository"
 	models0 "Ev-Charge-Hub/Server/internal/repository/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -36,6 +38,34 @@ func (m *MockEVStationRepository) EXPECT() *MockEVStationRepositoryMockRecorder
 	return m.recorder
 }
 
+// AddConnector mocks base method.
+func (m *MockEVStationRepository) AddConnector(ctx context.Context, stationID string, version int64, connector models.Connector) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddConnector", ctx, stationID, version, connector)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddConnector indicates an expected call of AddConnector.
+func (mr *MockEVStationRepositoryMockRecorder) AddConnector(ctx, stationID, version, connector interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddConnector", reflect.TypeOf((*MockEVStationRepository)(nil).AddConnector), ctx, stationID, version, connector)
+}
+
+// AddMaintenanceWindow mocks base method.
+func (m *MockEVStationRepository) AddMaintenanceWindow(ctx context.Context, stationID string, window models.MaintenanceWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMaintenanceWindow", ctx, stationID, window)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMaintenanceWindow indicates an expected call of AddMaintenanceWindow.
+func (mr *MockEVStationRepositoryMockRecorder) AddMaintenanceWindow(ctx, stationID, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMaintenanceWindow", reflect.TypeOf((*MockEVStationRepository)(nil).AddMaintenanceWindow), ctx, stationID, window)
+}
+
 // CreateStation mocks base method.
 func (m *MockEVStationRepository) CreateStation(ctx context.Context, domainModel models.EVStation) error {
 	m.ctrl.T.Helper()
@@ -50,6 +80,34 @@ func (mr *MockEVStationRepositoryMockRecorder) CreateStation(ctx, domainModel in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStation", reflect.TypeOf((*MockEVStationRepository)(nil).CreateStation), ctx, domainModel)
 }
 
+// EditConnector mocks base method.
+func (m *MockEVStationRepository) EditConnector(ctx context.Context, stationID string, version int64, connector models.Connector) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditConnector", ctx, stationID, version, connector)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditConnector indicates an expected call of EditConnector.
+func (mr *MockEVStationRepositoryMockRecorder) EditConnector(ctx, stationID, version, connector interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditConnector", reflect.TypeOf((*MockEVStationRepository)(nil).EditConnector), ctx, stationID, version, connector)
+}
+
+// EditMaintenanceWindow mocks base method.
+func (m *MockEVStationRepository) EditMaintenanceWindow(ctx context.Context, stationID string, window models.MaintenanceWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditMaintenanceWindow", ctx, stationID, window)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditMaintenanceWindow indicates an expected call of EditMaintenanceWindow.
+func (mr *MockEVStationRepositoryMockRecorder) EditMaintenanceWindow(ctx, stationID, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditMaintenanceWindow", reflect.TypeOf((*MockEVStationRepository)(nil).EditMaintenanceWindow), ctx, stationID, window)
+}
+
 // EditStation mocks base method.
 func (m *MockEVStationRepository) EditStation(ctx context.Context, domainModel models.EVStation) error {
 	m.ctrl.T.Helper()
@@ -109,6 +167,36 @@ func (mr *MockEVStationRepositoryMockRecorder) FindBookingsByUserName(ctx, usern
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingsByUserName", reflect.TypeOf((*MockEVStationRepository)(nil).FindBookingsByUserName), ctx, username)
 }
 
+// FindDeletedStationByID mocks base method.
+func (m *MockEVStationRepository) FindDeletedStationByID(ctx context.Context, id string) (*models0.EVStationDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedStationByID", ctx, id)
+	ret0, _ := ret[0].(*models0.EVStationDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedStationByID indicates an expected call of FindDeletedStationByID.
+func (mr *MockEVStationRepositoryMockRecorder) FindDeletedStationByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedStationByID", reflect.TypeOf((*MockEVStationRepository)(nil).FindDeletedStationByID), ctx, id)
+}
+
+// FindDeletedStations mocks base method.
+func (m *MockEVStationRepository) FindDeletedStations(ctx context.Context) ([]models0.EVStationDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedStations", ctx)
+	ret0, _ := ret[0].([]models0.EVStationDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedStations indicates an expected call of FindDeletedStations.
+func (mr *MockEVStationRepositoryMockRecorder) FindDeletedStations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedStations", reflect.TypeOf((*MockEVStationRepository)(nil).FindDeletedStations), ctx)
+}
+
 // FindStationByConnectorID mocks base method.
 func (m *MockEVStationRepository) FindStationByConnectorID(ctx context.Context, connectorID string) (*models0.EVStationDB, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +212,21 @@ func (mr *MockEVStationRepositoryMockRecorder) FindStationByConnectorID(ctx, con
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStationByConnectorID", reflect.TypeOf((*MockEVStationRepository)(nil).FindStationByConnectorID), ctx, connectorID)
 }
 
+// FindStationByExternalRef mocks base method.
+func (m *MockEVStationRepository) FindStationByExternalRef(ctx context.Context, externalRef string) (*models0.EVStationDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStationByExternalRef", ctx, externalRef)
+	ret0, _ := ret[0].(*models0.EVStationDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStationByExternalRef indicates an expected call of FindStationByExternalRef.
+func (mr *MockEVStationRepositoryMockRecorder) FindStationByExternalRef(ctx, externalRef interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStationByExternalRef", reflect.TypeOf((*MockEVStationRepository)(nil).FindStationByExternalRef), ctx, externalRef)
+}
+
 // FindStationByID mocks base method.
 func (m *MockEVStationRepository) FindStationByID(ctx context.Context, id string) (*models0.EVStationDB, error) {
 	m.ctrl.T.Helper()
@@ -154,33 +257,167 @@ func (mr *MockEVStationRepositoryMockRecorder) FindStationByUserName(ctx, userNa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStationByUserName", reflect.TypeOf((*MockEVStationRepository)(nil).FindStationByUserName), ctx, userName)
 }
 
+// FindStationRevision mocks base method.
+func (m *MockEVStationRepository) FindStationRevision(ctx context.Context, stationID string, version int64) (*models0.StationRevisionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStationRevision", ctx, stationID, version)
+	ret0, _ := ret[0].(*models0.StationRevisionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStationRevision indicates an expected call of FindStationRevision.
+func (mr *MockEVStationRepositoryMockRecorder) FindStationRevision(ctx, stationID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStationRevision", reflect.TypeOf((*MockEVStationRepository)(nil).FindStationRevision), ctx, stationID, version)
+}
+
+// FindStationRevisionAsOf mocks base method.
+func (m *MockEVStationRepository) FindStationRevisionAsOf(ctx context.Context, stationID string, asOf time.Time) (*models0.StationRevisionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStationRevisionAsOf", ctx, stationID, asOf)
+	ret0, _ := ret[0].(*models0.StationRevisionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStationRevisionAsOf indicates an expected call of FindStationRevisionAsOf.
+func (mr *MockEVStationRepositoryMockRecorder) FindStationRevisionAsOf(ctx, stationID, asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStationRevisionAsOf", reflect.TypeOf((*MockEVStationRepository)(nil).FindStationRevisionAsOf), ctx, stationID, asOf)
+}
+
+// FindStationRevisions mocks base method.
+func (m *MockEVStationRepository) FindStationRevisions(ctx context.Context, stationID string) ([]models0.StationRevisionDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStationRevisions", ctx, stationID)
+	ret0, _ := ret[0].([]models0.StationRevisionDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStationRevisions indicates an expected call of FindStationRevisions.
+func (mr *MockEVStationRepositoryMockRecorder) FindStationRevisions(ctx, stationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStationRevisions", reflect.TypeOf((*MockEVStationRepository)(nil).FindStationRevisions), ctx, stationID)
+}
+
 // FindStations mocks base method.
-func (m *MockEVStationRepository) FindStations(ctx context.Context, company, stationType, search, plugName string, isOpen *bool) ([]models0.EVStationDB, error) {
+func (m *MockEVStationRepository) FindStations(ctx context.Context, company, stationType, search, plugName string) ([]models0.EVStationDB, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindStations", ctx, company, stationType, search, plugName, isOpen)
+	ret := m.ctrl.Call(m, "FindStations", ctx, company, stationType, search, plugName)
 	ret0, _ := ret[0].([]models0.EVStationDB)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindStations indicates an expected call of FindStations.
-func (mr *MockEVStationRepositoryMockRecorder) FindStations(ctx, company, stationType, search, plugName, isOpen interface{}) *gomock.Call {
+func (mr *MockEVStationRepositoryMockRecorder) FindStations(ctx, company, stationType, search, plugName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStations", reflect.TypeOf((*MockEVStationRepository)(nil).FindStations), ctx, company, stationType, search, plugName)
+}
+
+// FindStationsInArea mocks base method.
+func (m *MockEVStationRepository) FindStationsInArea(ctx context.Context, minLatitude, maxLatitude, minLongitude, maxLongitude float64) ([]models0.EVStationDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStationsInArea", ctx, minLatitude, maxLatitude, minLongitude, maxLongitude)
+	ret0, _ := ret[0].([]models0.EVStationDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStationsInArea indicates an expected call of FindStationsInArea.
+func (mr *MockEVStationRepositoryMockRecorder) FindStationsInArea(ctx, minLatitude, maxLatitude, minLongitude, maxLongitude interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStations", reflect.TypeOf((*MockEVStationRepository)(nil).FindStations), ctx, company, stationType, search, plugName, isOpen)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStationsInArea", reflect.TypeOf((*MockEVStationRepository)(nil).FindStationsInArea), ctx, minLatitude, maxLatitude, minLongitude, maxLongitude)
 }
 
-// RemoveStation mocks base method.
-func (m *MockEVStationRepository) RemoveStation(ctx context.Context, id string) error {
+// FindStationsUpdatedBetween mocks base method.
+func (m *MockEVStationRepository) FindStationsUpdatedBetween(ctx context.Context, from, to *time.Time, offset, limit int64) ([]models0.EVStationDB, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveStation", ctx, id)
+	ret := m.ctrl.Call(m, "FindStationsUpdatedBetween", ctx, from, to, offset, limit)
+	ret0, _ := ret[0].([]models0.EVStationDB)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindStationsUpdatedBetween indicates an expected call of FindStationsUpdatedBetween.
+func (mr *MockEVStationRepositoryMockRecorder) FindStationsUpdatedBetween(ctx, from, to, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStationsUpdatedBetween", reflect.TypeOf((*MockEVStationRepository)(nil).FindStationsUpdatedBetween), ctx, from, to, offset, limit)
+}
+
+// MigrateLegacyOpeningHours mocks base method.
+func (m *MockEVStationRepository) MigrateLegacyOpeningHours(ctx context.Context, timeZone string, includeClosed bool) (*repository.OpeningHoursMigrationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateLegacyOpeningHours", ctx, timeZone, includeClosed)
+	ret0, _ := ret[0].(*repository.OpeningHoursMigrationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateLegacyOpeningHours indicates an expected call of MigrateLegacyOpeningHours.
+func (mr *MockEVStationRepositoryMockRecorder) MigrateLegacyOpeningHours(ctx, timeZone, includeClosed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateLegacyOpeningHours", reflect.TypeOf((*MockEVStationRepository)(nil).MigrateLegacyOpeningHours), ctx, timeZone, includeClosed)
+}
+
+// PurgeDeletedStations mocks base method.
+func (m *MockEVStationRepository) PurgeDeletedStations(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedStations", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedStations indicates an expected call of PurgeDeletedStations.
+func (mr *MockEVStationRepositoryMockRecorder) PurgeDeletedStations(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedStations", reflect.TypeOf((*MockEVStationRepository)(nil).PurgeDeletedStations), ctx, deletedBefore)
+}
+
+// RemoveConnector mocks base method.
+func (m *MockEVStationRepository) RemoveConnector(ctx context.Context, stationID string, version int64, connectorID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveConnector", ctx, stationID, version, connectorID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RemoveStation indicates an expected call of RemoveStation.
-func (mr *MockEVStationRepositoryMockRecorder) RemoveStation(ctx, id interface{}) *gomock.Call {
+// RemoveConnector indicates an expected call of RemoveConnector.
+func (mr *MockEVStationRepositoryMockRecorder) RemoveConnector(ctx, stationID, version, connectorID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveStation", reflect.TypeOf((*MockEVStationRepository)(nil).RemoveStation), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveConnector", reflect.TypeOf((*MockEVStationRepository)(nil).RemoveConnector), ctx, stationID, version, connectorID)
+}
+
+// RemoveMaintenanceWindow mocks base method.
+func (m *MockEVStationRepository) RemoveMaintenanceWindow(ctx context.Context, stationID, windowID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMaintenanceWindow", ctx, stationID, windowID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMaintenanceWindow indicates an expected call of RemoveMaintenanceWindow.
+func (mr *MockEVStationRepositoryMockRecorder) RemoveMaintenanceWindow(ctx, stationID, windowID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMaintenanceWindow", reflect.TypeOf((*MockEVStationRepository)(nil).RemoveMaintenanceWindow), ctx, stationID, windowID)
+}
+
+// RestoreStation mocks base method.
+func (m *MockEVStationRepository) RestoreStation(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreStation", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreStation indicates an expected call of RestoreStation.
+func (mr *MockEVStationRepositoryMockRecorder) RestoreStation(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreStation", reflect.TypeOf((*MockEVStationRepository)(nil).RestoreStation), ctx, id)
 }
 
 // SetBooking mocks base method.
@@ -196,3 +433,45 @@ func (mr *MockEVStationRepositoryMockRecorder) SetBooking(ctx, id, booking inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBooking", reflect.TypeOf((*MockEVStationRepository)(nil).SetBooking), ctx, id, booking)
 }
+
+// SoftDeleteStation mocks base method.
+func (m *MockEVStationRepository) SoftDeleteStation(ctx context.Context, id, deletedBy string, deletedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteStation", ctx, id, deletedBy, deletedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDeleteStation indicates an expected call of SoftDeleteStation.
+func (mr *MockEVStationRepositoryMockRecorder) SoftDeleteStation(ctx, id, deletedBy, deletedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteStation", reflect.TypeOf((*MockEVStationRepository)(nil).SoftDeleteStation), ctx, id, deletedBy, deletedAt)
+}
+
+// StreamStations mocks base method.
+func (m *MockEVStationRepository) StreamStations(ctx context.Context, company, stationType, search, plugName string, fn func(models0.EVStationDB) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamStations", ctx, company, stationType, search, plugName, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamStations indicates an expected call of StreamStations.
+func (mr *MockEVStationRepositoryMockRecorder) StreamStations(ctx, company, stationType, search, plugName, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamStations", reflect.TypeOf((*MockEVStationRepository)(nil).StreamStations), ctx, company, stationType, search, plugName, fn)
+}
+
+// UpdateStationFields mocks base method.
+func (m *MockEVStationRepository) UpdateStationFields(ctx context.Context, domainModel models.EVStation, fields []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStationFields", ctx, domainModel, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStationFields indicates an expected call of UpdateStationFields.
+func (mr *MockEVStationRepositoryMockRecorder) UpdateStationFields(ctx, domainModel, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStationFields", reflect.TypeOf((*MockEVStationRepository)(nil).UpdateStationFields), ctx, domainModel, fields)
+}
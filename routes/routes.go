@@ -1,36 +1,113 @@
 package routes
 
 import (
+	"Ev-Charge-Hub/Server/internal/constants"
 	"Ev-Charge-Hub/Server/internal/delivery/http"
 	"Ev-Charge-Hub/Server/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, userHandler http.UserHandlerInterface, stationHandler *http.EVStationHandler) {
+func SetupRoutes(router *gin.Engine, userHandler http.UserHandlerInterface, sessionHandler *http.SessionHandler, passwordResetHandler *http.PasswordResetHandler, emailVerificationHandler *http.EmailVerificationHandler, twoFactorHandler *http.TwoFactorHandler, stationHandler *http.EVStationHandler, vehicleHandler *http.VehicleHandler, maintenanceHandler *http.MaintenanceHandler, auditHandler *http.AuditHandler, ocpiHandler *http.OCPIHandler, ocpiAuth middleware.OCPITokenAuthenticator, tokenRevocations middleware.TokenRevocationChecker) {
 	userGroup := router.Group("/users")
 	{
 		userGroup.POST("/register", userHandler.RegisterUser)
 		userGroup.POST("/login", userHandler.LoginUser)
+		userGroup.POST("/refresh", sessionHandler.RefreshToken)
+		userGroup.POST("/logout", sessionHandler.Logout)
+		userGroup.POST("/logout-all", middleware.AuthMiddleware(tokenRevocations), sessionHandler.LogoutAll)
+		userGroup.POST("/password/forgot", passwordResetHandler.ForgotPassword)
+		userGroup.POST("/password/reset", passwordResetHandler.ResetPassword)
+		userGroup.GET("/verify", emailVerificationHandler.VerifyEmail)
+		userGroup.POST("/verify/resend", middleware.AuthMiddleware(tokenRevocations), emailVerificationHandler.ResendVerification)
+		userGroup.POST("/login/2fa", twoFactorHandler.VerifyLogin)
+		userGroup.POST("/login/2fa/setup", twoFactorHandler.SetupFromChallenge)
+
+		twoFactorGroup := userGroup.Group("/2fa", middleware.AuthMiddleware(tokenRevocations))
+		twoFactorGroup.POST("/setup", twoFactorHandler.SetupTOTP)
+		twoFactorGroup.POST("/activate", twoFactorHandler.ActivateTOTP)
+		twoFactorGroup.POST("/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+		twoFactorGroup.POST("/disable", twoFactorHandler.DisableTOTP)
 	}
 
 	stationGroup := router.Group("/stations")
 	{
-		stationGroup.Use(middleware.AuthMiddleware())
+		stationGroup.Use(middleware.AuthMiddleware(tokenRevocations), middleware.Authorize())
 		stationGroup.GET("/filter", stationHandler.FilterStations)
+		stationGroup.GET("/estimate", stationHandler.EstimateCharge)
+		stationGroup.GET("/export", stationHandler.ExportStations)
 		stationGroup.GET("/:id", stationHandler.GetStationByID)
 		stationGroup.PUT("/set-booking", stationHandler.SetBooking)
 		stationGroup.GET("", stationHandler.ShowAllStations)
 		stationGroup.POST("/create", stationHandler.CreateStation)
 		stationGroup.PUT("/:id", stationHandler.EditStation)
+		stationGroup.PATCH("/:id", stationHandler.PatchStation)
 		stationGroup.DELETE("/:id", stationHandler.RemoveStation)
+		stationGroup.GET("/trash", stationHandler.GetDeletedStations)
+		stationGroup.POST("/:id/restore", stationHandler.RestoreStation)
+		stationGroup.POST("/import", stationHandler.ImportStations)
+		stationGroup.GET("/:id/revisions", stationHandler.GetStationRevisions)
+		stationGroup.POST("/:id/revisions/:version/revert", stationHandler.RevertStation)
 		stationGroup.GET("/booking/:username", stationHandler.GetBookingByUserName)
 		stationGroup.GET("/bookings/:username", stationHandler.GetBookingsByUserName)	
 		stationGroup.GET("/connector/:connector_id", stationHandler.GetStationByConnectorID)
 		stationGroup.GET("/username/:username", stationHandler.GetStationByUserName)
+		stationGroup.POST("/:id/connectors", stationHandler.AddConnector)
+		stationGroup.POST("/:id/connectors/:connector_id", stationHandler.AddConnector)
+		stationGroup.PUT("/:id/connectors/:connector_id", stationHandler.EditConnector)
+		stationGroup.DELETE("/:id/connectors/:connector_id", stationHandler.RemoveConnector)
+
+		maintenanceGroup := stationGroup.Group("/:id/maintenance")
+		maintenanceGroup.GET("", maintenanceHandler.GetMaintenanceWindows)
+		maintenanceGroup.POST("", maintenanceHandler.CreateMaintenanceWindow)
+		maintenanceGroup.PUT("/:window_id", maintenanceHandler.EditMaintenanceWindow)
+		maintenanceGroup.DELETE("/:window_id", maintenanceHandler.RemoveMaintenanceWindow)
+	}
+	vehicleGroup := router.Group("/vehicles")
+	{
+		vehicleGroup.Use(middleware.AuthMiddleware(tokenRevocations), middleware.Authorize())
+		vehicleGroup.GET("/catalog", vehicleHandler.GetVehicleCatalog)
+		vehicleGroup.GET("", vehicleHandler.GetVehicles)
+		vehicleGroup.POST("", vehicleHandler.CreateVehicle)
+		vehicleGroup.GET("/:id", vehicleHandler.GetVehicleByID)
+		vehicleGroup.PUT("/:id", vehicleHandler.EditVehicle)
+		vehicleGroup.DELETE("/:id", vehicleHandler.RemoveVehicle)
+	}
+	adminGroup := router.Group("/admin")
+	{
+		adminGroup.Use(middleware.AuthMiddleware(tokenRevocations), middleware.Authorize())
+		adminGroup.GET("/audit", auditHandler.GetAuditEntries)
+		adminGroup.POST("/ocpi/partners", ocpiHandler.CreatePartner)
+		adminGroup.GET("/ocpi/partners", ocpiHandler.GetPartners)
+		adminGroup.POST("/users", userHandler.ProvisionUser)
+		adminGroup.GET("/users/admins", userHandler.GetAdminAccounts)
+		adminGroup.PUT("/users/:id/role", userHandler.ChangeUserRole)
+		adminGroup.POST("/users/:id/unlock", userHandler.UnlockUser)
 	}
+	// OCPI 2.2.1 for roaming partners; versions and credentials also accept the registration token
+	ocpiGroup := router.Group("/ocpi")
+	{
+		ocpiGroup.GET("/versions", middleware.OCPITokenAuth(ocpiAuth, false), ocpiHandler.GetVersions)
+		ocpiGroup.GET("/"+constants.OCPIVersion, middleware.OCPITokenAuth(ocpiAuth, false), ocpiHandler.GetVersionDetails)
+
+		credentialsGroup := ocpiGroup.Group("/" + constants.OCPIVersion + "/credentials")
+		credentialsGroup.Use(middleware.OCPITokenAuth(ocpiAuth, false))
+		credentialsGroup.GET("", ocpiHandler.GetCredentials)
+		credentialsGroup.POST("", ocpiHandler.PostCredentials)
+		credentialsGroup.PUT("", ocpiHandler.PutCredentials)
+		credentialsGroup.DELETE("", ocpiHandler.DeleteCredentials)
+
+		locationsGroup := ocpiGroup.Group("/" + constants.OCPIVersion + "/locations")
+		locationsGroup.Use(middleware.OCPITokenAuth(ocpiAuth, true))
+		locationsGroup.GET("", ocpiHandler.GetLocations)
+		locationsGroup.GET("/:location_id", ocpiHandler.GetLocation)
+		locationsGroup.GET("/:location_id/:evse_uid", ocpiHandler.GetLocation)
+		locationsGroup.GET("/:location_id/:evse_uid/:connector_id", ocpiHandler.GetLocation)
+	}
+	router.GET("/.well-known/jwks.json", http.JWKSHandler)
+
 	securityGroup := router.Group("/security")
 	{
-		securityGroup.GET("/validate-token", http.TokenValidationHandler)
+		securityGroup.GET("/validate-token", http.TokenValidationHandler(tokenRevocations))
 	}
 }
@@ -23,23 +23,59 @@ type Claims struct {
 }
 
 func CreateToken(userID, username string, role string) (string, error) {
+	return CreateAccessToken(userID, username, role, "", 24*time.Hour)
+}
+
+// CreateAccessToken signs a token that expires after ttl; jti identifies it for revocation
+func CreateAccessToken(userID, username string, role string, jti string, ttl time.Duration) (string, error) {
+	now := time.Now()
 	claims := Claims{
 		UserID:   userID,
 		Username: username,
 		Role:     role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
 		},
 	}
 
+	// the newest active asymmetric key signs; without one the shared secret still does, unless
+	// HS256 tokens would be rejected anyway
+	keySet := CurrentKeySet()
+	if key, ok := keySet.SigningKey(now); ok {
+		token := jwt.NewWithClaims(key.Method, claims)
+		token.Header["kid"] = key.ID
+		return token.SignedString(key.PrivateKey)
+	}
+	if !keySet.AcceptsHS256() {
+		return "", errors.New("no active JWT key to sign with and HS256 is disabled")
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(jwtSecret)
 }
 
 func ValidateToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
+	keySet := CurrentKeySet()
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return jwtSecret, nil
+		if token.Method == jwt.SigningMethodHS256 {
+			if !keySet.AcceptsHS256() {
+				return nil, errors.New("HS256 tokens are no longer accepted")
+			}
+			return jwtSecret, nil
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		key, ok := keySet.key(kid)
+		if !ok {
+			return nil, errors.New("unknown kid")
+		}
+		// the algorithm must match the key, so a token cannot pick a weaker one
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, errors.New("unexpected signing method")
+		}
+		return key.PublicKey, nil
 	})
 
 	if err != nil || !token.Valid {
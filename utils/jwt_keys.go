@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// minRSAKeyBits is the smallest RSA key accepted for signing or verification
+const minRSAKeyBits = 2048
+
+// JWTKey is one asymmetric key identified by its kid. Keys loaded from a public key PEM can only
+// verify; they are kept for tokens signed by a retired key or by another service.
+type JWTKey struct {
+	ID          string
+	Method      jwt.SigningMethod // RS256 or EdDSA, from the key type
+	PrivateKey  crypto.Signer     // nil for verify-only keys
+	PublicKey   crypto.PublicKey
+	ActivatesAt time.Time // the key signs new tokens from this time on; zero means immediately
+}
+
+// JWK is the public part of a key as published in the JWKS document (RFC 7517, RFC 8037)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// KeySet holds the asymmetric keys. The signing key is the private key activated most recently;
+// every key, including scheduled and retired ones, verifies tokens with its kid. With acceptHS256
+// tokens signed with JWT_SECRET are still accepted, for the transition away from the shared secret.
+type KeySet struct {
+	keys        []JWTKey
+	acceptHS256 bool
+}
+
+var (
+	keySetMu      sync.RWMutex
+	currentKeySet = &KeySet{acceptHS256: true}
+)
+
+// SetKeySet replaces the keys used by CreateAccessToken and ValidateToken
+func SetKeySet(keySet *KeySet) {
+	keySetMu.Lock()
+	defer keySetMu.Unlock()
+	currentKeySet = keySet
+}
+
+// CurrentKeySet returns the keys in use; without SetKeySet tokens are signed with JWT_SECRET
+func CurrentKeySet() *KeySet {
+	keySetMu.RLock()
+	defer keySetMu.RUnlock()
+	return currentKeySet
+}
+
+// NewKeySet checks that key IDs are unique and that a key can sign right away once HS256 is
+// turned off; a key scheduled for later would leave nothing to sign with until it activates
+func NewKeySet(keys []JWTKey, acceptHS256 bool) (*KeySet, error) {
+	now := time.Now()
+	seen := map[string]bool{}
+	canSign := false
+	for _, key := range keys {
+		canSign = canSign || (key.PrivateKey != nil && !key.ActivatesAt.After(now))
+		if key.ID == "" {
+			return nil, errors.New("JWT key without kid")
+		}
+		if seen[key.ID] {
+			return nil, fmt.Errorf("duplicate JWT kid %q", key.ID)
+		}
+		seen[key.ID] = true
+	}
+	if !acceptHS256 && !canSign {
+		return nil, errors.New("HS256 is disabled but no private key is active to sign tokens")
+	}
+
+	sorted := append([]JWTKey(nil), keys...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ActivatesAt.Before(sorted[j].ActivatesAt) })
+	return &KeySet{keys: sorted, acceptHS256: acceptHS256}, nil
+}
+
+// SigningKey returns the private key activated most recently before now
+func (ks *KeySet) SigningKey(now time.Time) (*JWTKey, bool) {
+	for i := len(ks.keys) - 1; i >= 0; i-- {
+		key := ks.keys[i]
+		if key.PrivateKey != nil && !key.ActivatesAt.After(now) {
+			return &key, true
+		}
+	}
+	return nil, false
+}
+
+// AcceptsHS256 reports whether tokens signed with the shared secret are still valid
+func (ks *KeySet) AcceptsHS256() bool {
+	return ks.acceptHS256
+}
+
+func (ks *KeySet) key(id string) (*JWTKey, bool) {
+	for i := range ks.keys {
+		if ks.keys[i].ID == id {
+			return &ks.keys[i], true
+		}
+	}
+	return nil, false
+}
+
+// JWKS lists the public keys, scheduled ones included so verifiers can cache them before use
+func (ks *KeySet) JWKS() []JWK {
+	jwks := make([]JWK, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		jwk := JWK{KeyID: key.ID, Use: "sig", Algorithm: key.Method.Alg()}
+		switch public := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks = append(jwks, jwk)
+	}
+	return jwks
+}
+
+// LoadJWTKey reads a PEM file holding an RSA or Ed25519 private key (PKCS#1 or PKCS#8) or public key (PKIX)
+func LoadJWTKey(id string, path string, activatesAt time.Time) (JWTKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return JWTKey{}, fmt.Errorf("JWT key %s: %v", id, err)
+	}
+	key, err := ParseJWTKey(id, data, activatesAt)
+	if err != nil {
+		return JWTKey{}, fmt.Errorf("JWT key %s (%s): %v", id, path, err)
+	}
+	return key, nil
+}
+
+// ParseJWTKey picks RS256 or EdDSA from the key type and rejects RSA keys under 2048 bits
+func ParseJWTKey(id string, pemData []byte, activatesAt time.Time) (JWTKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return JWTKey{}, errors.New("no PEM block found")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return JWTKey{}, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return JWTKey{}, err
+	}
+
+	key := JWTKey{ID: id, ActivatesAt: activatesAt}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.PrivateKey, key.PublicKey = k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.PublicKey = k
+	case ed25519.PrivateKey:
+		key.PrivateKey, key.PublicKey = k, k.Public()
+	case ed25519.PublicKey:
+		key.PublicKey = k
+	default:
+		return JWTKey{}, fmt.Errorf("unsupported key type %T, use RSA or Ed25519", parsed)
+	}
+
+	switch public := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < minRSAKeyBits {
+			return JWTKey{}, fmt.Errorf("RSA key is %d bits, at least %d are required", public.N.BitLen(), minRSAKeyBits)
+		}
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	}
+	return key, nil
+}